@@ -1,10 +1,12 @@
 package main
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/temirov/llm-proxy/internal/proxy"
 	"github.com/temirov/llm-proxy/internal/utils"
 )
 
@@ -32,6 +34,144 @@ func populateIntConfiguration(command *cobra.Command, flagName, configurationKey
 	}
 }
 
+// populateFloatConfiguration resolves a floating-point value from command flags and environment variables.
+// flagName specifies the CLI flag, configurationKey maps to the viper key, and destination receives the result.
+func populateFloatConfiguration(command *cobra.Command, flagName, configurationKey string, destination *float64) {
+	if !command.Flags().Changed(flagName) {
+		*destination = viper.GetFloat64(configurationKey)
+	}
+}
+
+// populateBoolConfiguration resolves a boolean value from command flags, environment variables and the flag default.
+// flagName specifies the CLI flag, configurationKey maps to the viper key, and destination receives the result.
+func populateBoolConfiguration(command *cobra.Command, flagName, configurationKey string, destination *bool) {
+	if !command.Flags().Changed(flagName) {
+		*destination = viper.GetBool(configurationKey)
+	}
+}
+
+// populateStringMapConfiguration resolves a map from a comma-separated list of name=value pairs supplied by
+// command flags or environment variables. configurationKey maps to the viper key, which already prefers an explicit
+// flag over the environment, and destination receives the parsed pairs. Entries without a separator or with a blank
+// name are ignored.
+func populateStringMapConfiguration(configurationKey string, destination *map[string]string) {
+	rawValue := viper.GetString(configurationKey)
+	parsedPairs := make(map[string]string)
+	for _, entry := range strings.Split(rawValue, listSeparator) {
+		name, value, found := strings.Cut(entry, pairSeparator)
+		if !found || utils.IsBlank(name) {
+			continue
+		}
+		parsedPairs[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	*destination = parsedPairs
+}
+
+// populatePairListConfiguration resolves a map from name=value entries whose values may contain commas, such as
+// prompts. Each repetition of the flag supplies one entry; the environment variable supplies one entry per line.
+// Entries without a separator or with a blank name are ignored.
+func populatePairListConfiguration(command *cobra.Command, flagName, configurationKey string, destination *map[string]string) {
+	var entries []string
+	if command.Flags().Changed(flagName) {
+		entries, _ = command.Flags().GetStringArray(flagName)
+	} else {
+		entries = strings.Split(viper.GetString(configurationKey), lineSeparator)
+	}
+	parsedPairs := make(map[string]string)
+	for _, entry := range entries {
+		name, value, found := strings.Cut(entry, pairSeparator)
+		if !found || utils.IsBlank(name) {
+			continue
+		}
+		parsedPairs[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	*destination = parsedPairs
+}
+
+// populateIntMapConfiguration resolves a map of integers from a comma-separated list of name=value pairs supplied by
+// command flags or environment variables. Entries ignored by populateStringMapConfiguration, and entries whose value
+// is not an integer, are skipped.
+func populateIntMapConfiguration(configurationKey string, destination *map[string]int) {
+	var stringPairs map[string]string
+	populateStringMapConfiguration(configurationKey, &stringPairs)
+	parsedPairs := make(map[string]int)
+	for name, value := range stringPairs {
+		if parsedValue, parseError := strconv.Atoi(value); parseError == nil {
+			parsedPairs[name] = parsedValue
+		}
+	}
+	*destination = parsedPairs
+}
+
+// populateModelPricingConfiguration resolves per-model token prices from a comma-separated list of
+// model=input:output pairs supplied by command flags or environment variables. Entries ignored by
+// populateStringMapConfiguration, and entries whose prices are missing or not numbers, are skipped.
+func populateModelPricingConfiguration(configurationKey string, destination *map[string]proxy.ModelPrice) {
+	var stringPairs map[string]string
+	populateStringMapConfiguration(configurationKey, &stringPairs)
+	parsedPrices := make(map[string]proxy.ModelPrice)
+	for name, value := range stringPairs {
+		inputValue, outputValue, found := strings.Cut(value, priceSeparator)
+		if !found {
+			continue
+		}
+		inputPrice, inputParseError := strconv.ParseFloat(strings.TrimSpace(inputValue), 64)
+		outputPrice, outputParseError := strconv.ParseFloat(strings.TrimSpace(outputValue), 64)
+		if inputParseError != nil || outputParseError != nil {
+			continue
+		}
+		parsedPrices[name] = proxy.ModelPrice{InputPerMillionTokens: inputPrice, OutputPerMillionTokens: outputPrice}
+	}
+	*destination = parsedPrices
+}
+
+// populateModelPoolsConfiguration resolves worker pools from a comma-separated list of
+// name=model|model:workers[:queue_size] entries supplied by command flags or environment variables. Entries ignored
+// by populateStringMapConfiguration, and entries whose counts are missing or not integers, are skipped.
+func populateModelPoolsConfiguration(configurationKey string, destination *map[string]proxy.ModelPool) {
+	var stringPairs map[string]string
+	populateStringMapConfiguration(configurationKey, &stringPairs)
+	parsedPools := make(map[string]proxy.ModelPool)
+	for name, value := range stringPairs {
+		poolFields := strings.Split(value, poolFieldSeparator)
+		if len(poolFields) < 2 || len(poolFields) > 3 {
+			continue
+		}
+		workerCount, workerParseError := strconv.Atoi(strings.TrimSpace(poolFields[1]))
+		if workerParseError != nil {
+			continue
+		}
+		modelPool := proxy.ModelPool{WorkerCount: workerCount}
+		if len(poolFields) == 3 {
+			queueSize, queueParseError := strconv.Atoi(strings.TrimSpace(poolFields[2]))
+			if queueParseError != nil {
+				continue
+			}
+			modelPool.QueueSize = queueSize
+		}
+		for _, modelIdentifier := range strings.Split(poolFields[0], modelSeparator) {
+			if !utils.IsBlank(modelIdentifier) {
+				modelPool.Models = append(modelPool.Models, strings.TrimSpace(modelIdentifier))
+			}
+		}
+		parsedPools[name] = modelPool
+	}
+	*destination = parsedPools
+}
+
+// populateStringListConfiguration resolves a comma-separated list supplied by command flags or environment variables.
+// configurationKey maps to the viper key and destination receives the trimmed, non-blank entries.
+func populateStringListConfiguration(configurationKey string, destination *[]string) {
+	var parsedEntries []string
+	for _, entry := range strings.Split(viper.GetString(configurationKey), listSeparator) {
+		if utils.IsBlank(entry) {
+			continue
+		}
+		parsedEntries = append(parsedEntries, strings.TrimSpace(entry))
+	}
+	*destination = parsedEntries
+}
+
 // identityTransformer returns the supplied value unchanged.
 func identityTransformer(value string) string {
 	return value
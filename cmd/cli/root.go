@@ -17,40 +17,271 @@ import (
 const (
 	envPrefix = "gpt"
 
-	keyOpenAIAPIKey               = "openai_api_key"
-	keyServiceSecret              = "service_secret"
-	keyLogLevel                   = "log_level"
-	keySystemPrompt               = "system_prompt"
-	keyWorkers                    = "workers"
-	keyQueueSize                  = "queue_size"
-	keyPort                       = "port"
-	keyRequestTimeoutSeconds      = "request_timeout_seconds"
-	keyUpstreamPollTimeoutSeconds = "upstream_poll_timeout_seconds"
-	keyMaxOutputTokens            = "max_output_tokens"
+	keyOpenAIAPIKey                     = "openai_api_key"
+	keyServiceSecret                    = "service_secret"
+	keyLogLevel                         = "log_level"
+	keySystemPrompt                     = "system_prompt"
+	keyWorkers                          = "workers"
+	keyQueueSize                        = "queue_size"
+	keyPort                             = "port"
+	keyRequestTimeoutSeconds            = "request_timeout_seconds"
+	keyUpstreamPollTimeoutSeconds       = "upstream_poll_timeout_seconds"
+	keyMaxOutputTokens                  = "max_output_tokens"
+	keyDisableSystemPromptOverride      = "disable_system_prompt_override"
+	keyPromptPrefixModels               = "prompt_prefix_models"
+	keyLogRedactedFields                = "log_redacted_fields"
+	keyDiskQueuePath                    = "disk_queue_path"
+	keyDiskQueueMaxEntries              = "disk_queue_max_entries"
+	keyAuditLogPath                     = "audit_log_path"
+	keyTrustedProxies                   = "trusted_proxies"
+	keyMaxFormattedBytes                = "max_formatted_bytes"
+	keyRejectDuplicateParams            = "reject_duplicate_params"
+	keyWarmupEnabled                    = "warmup_enabled"
+	keyWarmupFailureFatal               = "warmup_failure_fatal"
+	keyIncludeFinishReason              = "include_finish_reason"
+	keyRetryOnLengthTruncation          = "retry_on_length_truncation"
+	keyABTestModel                      = "ab_test_model"
+	keyABTestPercentage                 = "ab_test_percentage"
+	keyExposeUpstreamLatency            = "expose_upstream_latency"
+	keySynthesisBudgetFraction          = "synthesis_budget_fraction"
+	keyMaxOutputTokensCeiling           = "max_output_tokens_ceiling"
+	keySendRequestIDToUpstream          = "send_request_id_to_upstream"
+	keyTrimTrailingNewline              = "trim_trailing_newline"
+	keyExtractionStrategy               = "extraction_strategy"
+	keyServiceSecrets                   = "service_secrets"
+	keyRecentBufferSize                 = "recent_buffer_size"
+	keyStartupTimeoutSeconds            = "startup_timeout_seconds"
+	keyRateLimitPerSecond               = "rate_limit_per_second"
+	keyRateLimitBurst                   = "rate_limit_burst"
+	keyShutdownGraceSeconds             = "shutdown_grace_seconds"
+	keyResponseCacheSize                = "response_cache_size"
+	keyResponseCacheTTLSeconds          = "response_cache_ttl_seconds"
+	keyModelAliases                     = "model_aliases"
+	keyRejectFallbackAnswer             = "reject_fallback_answer"
+	keyModelRateLimits                  = "model_rate_limits"
+	keyAccessLogFormat                  = "access_log_format"
+	keyOpenAIBaseURL                    = "openai_base_url"
+	keyDisableFormatting                = "disable_formatting"
+	keyOpenAIOrganization               = "openai_organization"
+	keyOpenAIProject                    = "openai_project"
+	keyExposeVersionHeader              = "expose_version_header"
+	keyDefaultModel                     = "default_model"
+	keyRetryOnParseFailure              = "retry_on_parse_failure"
+	keyModelPricing                     = "model_pricing"
+	keyFallbackModels                   = "fallback_models"
+	keyReportCost                       = "report_cost"
+	keyRetryInitialIntervalMilliseconds = "retry_initial_interval_ms"
+	keyRetryMultiplier                  = "retry_multiplier"
+	keyRetryMaxElapsedMilliseconds      = "retry_max_elapsed_ms"
+	keyEnabledFormats                   = "enabled_formats"
+	keyCircuitBreakerFailureThreshold   = "circuit_breaker_failure_threshold"
+	keyCircuitBreakerWindowSeconds      = "circuit_breaker_window_seconds"
+	keyCircuitBreakerCooldownSeconds    = "circuit_breaker_cooldown_seconds"
+	keyStuckSessionPollThreshold        = "stuck_session_poll_threshold"
+	keyVerboseQueueFull                 = "verbose_queue_full"
+	keyMinRequestTimeout                = "min_request_timeout"
+	keyMaxRequestTimeout                = "max_request_timeout"
+	keyMaxPromptBytes                   = "max_prompt_bytes"
+	keyDegradeReasoningUnderLoad        = "degrade_reasoning_under_load"
+	keyDegradeReasoningQueueDepth       = "degrade_reasoning_queue_depth"
+	keyMirrorUpstreamStatus             = "mirror_upstream_status"
+	keyModelPools                       = "model_pools"
+	keyDeprecatedModels                 = "deprecated_models"
+	keyRedactPrompts                    = "redact_prompts"
+	keyMaxTools                         = "max_tools"
+	keyMaxConcurrentRequests            = "max_concurrent_requests"
+	keySkipModelValidation              = "skip_model_validation"
+	keyModelOutputTokenCeilings         = "model_output_token_ceilings"
+	keyExposeQueueWait                  = "expose_queue_wait"
+	keyPassthroughHeaders               = "passthrough_headers"
+	keyPromptPrefix                     = "prompt_prefix"
+	keyPromptSuffix                     = "prompt_suffix"
+	keyPollIntervalMillis               = "poll_interval_ms"
+	keyEnableCompression                = "enable_compression"
+	keyProvider                         = "provider"
+	keyAzureAPIVersion                  = "azure_api_version"
+	keySystemPromptTemplate             = "system_prompt_template"
 
-	flagOpenAIAPIKey        = keyOpenAIAPIKey
-	flagServiceSecret       = keyServiceSecret
-	flagLogLevel            = keyLogLevel
-	flagSystemPrompt        = keySystemPrompt
-	flagWorkers             = keyWorkers
-	flagQueueSize           = keyQueueSize
-	flagPort                = keyPort
-	flagRequestTimeout      = "request_timeout"
-	flagUpstreamPollTimeout = "upstream_poll_timeout"
-	flagMaxOutputTokens     = keyMaxOutputTokens
+	flagOpenAIAPIKey                     = keyOpenAIAPIKey
+	flagServiceSecret                    = keyServiceSecret
+	flagLogLevel                         = keyLogLevel
+	flagSystemPrompt                     = keySystemPrompt
+	flagWorkers                          = keyWorkers
+	flagQueueSize                        = keyQueueSize
+	flagPort                             = keyPort
+	flagRequestTimeout                   = "request_timeout"
+	flagUpstreamPollTimeout              = "upstream_poll_timeout"
+	flagMaxOutputTokens                  = keyMaxOutputTokens
+	flagDisableSystemPromptOverride      = keyDisableSystemPromptOverride
+	flagPromptPrefixModels               = keyPromptPrefixModels
+	flagLogRedactedFields                = keyLogRedactedFields
+	flagDiskQueuePath                    = keyDiskQueuePath
+	flagDiskQueueMaxEntries              = keyDiskQueueMaxEntries
+	flagAuditLogPath                     = keyAuditLogPath
+	flagTrustedProxies                   = keyTrustedProxies
+	flagMaxFormattedBytes                = keyMaxFormattedBytes
+	flagRejectDuplicateParams            = keyRejectDuplicateParams
+	flagWarmupEnabled                    = keyWarmupEnabled
+	flagWarmupFailureFatal               = keyWarmupFailureFatal
+	flagIncludeFinishReason              = keyIncludeFinishReason
+	flagRetryOnLengthTruncation          = keyRetryOnLengthTruncation
+	flagABTestModel                      = keyABTestModel
+	flagABTestPercentage                 = keyABTestPercentage
+	flagExposeUpstreamLatency            = keyExposeUpstreamLatency
+	flagSynthesisBudgetFraction          = keySynthesisBudgetFraction
+	flagMaxOutputTokensCeiling           = keyMaxOutputTokensCeiling
+	flagSendRequestIDToUpstream          = keySendRequestIDToUpstream
+	flagTrimTrailingNewline              = keyTrimTrailingNewline
+	flagExtractionStrategy               = keyExtractionStrategy
+	flagServiceSecrets                   = keyServiceSecrets
+	flagRecentBufferSize                 = keyRecentBufferSize
+	flagStartupTimeoutSeconds            = keyStartupTimeoutSeconds
+	flagRateLimitPerSecond               = keyRateLimitPerSecond
+	flagRateLimitBurst                   = keyRateLimitBurst
+	flagShutdownGraceSeconds             = keyShutdownGraceSeconds
+	flagResponseCacheSize                = keyResponseCacheSize
+	flagResponseCacheTTLSeconds          = keyResponseCacheTTLSeconds
+	flagModelAliases                     = keyModelAliases
+	flagRejectFallbackAnswer             = keyRejectFallbackAnswer
+	flagModelRateLimits                  = keyModelRateLimits
+	flagAccessLogFormat                  = keyAccessLogFormat
+	flagOpenAIBaseURL                    = keyOpenAIBaseURL
+	flagDisableFormatting                = keyDisableFormatting
+	flagOpenAIOrganization               = keyOpenAIOrganization
+	flagOpenAIProject                    = keyOpenAIProject
+	flagExposeVersionHeader              = keyExposeVersionHeader
+	flagDefaultModel                     = keyDefaultModel
+	flagRetryOnParseFailure              = keyRetryOnParseFailure
+	flagModelPricing                     = keyModelPricing
+	flagFallbackModels                   = keyFallbackModels
+	flagReportCost                       = keyReportCost
+	flagRetryInitialIntervalMilliseconds = keyRetryInitialIntervalMilliseconds
+	flagRetryMultiplier                  = keyRetryMultiplier
+	flagRetryMaxElapsedMilliseconds      = keyRetryMaxElapsedMilliseconds
+	flagEnabledFormats                   = keyEnabledFormats
+	flagCircuitBreakerFailureThreshold   = keyCircuitBreakerFailureThreshold
+	flagCircuitBreakerWindowSeconds      = keyCircuitBreakerWindowSeconds
+	flagCircuitBreakerCooldownSeconds    = keyCircuitBreakerCooldownSeconds
+	flagStuckSessionPollThreshold        = keyStuckSessionPollThreshold
+	flagVerboseQueueFull                 = keyVerboseQueueFull
+	flagMinRequestTimeout                = keyMinRequestTimeout
+	flagMaxRequestTimeout                = keyMaxRequestTimeout
+	flagMaxPromptBytes                   = keyMaxPromptBytes
+	flagDegradeReasoningUnderLoad        = keyDegradeReasoningUnderLoad
+	flagDegradeReasoningQueueDepth       = keyDegradeReasoningQueueDepth
+	flagMirrorUpstreamStatus             = keyMirrorUpstreamStatus
+	flagModelPools                       = keyModelPools
+	flagDeprecatedModels                 = keyDeprecatedModels
+	flagRedactPrompts                    = keyRedactPrompts
+	flagMaxTools                         = keyMaxTools
+	flagMaxConcurrentRequests            = keyMaxConcurrentRequests
+	flagSkipModelValidation              = keySkipModelValidation
+	flagModelOutputTokenCeilings         = keyModelOutputTokenCeilings
+	flagExposeQueueWait                  = keyExposeQueueWait
+	flagPassthroughHeaders               = keyPassthroughHeaders
+	flagPromptPrefix                     = keyPromptPrefix
+	flagPromptSuffix                     = keyPromptSuffix
+	flagPollIntervalMillis               = keyPollIntervalMillis
+	flagEnableCompression                = keyEnableCompression
+	flagProvider                         = keyProvider
+	flagAzureAPIVersion                  = keyAzureAPIVersion
+	flagSystemPromptTemplate             = keySystemPromptTemplate
 
-	envOpenAIAPIKey               = "OPENAI_API_KEY"
-	envServiceSecret              = "SERVICE_SECRET"
-	envLogLevel                   = "LOG_LEVEL"
-	envSystemPrompt               = "SYSTEM_PROMPT"
-	envWorkers                    = "GPT_WORKERS"
-	envQueueSize                  = "GPT_QUEUE_SIZE"
-	envPort                       = "HTTP_PORT"
-	envRequestTimeoutSeconds      = "GPT_REQUEST_TIMEOUT_SECONDS"
-	envUpstreamPollTimeoutSeconds = "GPT_UPSTREAM_POLL_TIMEOUT_SECONDS"
-	envMaxOutputTokens            = "GPT_MAX_OUTPUT_TOKENS"
+	envOpenAIAPIKey                     = "OPENAI_API_KEY"
+	envServiceSecret                    = "SERVICE_SECRET"
+	envLogLevel                         = "LOG_LEVEL"
+	envSystemPrompt                     = "SYSTEM_PROMPT"
+	envWorkers                          = "GPT_WORKERS"
+	envQueueSize                        = "GPT_QUEUE_SIZE"
+	envPort                             = "HTTP_PORT"
+	envRequestTimeoutSeconds            = "GPT_REQUEST_TIMEOUT_SECONDS"
+	envUpstreamPollTimeoutSeconds       = "GPT_UPSTREAM_POLL_TIMEOUT_SECONDS"
+	envMaxOutputTokens                  = "GPT_MAX_OUTPUT_TOKENS"
+	envDisableSystemPromptOverride      = "GPT_DISABLE_SYSTEM_PROMPT_OVERRIDE"
+	envPromptPrefixModels               = "GPT_PROMPT_PREFIX_MODELS"
+	envLogRedactedFields                = "GPT_LOG_REDACTED_FIELDS"
+	envDiskQueuePath                    = "GPT_DISK_QUEUE_PATH"
+	envDiskQueueMaxEntries              = "GPT_DISK_QUEUE_MAX_ENTRIES"
+	envAuditLogPath                     = "GPT_AUDIT_LOG_PATH"
+	envTrustedProxies                   = "GPT_TRUSTED_PROXIES"
+	envMaxFormattedBytes                = "GPT_MAX_FORMATTED_BYTES"
+	envRejectDuplicateParams            = "GPT_REJECT_DUPLICATE_PARAMS"
+	envWarmupEnabled                    = "GPT_WARMUP_ENABLED"
+	envWarmupFailureFatal               = "GPT_WARMUP_FAILURE_FATAL"
+	envIncludeFinishReason              = "GPT_INCLUDE_FINISH_REASON"
+	envRetryOnLengthTruncation          = "GPT_RETRY_ON_LENGTH_TRUNCATION"
+	envABTestModel                      = "GPT_AB_TEST_MODEL"
+	envABTestPercentage                 = "GPT_AB_TEST_PERCENTAGE"
+	envExposeUpstreamLatency            = "GPT_EXPOSE_UPSTREAM_LATENCY"
+	envSynthesisBudgetFraction          = "GPT_SYNTHESIS_BUDGET_FRACTION"
+	envMaxOutputTokensCeiling           = "GPT_MAX_OUTPUT_TOKENS_CEILING"
+	envSendRequestIDToUpstream          = "GPT_SEND_REQUEST_ID_TO_UPSTREAM"
+	envTrimTrailingNewline              = "GPT_TRIM_TRAILING_NEWLINE"
+	envExtractionStrategy               = "GPT_EXTRACTION_STRATEGY"
+	envServiceSecrets                   = "SERVICE_SECRETS"
+	envRecentBufferSize                 = "GPT_RECENT_BUFFER_SIZE"
+	envStartupTimeoutSeconds            = "GPT_STARTUP_TIMEOUT_SECONDS"
+	envRateLimitPerSecond               = "GPT_RATE_LIMIT_PER_SECOND"
+	envRateLimitBurst                   = "GPT_RATE_LIMIT_BURST"
+	envShutdownGraceSeconds             = "GPT_SHUTDOWN_GRACE_SECONDS"
+	envResponseCacheSize                = "GPT_RESPONSE_CACHE_SIZE"
+	envResponseCacheTTLSeconds          = "GPT_RESPONSE_CACHE_TTL_SECONDS"
+	envModelAliases                     = "GPT_MODEL_ALIASES"
+	envRejectFallbackAnswer             = "GPT_REJECT_FALLBACK_ANSWER"
+	envModelRateLimits                  = "GPT_MODEL_RATE_LIMITS"
+	envAccessLogFormat                  = "GPT_ACCESS_LOG_FORMAT"
+	envOpenAIBaseURL                    = "OPENAI_BASE_URL"
+	envDisableFormatting                = "GPT_DISABLE_FORMATTING"
+	envOpenAIOrganization               = "OPENAI_ORGANIZATION"
+	envOpenAIProject                    = "OPENAI_PROJECT"
+	envExposeVersionHeader              = "GPT_EXPOSE_VERSION_HEADER"
+	envDefaultModel                     = "GPT_DEFAULT_MODEL"
+	envRetryOnParseFailure              = "GPT_RETRY_ON_PARSE_FAILURE"
+	envModelPricing                     = "GPT_MODEL_PRICING"
+	envFallbackModels                   = "GPT_FALLBACK_MODELS"
+	envReportCost                       = "GPT_REPORT_COST"
+	envRetryInitialIntervalMilliseconds = "GPT_RETRY_INITIAL_INTERVAL_MS"
+	envRetryMultiplier                  = "GPT_RETRY_MULTIPLIER"
+	envRetryMaxElapsedMilliseconds      = "GPT_RETRY_MAX_ELAPSED_MS"
+	envEnabledFormats                   = "GPT_ENABLED_FORMATS"
+	envCircuitBreakerFailureThreshold   = "GPT_CIRCUIT_BREAKER_FAILURE_THRESHOLD"
+	envCircuitBreakerWindowSeconds      = "GPT_CIRCUIT_BREAKER_WINDOW_SECONDS"
+	envCircuitBreakerCooldownSeconds    = "GPT_CIRCUIT_BREAKER_COOLDOWN_SECONDS"
+	envStuckSessionPollThreshold        = "GPT_STUCK_SESSION_POLL_THRESHOLD"
+	envVerboseQueueFull                 = "GPT_VERBOSE_QUEUE_FULL"
+	envMinRequestTimeout                = "GPT_MIN_REQUEST_TIMEOUT_SECONDS"
+	envMaxRequestTimeout                = "GPT_MAX_REQUEST_TIMEOUT_SECONDS"
+	envMaxPromptBytes                   = "GPT_MAX_PROMPT_BYTES"
+	envDegradeReasoningUnderLoad        = "GPT_DEGRADE_REASONING_UNDER_LOAD"
+	envDegradeReasoningQueueDepth       = "GPT_DEGRADE_REASONING_QUEUE_DEPTH"
+	envMirrorUpstreamStatus             = "GPT_MIRROR_UPSTREAM_STATUS"
+	envModelPools                       = "GPT_MODEL_POOLS"
+	envDeprecatedModels                 = "GPT_DEPRECATED_MODELS"
+	envRedactPrompts                    = "GPT_REDACT_PROMPTS"
+	envMaxTools                         = "GPT_MAX_TOOLS"
+	envMaxConcurrentRequests            = "GPT_MAX_CONCURRENT_REQUESTS"
+	envSkipModelValidation              = "GPT_SKIP_MODEL_VALIDATION"
+	envModelOutputTokenCeilings         = "GPT_MODEL_OUTPUT_TOKEN_CEILINGS"
+	envExposeQueueWait                  = "GPT_EXPOSE_QUEUE_WAIT"
+	envPassthroughHeaders               = "GPT_PASSTHROUGH_HEADERS"
+	envPromptPrefix                     = "GPT_PROMPT_PREFIX"
+	envPromptSuffix                     = "GPT_PROMPT_SUFFIX"
+	envPollIntervalMillis               = "GPT_POLL_INTERVAL_MS"
+	envEnableCompression                = "GPT_ENABLE_COMPRESSION"
+	envProvider                         = "GPT_PROVIDER"
+	envAzureAPIVersion                  = "GPT_AZURE_API_VERSION"
+	envSystemPromptTemplate             = "GPT_SYSTEM_PROMPT_TEMPLATES"
 
 	quoteCharacters = "\"'"
+	listSeparator   = ","
+	pairSeparator   = "="
+	// lineSeparator separates the entries of list settings whose values may contain commas.
+	lineSeparator  = "\n"
+	priceSeparator = ":"
+	// poolFieldSeparator separates the models, worker count and queue size of one model pool entry.
+	poolFieldSeparator = ":"
+	// modelSeparator separates the models of one model pool entry.
+	modelSeparator = "|"
 )
 
 const (
@@ -109,6 +340,80 @@ var rootCmd = &cobra.Command{
 		populateIntConfiguration(command, flagRequestTimeout, keyRequestTimeoutSeconds, &config.RequestTimeoutSeconds, proxy.DefaultRequestTimeoutSeconds)
 		populateIntConfiguration(command, flagUpstreamPollTimeout, keyUpstreamPollTimeoutSeconds, &config.UpstreamPollTimeoutSeconds, proxy.DefaultUpstreamPollTimeoutSeconds)
 		populateIntConfiguration(command, flagMaxOutputTokens, keyMaxOutputTokens, &config.MaxOutputTokens, proxy.DefaultMaxOutputTokens)
+		populateBoolConfiguration(command, flagDisableSystemPromptOverride, keyDisableSystemPromptOverride, &config.DisableSystemPromptOverride)
+		populateStringMapConfiguration(keyPromptPrefixModels, &config.PromptPrefixModelMap)
+		populateStringListConfiguration(keyLogRedactedFields, &config.LogRedactedFields)
+		populateStringConfiguration(command, flagDiskQueuePath, keyDiskQueuePath, &config.DiskQueuePath, constants.EmptyString, identityTransformer)
+		populateIntConfiguration(command, flagDiskQueueMaxEntries, keyDiskQueueMaxEntries, &config.DiskQueueMaxEntries, proxy.DefaultDiskQueueMaxEntries)
+		populateStringConfiguration(command, flagAuditLogPath, keyAuditLogPath, &config.AuditLogPath, constants.EmptyString, identityTransformer)
+		populateStringListConfiguration(keyTrustedProxies, &config.TrustedProxies)
+		populateIntConfiguration(command, flagMaxFormattedBytes, keyMaxFormattedBytes, &config.MaxFormattedBytes, 0)
+		populateBoolConfiguration(command, flagRejectDuplicateParams, keyRejectDuplicateParams, &config.RejectDuplicateParams)
+		populateBoolConfiguration(command, flagWarmupEnabled, keyWarmupEnabled, &config.WarmupEnabled)
+		populateBoolConfiguration(command, flagWarmupFailureFatal, keyWarmupFailureFatal, &config.WarmupFailureFatal)
+		populateBoolConfiguration(command, flagIncludeFinishReason, keyIncludeFinishReason, &config.IncludeFinishReason)
+		populateBoolConfiguration(command, flagRetryOnLengthTruncation, keyRetryOnLengthTruncation, &config.RetryOnLengthTruncation)
+		populateStringConfiguration(command, flagABTestModel, keyABTestModel, &config.ABTestModel, constants.EmptyString, identityTransformer)
+		populateIntConfiguration(command, flagABTestPercentage, keyABTestPercentage, &config.ABTestPercentage, 0)
+		populateBoolConfiguration(command, flagExposeUpstreamLatency, keyExposeUpstreamLatency, &config.ExposeUpstreamLatency)
+		populateFloatConfiguration(command, flagSynthesisBudgetFraction, keySynthesisBudgetFraction, &config.SynthesisBudgetFraction)
+		populateIntConfiguration(command, flagMaxOutputTokensCeiling, keyMaxOutputTokensCeiling, &config.MaxOutputTokensCeiling, proxy.DefaultMaxOutputTokensCeiling)
+		populateBoolConfiguration(command, flagSendRequestIDToUpstream, keySendRequestIDToUpstream, &config.SendRequestIDToUpstream)
+		populateBoolConfiguration(command, flagTrimTrailingNewline, keyTrimTrailingNewline, &config.TrimTrailingNewline)
+		populateStringConfiguration(command, flagExtractionStrategy, keyExtractionStrategy, &config.ExtractionStrategy, proxy.ExtractionStrategyOutputTextFirst, identityTransformer)
+		populateStringListConfiguration(keyServiceSecrets, &config.ServiceSecrets)
+		populateIntConfiguration(command, flagRecentBufferSize, keyRecentBufferSize, &config.RecentBufferSize, 0)
+		populateIntConfiguration(command, flagStartupTimeoutSeconds, keyStartupTimeoutSeconds, &config.StartupTimeoutSeconds, 0)
+		populateFloatConfiguration(command, flagRateLimitPerSecond, keyRateLimitPerSecond, &config.RateLimitPerSecond)
+		populateIntConfiguration(command, flagRateLimitBurst, keyRateLimitBurst, &config.RateLimitBurst, 0)
+		populateIntConfiguration(command, flagShutdownGraceSeconds, keyShutdownGraceSeconds, &config.ShutdownGraceSeconds, proxy.DefaultShutdownGraceSeconds)
+		populateIntConfiguration(command, flagResponseCacheSize, keyResponseCacheSize, &config.ResponseCacheSize, 0)
+		populateIntConfiguration(command, flagResponseCacheTTLSeconds, keyResponseCacheTTLSeconds, &config.ResponseCacheTTLSeconds, proxy.DefaultResponseCacheTTLSeconds)
+		populateStringMapConfiguration(keyModelAliases, &config.ModelAliases)
+		populateBoolConfiguration(command, flagRejectFallbackAnswer, keyRejectFallbackAnswer, &config.RejectFallbackAnswer)
+		populateIntMapConfiguration(keyModelRateLimits, &config.ModelRateLimits)
+		populateStringConfiguration(command, flagAccessLogFormat, keyAccessLogFormat, &config.AccessLogFormat, proxy.AccessLogFormatJSON, identityTransformer)
+		populateStringConfiguration(command, flagOpenAIBaseURL, keyOpenAIBaseURL, &config.OpenAIBaseURL, constants.EmptyString, trimSpacesAndQuotes)
+		populateBoolConfiguration(command, flagDisableFormatting, keyDisableFormatting, &config.DisableFormatting)
+		populateStringConfiguration(command, flagOpenAIOrganization, keyOpenAIOrganization, &config.OpenAIOrganization, constants.EmptyString, trimSpacesAndQuotes)
+		populateStringConfiguration(command, flagOpenAIProject, keyOpenAIProject, &config.OpenAIProject, constants.EmptyString, trimSpacesAndQuotes)
+		populateBoolConfiguration(command, flagExposeVersionHeader, keyExposeVersionHeader, &config.ExposeVersionHeader)
+		populateStringConfiguration(command, flagDefaultModel, keyDefaultModel, &config.DefaultModel, proxy.DefaultModel, identityTransformer)
+		populateBoolConfiguration(command, flagRetryOnParseFailure, keyRetryOnParseFailure, &config.RetryOnParseFailure)
+		populateModelPricingConfiguration(keyModelPricing, &config.ModelPricing)
+		populateStringListConfiguration(keyFallbackModels, &config.FallbackModels)
+		populateBoolConfiguration(command, flagReportCost, keyReportCost, &config.ReportCost)
+		populateIntConfiguration(command, flagRetryInitialIntervalMilliseconds, keyRetryInitialIntervalMilliseconds, &config.RetryInitialIntervalMilliseconds, 0)
+		populateFloatConfiguration(command, flagRetryMultiplier, keyRetryMultiplier, &config.RetryMultiplier)
+		populateIntConfiguration(command, flagRetryMaxElapsedMilliseconds, keyRetryMaxElapsedMilliseconds, &config.RetryMaxElapsedMilliseconds, 0)
+		populateStringListConfiguration(keyEnabledFormats, &config.EnabledFormats)
+		populateIntConfiguration(command, flagCircuitBreakerFailureThreshold, keyCircuitBreakerFailureThreshold, &config.CircuitBreakerFailureThreshold, 0)
+		populateIntConfiguration(command, flagCircuitBreakerWindowSeconds, keyCircuitBreakerWindowSeconds, &config.CircuitBreakerWindowSeconds, proxy.DefaultCircuitBreakerWindowSeconds)
+		populateIntConfiguration(command, flagCircuitBreakerCooldownSeconds, keyCircuitBreakerCooldownSeconds, &config.CircuitBreakerCooldownSeconds, proxy.DefaultCircuitBreakerCooldownSeconds)
+		populateIntConfiguration(command, flagStuckSessionPollThreshold, keyStuckSessionPollThreshold, &config.StuckSessionPollThreshold, 0)
+		populateBoolConfiguration(command, flagVerboseQueueFull, keyVerboseQueueFull, &config.VerboseQueueFull)
+		populateIntConfiguration(command, flagMinRequestTimeout, keyMinRequestTimeout, &config.MinRequestTimeoutSeconds, proxy.DefaultMinRequestTimeoutSeconds)
+		populateIntConfiguration(command, flagMaxRequestTimeout, keyMaxRequestTimeout, &config.MaxRequestTimeoutSeconds, proxy.DefaultMaxRequestTimeoutSeconds)
+		populateIntConfiguration(command, flagMaxPromptBytes, keyMaxPromptBytes, &config.MaxPromptBytes, 0)
+		populateBoolConfiguration(command, flagDegradeReasoningUnderLoad, keyDegradeReasoningUnderLoad, &config.DegradeReasoningUnderLoad)
+		populateIntConfiguration(command, flagDegradeReasoningQueueDepth, keyDegradeReasoningQueueDepth, &config.DegradeReasoningQueueDepth, 0)
+		populateBoolConfiguration(command, flagMirrorUpstreamStatus, keyMirrorUpstreamStatus, &config.MirrorUpstreamStatus)
+		populateModelPoolsConfiguration(keyModelPools, &config.ModelPools)
+		populateStringListConfiguration(keyDeprecatedModels, &config.DeprecatedModels)
+		populateBoolConfiguration(command, flagRedactPrompts, keyRedactPrompts, &config.RedactPrompts)
+		populateIntConfiguration(command, flagMaxTools, keyMaxTools, &config.MaxTools, proxy.DefaultMaxTools)
+		populateIntConfiguration(command, flagMaxConcurrentRequests, keyMaxConcurrentRequests, &config.MaxConcurrentRequests, 0)
+		populateBoolConfiguration(command, flagSkipModelValidation, keySkipModelValidation, &config.SkipModelValidation)
+		populateIntMapConfiguration(keyModelOutputTokenCeilings, &config.ModelOutputTokenCeilings)
+		populateBoolConfiguration(command, flagExposeQueueWait, keyExposeQueueWait, &config.ExposeQueueWait)
+		populateStringListConfiguration(keyPassthroughHeaders, &config.PassthroughHeaders)
+		populateStringConfiguration(command, flagPromptPrefix, keyPromptPrefix, &config.PromptPrefix, constants.EmptyString, identityTransformer)
+		populateStringConfiguration(command, flagPromptSuffix, keyPromptSuffix, &config.PromptSuffix, constants.EmptyString, identityTransformer)
+		populateIntConfiguration(command, flagPollIntervalMillis, keyPollIntervalMillis, &config.PollIntervalMillis, proxy.DefaultPollIntervalMillis)
+		populateBoolConfiguration(command, flagEnableCompression, keyEnableCompression, &config.EnableCompression)
+		populateStringConfiguration(command, flagProvider, keyProvider, &config.Provider, proxy.ProviderOpenAI, identityTransformer)
+		populateStringConfiguration(command, flagAzureAPIVersion, keyAzureAPIVersion, &config.AzureAPIVersion, proxy.DefaultAzureAPIVersion, trimSpacesAndQuotes)
+		populatePairListConfiguration(command, flagSystemPromptTemplate, keySystemPromptTemplate, &config.SystemPromptTemplates)
 
 		var logger *zap.Logger
 		var loggerError error
@@ -137,6 +442,7 @@ var rootCmd = &cobra.Command{
 			"port", config.Port,
 			"log_level", strings.ToLower(config.LogLevel),
 			"secret_fingerprint", utils.Fingerprint(config.ServiceSecret),
+			"additional_secret_count", len(config.ServiceSecrets),
 		)
 		return proxy.Serve(config, sugar)
 	},
@@ -175,6 +481,228 @@ func bindOrDie() error {
 	if bindError := viper.BindEnv(keyMaxOutputTokens, envMaxOutputTokens); bindError != nil {
 		bindingErrors = append(bindingErrors, keyMaxOutputTokens+":"+bindError.Error())
 	}
+	if bindError := viper.BindEnv(keyDisableSystemPromptOverride, envDisableSystemPromptOverride); bindError != nil {
+		bindingErrors = append(bindingErrors, keyDisableSystemPromptOverride+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyPromptPrefixModels, envPromptPrefixModels); bindError != nil {
+		bindingErrors = append(bindingErrors, keyPromptPrefixModels+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyLogRedactedFields, envLogRedactedFields); bindError != nil {
+		bindingErrors = append(bindingErrors, keyLogRedactedFields+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyDiskQueuePath, envDiskQueuePath); bindError != nil {
+		bindingErrors = append(bindingErrors, keyDiskQueuePath+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyDiskQueueMaxEntries, envDiskQueueMaxEntries); bindError != nil {
+		bindingErrors = append(bindingErrors, keyDiskQueueMaxEntries+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyAuditLogPath, envAuditLogPath); bindError != nil {
+		bindingErrors = append(bindingErrors, keyAuditLogPath+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyTrustedProxies, envTrustedProxies); bindError != nil {
+		bindingErrors = append(bindingErrors, keyTrustedProxies+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyMaxFormattedBytes, envMaxFormattedBytes); bindError != nil {
+		bindingErrors = append(bindingErrors, keyMaxFormattedBytes+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyRejectDuplicateParams, envRejectDuplicateParams); bindError != nil {
+		bindingErrors = append(bindingErrors, keyRejectDuplicateParams+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyWarmupEnabled, envWarmupEnabled); bindError != nil {
+		bindingErrors = append(bindingErrors, keyWarmupEnabled+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyWarmupFailureFatal, envWarmupFailureFatal); bindError != nil {
+		bindingErrors = append(bindingErrors, keyWarmupFailureFatal+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyIncludeFinishReason, envIncludeFinishReason); bindError != nil {
+		bindingErrors = append(bindingErrors, keyIncludeFinishReason+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyRetryOnLengthTruncation, envRetryOnLengthTruncation); bindError != nil {
+		bindingErrors = append(bindingErrors, keyRetryOnLengthTruncation+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyABTestModel, envABTestModel); bindError != nil {
+		bindingErrors = append(bindingErrors, keyABTestModel+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyABTestPercentage, envABTestPercentage); bindError != nil {
+		bindingErrors = append(bindingErrors, keyABTestPercentage+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyExposeUpstreamLatency, envExposeUpstreamLatency); bindError != nil {
+		bindingErrors = append(bindingErrors, keyExposeUpstreamLatency+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keySynthesisBudgetFraction, envSynthesisBudgetFraction); bindError != nil {
+		bindingErrors = append(bindingErrors, keySynthesisBudgetFraction+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyMaxOutputTokensCeiling, envMaxOutputTokensCeiling); bindError != nil {
+		bindingErrors = append(bindingErrors, keyMaxOutputTokensCeiling+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keySendRequestIDToUpstream, envSendRequestIDToUpstream); bindError != nil {
+		bindingErrors = append(bindingErrors, keySendRequestIDToUpstream+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyTrimTrailingNewline, envTrimTrailingNewline); bindError != nil {
+		bindingErrors = append(bindingErrors, keyTrimTrailingNewline+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyExtractionStrategy, envExtractionStrategy); bindError != nil {
+		bindingErrors = append(bindingErrors, keyExtractionStrategy+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyServiceSecrets, envServiceSecrets); bindError != nil {
+		bindingErrors = append(bindingErrors, keyServiceSecrets+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyRecentBufferSize, envRecentBufferSize); bindError != nil {
+		bindingErrors = append(bindingErrors, keyRecentBufferSize+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyStartupTimeoutSeconds, envStartupTimeoutSeconds); bindError != nil {
+		bindingErrors = append(bindingErrors, keyStartupTimeoutSeconds+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyRateLimitPerSecond, envRateLimitPerSecond); bindError != nil {
+		bindingErrors = append(bindingErrors, keyRateLimitPerSecond+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyRateLimitBurst, envRateLimitBurst); bindError != nil {
+		bindingErrors = append(bindingErrors, keyRateLimitBurst+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyShutdownGraceSeconds, envShutdownGraceSeconds); bindError != nil {
+		bindingErrors = append(bindingErrors, keyShutdownGraceSeconds+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyResponseCacheSize, envResponseCacheSize); bindError != nil {
+		bindingErrors = append(bindingErrors, keyResponseCacheSize+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyResponseCacheTTLSeconds, envResponseCacheTTLSeconds); bindError != nil {
+		bindingErrors = append(bindingErrors, keyResponseCacheTTLSeconds+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyModelAliases, envModelAliases); bindError != nil {
+		bindingErrors = append(bindingErrors, keyModelAliases+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyRejectFallbackAnswer, envRejectFallbackAnswer); bindError != nil {
+		bindingErrors = append(bindingErrors, keyRejectFallbackAnswer+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyModelRateLimits, envModelRateLimits); bindError != nil {
+		bindingErrors = append(bindingErrors, keyModelRateLimits+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyAccessLogFormat, envAccessLogFormat); bindError != nil {
+		bindingErrors = append(bindingErrors, keyAccessLogFormat+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyOpenAIBaseURL, envOpenAIBaseURL); bindError != nil {
+		bindingErrors = append(bindingErrors, keyOpenAIBaseURL+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyDisableFormatting, envDisableFormatting); bindError != nil {
+		bindingErrors = append(bindingErrors, keyDisableFormatting+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyOpenAIOrganization, envOpenAIOrganization); bindError != nil {
+		bindingErrors = append(bindingErrors, keyOpenAIOrganization+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyOpenAIProject, envOpenAIProject); bindError != nil {
+		bindingErrors = append(bindingErrors, keyOpenAIProject+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyExposeVersionHeader, envExposeVersionHeader); bindError != nil {
+		bindingErrors = append(bindingErrors, keyExposeVersionHeader+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyDefaultModel, envDefaultModel); bindError != nil {
+		bindingErrors = append(bindingErrors, keyDefaultModel+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyRetryOnParseFailure, envRetryOnParseFailure); bindError != nil {
+		bindingErrors = append(bindingErrors, keyRetryOnParseFailure+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyModelPricing, envModelPricing); bindError != nil {
+		bindingErrors = append(bindingErrors, keyModelPricing+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyFallbackModels, envFallbackModels); bindError != nil {
+		bindingErrors = append(bindingErrors, keyFallbackModels+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyReportCost, envReportCost); bindError != nil {
+		bindingErrors = append(bindingErrors, keyReportCost+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyRetryInitialIntervalMilliseconds, envRetryInitialIntervalMilliseconds); bindError != nil {
+		bindingErrors = append(bindingErrors, keyRetryInitialIntervalMilliseconds+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyRetryMultiplier, envRetryMultiplier); bindError != nil {
+		bindingErrors = append(bindingErrors, keyRetryMultiplier+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyRetryMaxElapsedMilliseconds, envRetryMaxElapsedMilliseconds); bindError != nil {
+		bindingErrors = append(bindingErrors, keyRetryMaxElapsedMilliseconds+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyEnabledFormats, envEnabledFormats); bindError != nil {
+		bindingErrors = append(bindingErrors, keyEnabledFormats+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyCircuitBreakerFailureThreshold, envCircuitBreakerFailureThreshold); bindError != nil {
+		bindingErrors = append(bindingErrors, keyCircuitBreakerFailureThreshold+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyCircuitBreakerWindowSeconds, envCircuitBreakerWindowSeconds); bindError != nil {
+		bindingErrors = append(bindingErrors, keyCircuitBreakerWindowSeconds+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyCircuitBreakerCooldownSeconds, envCircuitBreakerCooldownSeconds); bindError != nil {
+		bindingErrors = append(bindingErrors, keyCircuitBreakerCooldownSeconds+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyStuckSessionPollThreshold, envStuckSessionPollThreshold); bindError != nil {
+		bindingErrors = append(bindingErrors, keyStuckSessionPollThreshold+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyVerboseQueueFull, envVerboseQueueFull); bindError != nil {
+		bindingErrors = append(bindingErrors, keyVerboseQueueFull+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyMinRequestTimeout, envMinRequestTimeout); bindError != nil {
+		bindingErrors = append(bindingErrors, keyMinRequestTimeout+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyMaxRequestTimeout, envMaxRequestTimeout); bindError != nil {
+		bindingErrors = append(bindingErrors, keyMaxRequestTimeout+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyMaxPromptBytes, envMaxPromptBytes); bindError != nil {
+		bindingErrors = append(bindingErrors, keyMaxPromptBytes+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyDegradeReasoningUnderLoad, envDegradeReasoningUnderLoad); bindError != nil {
+		bindingErrors = append(bindingErrors, keyDegradeReasoningUnderLoad+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyDegradeReasoningQueueDepth, envDegradeReasoningQueueDepth); bindError != nil {
+		bindingErrors = append(bindingErrors, keyDegradeReasoningQueueDepth+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyMirrorUpstreamStatus, envMirrorUpstreamStatus); bindError != nil {
+		bindingErrors = append(bindingErrors, keyMirrorUpstreamStatus+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyModelPools, envModelPools); bindError != nil {
+		bindingErrors = append(bindingErrors, keyModelPools+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyDeprecatedModels, envDeprecatedModels); bindError != nil {
+		bindingErrors = append(bindingErrors, keyDeprecatedModels+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyRedactPrompts, envRedactPrompts); bindError != nil {
+		bindingErrors = append(bindingErrors, keyRedactPrompts+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyMaxTools, envMaxTools); bindError != nil {
+		bindingErrors = append(bindingErrors, keyMaxTools+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyMaxConcurrentRequests, envMaxConcurrentRequests); bindError != nil {
+		bindingErrors = append(bindingErrors, keyMaxConcurrentRequests+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keySkipModelValidation, envSkipModelValidation); bindError != nil {
+		bindingErrors = append(bindingErrors, keySkipModelValidation+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyModelOutputTokenCeilings, envModelOutputTokenCeilings); bindError != nil {
+		bindingErrors = append(bindingErrors, keyModelOutputTokenCeilings+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyExposeQueueWait, envExposeQueueWait); bindError != nil {
+		bindingErrors = append(bindingErrors, keyExposeQueueWait+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyPassthroughHeaders, envPassthroughHeaders); bindError != nil {
+		bindingErrors = append(bindingErrors, keyPassthroughHeaders+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyPromptPrefix, envPromptPrefix); bindError != nil {
+		bindingErrors = append(bindingErrors, keyPromptPrefix+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyPromptSuffix, envPromptSuffix); bindError != nil {
+		bindingErrors = append(bindingErrors, keyPromptSuffix+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyPollIntervalMillis, envPollIntervalMillis); bindError != nil {
+		bindingErrors = append(bindingErrors, keyPollIntervalMillis+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyEnableCompression, envEnableCompression); bindError != nil {
+		bindingErrors = append(bindingErrors, keyEnableCompression+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyProvider, envProvider); bindError != nil {
+		bindingErrors = append(bindingErrors, keyProvider+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keyAzureAPIVersion, envAzureAPIVersion); bindError != nil {
+		bindingErrors = append(bindingErrors, keyAzureAPIVersion+":"+bindError.Error())
+	}
+	if bindError := viper.BindEnv(keySystemPromptTemplate, envSystemPromptTemplate); bindError != nil {
+		bindingErrors = append(bindingErrors, keySystemPromptTemplate+":"+bindError.Error())
+	}
 	if len(bindingErrors) > 0 {
 		return errors.New(strings.Join(bindingErrors, bindingErrorSeparator))
 	}
@@ -204,13 +732,13 @@ func init() {
 	rootCmd.Flags().IntVar(
 		&config.Port,
 		flagPort,
-		0,
+		proxy.DefaultPort,
 		"TCP port to listen on (env: "+envPort+")",
 	)
 	rootCmd.Flags().StringVar(
 		&config.LogLevel,
 		flagLogLevel,
-		"",
+		proxy.LogLevelInfo,
 		"logging level: debug or info (env: "+envLogLevel+")",
 	)
 	rootCmd.Flags().StringVar(
@@ -222,33 +750,463 @@ func init() {
 	rootCmd.Flags().IntVar(
 		&config.WorkerCount,
 		flagWorkers,
-		0,
+		proxy.DefaultWorkers,
 		"number of worker goroutines (env: "+envWorkers+")",
 	)
 	rootCmd.Flags().IntVar(
 		&config.QueueSize,
 		flagQueueSize,
-		0,
+		proxy.DefaultQueueSize,
 		"request queue size (env: "+envQueueSize+")",
 	)
 	rootCmd.Flags().IntVar(
 		&config.RequestTimeoutSeconds,
 		flagRequestTimeout,
-		0,
+		proxy.DefaultRequestTimeoutSeconds,
 		"overall request timeout in seconds (env: "+envRequestTimeoutSeconds+")",
 	)
 	rootCmd.Flags().IntVar(
 		&config.UpstreamPollTimeoutSeconds,
 		flagUpstreamPollTimeout,
-		0,
+		proxy.DefaultUpstreamPollTimeoutSeconds,
 		"upstream poll timeout in seconds for incomplete responses (env: "+envUpstreamPollTimeoutSeconds+")",
 	)
 	rootCmd.Flags().IntVar(
 		&config.MaxOutputTokens,
 		flagMaxOutputTokens,
-		0,
+		proxy.DefaultMaxOutputTokens,
 		"maximum output tokens (env: "+envMaxOutputTokens+")",
 	)
+	rootCmd.Flags().BoolVar(
+		&config.DisableSystemPromptOverride,
+		flagDisableSystemPromptOverride,
+		false,
+		"ignore client overrides of the system prompt via the system_prompt parameter (env: "+envDisableSystemPromptOverride+")",
+	)
+	rootCmd.Flags().String(
+		flagPromptPrefixModels,
+		"",
+		"comma-separated prompt directive to model pairs, e.g. @fast:=gpt-4o-mini (env: "+envPromptPrefixModels+")",
+	)
+	rootCmd.Flags().String(
+		flagLogRedactedFields,
+		"",
+		"comma-separated JSON paths masked in logged upstream bodies, e.g. output[].content[].text (env: "+envLogRedactedFields+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.DiskQueuePath,
+		flagDiskQueuePath,
+		"",
+		"directory for the disk overflow queue; empty disables it (env: "+envDiskQueuePath+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.DiskQueueMaxEntries,
+		flagDiskQueueMaxEntries,
+		proxy.DefaultDiskQueueMaxEntries,
+		"maximum tasks held in the disk overflow queue (env: "+envDiskQueueMaxEntries+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.AuditLogPath,
+		flagAuditLogPath,
+		"",
+		"file path for the hashed prompt/response audit log; empty disables it (env: "+envAuditLogPath+")",
+	)
+	rootCmd.Flags().String(
+		flagTrustedProxies,
+		"",
+		"comma-separated proxy addresses or CIDR ranges trusted for X-Forwarded-For; empty trusts none (env: "+envTrustedProxies+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.MaxFormattedBytes,
+		flagMaxFormattedBytes,
+		0,
+		"maximum formatted response size in bytes; 0 disables the cap (env: "+envMaxFormattedBytes+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.RejectDuplicateParams,
+		flagRejectDuplicateParams,
+		false,
+		"reject requests that repeat the key, model or web_search parameters (env: "+envRejectDuplicateParams+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.WarmupEnabled,
+		flagWarmupEnabled,
+		false,
+		"send a tiny warm-up prompt to the default model at startup (env: "+envWarmupEnabled+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.WarmupFailureFatal,
+		flagWarmupFailureFatal,
+		false,
+		"refuse to start when the warm-up prompt fails (env: "+envWarmupFailureFatal+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.IncludeFinishReason,
+		flagIncludeFinishReason,
+		false,
+		"expose the model finish reason in the X-Finish-Reason header (env: "+envIncludeFinishReason+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.RetryOnLengthTruncation,
+		flagRetryOnLengthTruncation,
+		false,
+		"retry once with a larger output budget when an answer is truncated (env: "+envRetryOnLengthTruncation+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.ABTestModel,
+		flagABTestModel,
+		"",
+		"candidate model that serves a share of default-model traffic (env: "+envABTestModel+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.ABTestPercentage,
+		flagABTestPercentage,
+		0,
+		"percentage of default-model requests routed to the A/B test model (env: "+envABTestPercentage+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.ExposeUpstreamLatency,
+		flagExposeUpstreamLatency,
+		false,
+		"report the cumulative upstream latency in the X-Upstream-Latency-Ms header (env: "+envExposeUpstreamLatency+")",
+	)
+	rootCmd.Flags().Float64Var(
+		&config.SynthesisBudgetFraction,
+		flagSynthesisBudgetFraction,
+		0,
+		"fraction of the remaining request budget granted to each synthesis poll phase (env: "+envSynthesisBudgetFraction+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.MaxOutputTokensCeiling,
+		flagMaxOutputTokensCeiling,
+		proxy.DefaultMaxOutputTokensCeiling,
+		"largest max_tokens value a request may ask for (env: "+envMaxOutputTokensCeiling+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.SendRequestIDToUpstream,
+		flagSendRequestIDToUpstream,
+		false,
+		"attach the X-Request-ID correlation id to upstream metadata (env: "+envSendRequestIDToUpstream+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.TrimTrailingNewline,
+		flagTrimTrailingNewline,
+		false,
+		"trim trailing whitespace and newlines from the model text before formatting (env: "+envTrimTrailingNewline+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.ExtractionStrategy,
+		flagExtractionStrategy,
+		proxy.ExtractionStrategyOutputTextFirst,
+		"which response field to read first: output_text_first or message_first (env: "+envExtractionStrategy+")",
+	)
+	rootCmd.Flags().String(
+		flagServiceSecrets,
+		"",
+		"comma-separated additional client keys accepted alongside service_secret, for secret rotation (env: "+envServiceSecrets+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.RecentBufferSize,
+		flagRecentBufferSize,
+		0,
+		"number of request summaries kept for GET /recent; 0 disables the endpoint (env: "+envRecentBufferSize+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.StartupTimeoutSeconds,
+		flagStartupTimeoutSeconds,
+		0,
+		"seconds to wait for the startup warm-up before treating it as failed; 0 waits for the request timeout (env: "+envStartupTimeoutSeconds+")",
+	)
+	rootCmd.Flags().Float64Var(
+		&config.RateLimitPerSecond,
+		flagRateLimitPerSecond,
+		0,
+		"sustained requests per second allowed from one client address; 0 disables rate limiting (env: "+envRateLimitPerSecond+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.RateLimitBurst,
+		flagRateLimitBurst,
+		0,
+		"requests a client address may send at once before the rate limit applies; 0 uses the per-second rate rounded up (env: "+envRateLimitBurst+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.ShutdownGraceSeconds,
+		flagShutdownGraceSeconds,
+		proxy.DefaultShutdownGraceSeconds,
+		"seconds a graceful shutdown waits for in-flight requests and queued tasks (env: "+envShutdownGraceSeconds+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.ResponseCacheSize,
+		flagResponseCacheSize,
+		0,
+		"number of answers kept in the in-memory response cache; 0 disables caching (env: "+envResponseCacheSize+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.ResponseCacheTTLSeconds,
+		flagResponseCacheTTLSeconds,
+		proxy.DefaultResponseCacheTTLSeconds,
+		"seconds a cached answer may be served (env: "+envResponseCacheTTLSeconds+")",
+	)
+	rootCmd.Flags().String(
+		flagModelAliases,
+		"",
+		"comma-separated model alias pairs, e.g. fast=gpt-4o-mini (env: "+envModelAliases+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.RejectFallbackAnswer,
+		flagRejectFallbackAnswer,
+		false,
+		"answer 502 when the model finishes a web search without a final answer (env: "+envRejectFallbackAnswer+")",
+	)
+	rootCmd.Flags().String(
+		flagModelRateLimits,
+		"",
+		"comma-separated requests per minute allowed per model, e.g. gpt-5=30 (env: "+envModelRateLimits+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.AccessLogFormat,
+		flagAccessLogFormat,
+		proxy.AccessLogFormatJSON,
+		"access log format: json or clf (env: "+envAccessLogFormat+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.OpenAIBaseURL,
+		flagOpenAIBaseURL,
+		"",
+		"base url of an openai-compatible api, e.g. https://gateway.local; blank uses api.openai.com (env: "+envOpenAIBaseURL+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.DisableFormatting,
+		flagDisableFormatting,
+		false,
+		"always return the raw model text as text/plain, ignoring format and accept (env: "+envDisableFormatting+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.OpenAIOrganization,
+		flagOpenAIOrganization,
+		"",
+		"openai organization sent in the OpenAI-Organization header (env: "+envOpenAIOrganization+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.OpenAIProject,
+		flagOpenAIProject,
+		"",
+		"openai project sent in the OpenAI-Project header (env: "+envOpenAIProject+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.ExposeVersionHeader,
+		flagExposeVersionHeader,
+		true,
+		"report the proxy version in the X-Proxy-Version response header (env: "+envExposeVersionHeader+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.DefaultModel,
+		flagDefaultModel,
+		proxy.DefaultModel,
+		"model used when a request names none (env: "+envDefaultModel+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.RetryOnParseFailure,
+		flagRetryOnParseFailure,
+		false,
+		"retry once when the openai response body is not valid json (env: "+envRetryOnParseFailure+")",
+	)
+	rootCmd.Flags().String(
+		flagModelPricing,
+		"",
+		"comma-separated input:output prices per million tokens per model, served by GET /pricing, e.g. gpt-4.1=2.00:8.00 (env: "+envModelPricing+")",
+	)
+	rootCmd.Flags().String(
+		flagFallbackModels,
+		"",
+		"comma-separated models tried in order when a non-streamed request fails upstream (env: "+envFallbackModels+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.ReportCost,
+		flagReportCost,
+		false,
+		"report the estimated request cost from model_pricing in the X-Estimated-Cost-USD header (env: "+envReportCost+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.RetryInitialIntervalMilliseconds,
+		flagRetryInitialIntervalMilliseconds,
+		0,
+		"wait in milliseconds before the first retry of a failed openai request; 0 uses 500 (env: "+envRetryInitialIntervalMilliseconds+")",
+	)
+	rootCmd.Flags().Float64Var(
+		&config.RetryMultiplier,
+		flagRetryMultiplier,
+		0,
+		"factor applied to the retry wait after each attempt; 0 uses 1.5 (env: "+envRetryMultiplier+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.RetryMaxElapsedMilliseconds,
+		flagRetryMaxElapsedMilliseconds,
+		0,
+		"stop retrying a failed openai request after this many milliseconds; 0 uses 15 minutes, capped by the request timeout (env: "+envRetryMaxElapsedMilliseconds+")",
+	)
+	rootCmd.Flags().String(
+		flagEnabledFormats,
+		"",
+		"comma-separated response formats offered to clients (json, xml, yaml, csv); empty enables all, plain text is always enabled (env: "+envEnabledFormats+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.CircuitBreakerFailureThreshold,
+		flagCircuitBreakerFailureThreshold,
+		0,
+		"consecutive failed openai calls that open the circuit breaker; 0 disables it (env: "+envCircuitBreakerFailureThreshold+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.CircuitBreakerWindowSeconds,
+		flagCircuitBreakerWindowSeconds,
+		proxy.DefaultCircuitBreakerWindowSeconds,
+		"window in seconds within which consecutive failures count toward the threshold (env: "+envCircuitBreakerWindowSeconds+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.CircuitBreakerCooldownSeconds,
+		flagCircuitBreakerCooldownSeconds,
+		proxy.DefaultCircuitBreakerCooldownSeconds,
+		"seconds an open circuit rejects requests before probing openai again (env: "+envCircuitBreakerCooldownSeconds+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.StuckSessionPollThreshold,
+		flagStuckSessionPollThreshold,
+		0,
+		"polls without progress after which a continued session is escalated to synthesis; 0 disables (env: "+envStuckSessionPollThreshold+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.VerboseQueueFull,
+		flagVerboseQueueFull,
+		false,
+		"include the queue length, capacity and retry-after seconds in queue-full 503 bodies (env: "+envVerboseQueueFull+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.MinRequestTimeoutSeconds,
+		flagMinRequestTimeout,
+		proxy.DefaultMinRequestTimeoutSeconds,
+		"shortest per-request timeout in seconds a client may ask for through the timeout parameter (env: "+envMinRequestTimeout+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.MaxRequestTimeoutSeconds,
+		flagMaxRequestTimeout,
+		proxy.DefaultMaxRequestTimeoutSeconds,
+		"longest per-request timeout in seconds a client may ask for through the timeout parameter, capped at the request timeout (env: "+envMaxRequestTimeout+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.MaxPromptBytes,
+		flagMaxPromptBytes,
+		0,
+		"enable POST /ask-file for uploaded prompt files of at most this many bytes; 0 disables it (env: "+envMaxPromptBytes+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.DegradeReasoningUnderLoad,
+		flagDegradeReasoningUnderLoad,
+		false,
+		"lower the reasoning effort of reasoning models by one level while the queue is deep (env: "+envDegradeReasoningUnderLoad+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.DegradeReasoningQueueDepth,
+		flagDegradeReasoningQueueDepth,
+		0,
+		"queue length above which reasoning effort is degraded; 0 selects half of the queue size (env: "+envDegradeReasoningQueueDepth+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.MirrorUpstreamStatus,
+		flagMirrorUpstreamStatus,
+		false,
+		"answer upstream error responses with the upstream status code and message instead of 502 or 504 (env: "+envMirrorUpstreamStatus+")",
+	)
+	rootCmd.Flags().String(
+		flagModelPools,
+		"",
+		"comma-separated worker pools with their own queue, as name=model|model:workers[:queue_size], e.g. reasoning=gpt-5|gpt-5-mini:2:20 (env: "+envModelPools+")",
+	)
+	rootCmd.Flags().String(
+		flagDeprecatedModels,
+		"",
+		"comma-separated models that are still served but answered with a deprecation Warning header (env: "+envDeprecatedModels+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.RedactPrompts,
+		flagRedactPrompts,
+		false,
+		"replace prompt and response text in logs with a placeholder (env: "+envRedactPrompts+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.MaxTools,
+		flagMaxTools,
+		proxy.DefaultMaxTools,
+		"largest number of tools a request may attach (env: "+envMaxTools+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.MaxConcurrentRequests,
+		flagMaxConcurrentRequests,
+		0,
+		"maximum requests handled at once before rejecting with 503; 0 disables the cap (env: "+envMaxConcurrentRequests+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.SkipModelValidation,
+		flagSkipModelValidation,
+		false,
+		"accept every model identifier and forward unknown models to the upstream (env: "+envSkipModelValidation+")",
+	)
+	rootCmd.Flags().String(
+		flagModelOutputTokenCeilings,
+		"",
+		"comma-separated output token ceilings per model that larger budgets are clamped to, e.g. gpt-4o-mini=4096 (env: "+envModelOutputTokenCeilings+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.ExposeQueueWait,
+		flagExposeQueueWait,
+		false,
+		"report the time spent waiting for a worker in the X-Queue-Wait-Ms header (env: "+envExposeQueueWait+")",
+	)
+	rootCmd.Flags().String(
+		flagPassthroughHeaders,
+		"",
+		"comma-separated inbound request headers copied onto upstream OpenAI requests; Authorization is never copied (env: "+envPassthroughHeaders+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.PromptPrefix,
+		flagPromptPrefix,
+		"",
+		"text prepended to every prompt sent upstream, before the system prompt (env: "+envPromptPrefix+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.PromptSuffix,
+		flagPromptSuffix,
+		"",
+		"text appended to every prompt sent upstream, after the user prompt (env: "+envPromptSuffix+")",
+	)
+	rootCmd.Flags().IntVar(
+		&config.PollIntervalMillis,
+		flagPollIntervalMillis,
+		proxy.DefaultPollIntervalMillis,
+		"wait in milliseconds between polls of an incomplete openai response (env: "+envPollIntervalMillis+")",
+	)
+	rootCmd.Flags().BoolVar(
+		&config.EnableCompression,
+		flagEnableCompression,
+		false,
+		"compress prompt responses with gzip or deflate when the client accepts it (env: "+envEnableCompression+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.Provider,
+		flagProvider,
+		proxy.ProviderOpenAI,
+		"upstream provider: openai or azure; azure requires openai_base_url to name the Azure resource (env: "+envProvider+")",
+	)
+	rootCmd.Flags().StringVar(
+		&config.AzureAPIVersion,
+		flagAzureAPIVersion,
+		proxy.DefaultAzureAPIVersion,
+		"api-version query parameter sent to Azure OpenAI (env: "+envAzureAPIVersion+")",
+	)
+	rootCmd.Flags().StringArray(
+		flagSystemPromptTemplate,
+		nil,
+		"named system prompt selected by the system_prompt_name parameter, e.g. support=You are a support agent; repeat for more templates (env: "+envSystemPromptTemplate+", one name=prompt entry per line)",
+	)
 
 	if flagBindError := viper.BindPFlags(rootCmd.Flags()); flagBindError != nil {
 		panic("failed to bind flags: " + flagBindError.Error())
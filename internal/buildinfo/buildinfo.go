@@ -0,0 +1,58 @@
+// Package buildinfo reports the build version, git commit and build time of the proxy.
+package buildinfo
+
+import (
+	"runtime/debug"
+
+	"github.com/temirov/llm-proxy/internal/constants"
+)
+
+const (
+	// developmentVersion identifies builds that carry no release version.
+	developmentVersion = "dev"
+	// unknownValue identifies a build detail that was not recorded at link time.
+	unknownValue = "unknown"
+	// untaggedModuleVersion is the module version the Go toolchain records for builds outside a tagged module.
+	untaggedModuleVersion = "(devel)"
+)
+
+// buildVersion, gitCommit and buildTime are set at link time, e.g.
+// -ldflags "-X github.com/temirov/llm-proxy/internal/buildinfo.buildVersion=v1.2.3
+// -X github.com/temirov/llm-proxy/internal/buildinfo.gitCommit=abc1234
+// -X github.com/temirov/llm-proxy/internal/buildinfo.buildTime=2025-09-06T12:00:00Z".
+var (
+	buildVersion string
+	gitCommit    string
+	buildTime    string
+)
+
+// Version returns the link-time version when set, otherwise the module version recorded in the build information,
+// otherwise "dev".
+func Version() string {
+	if buildVersion != constants.EmptyString {
+		return buildVersion
+	}
+	buildInformation, available := debug.ReadBuildInfo()
+	if available && buildInformation.Main.Version != constants.EmptyString && buildInformation.Main.Version != untaggedModuleVersion {
+		return buildInformation.Main.Version
+	}
+	return developmentVersion
+}
+
+// Commit returns the link-time git commit, or "unknown" when none was recorded.
+func Commit() string {
+	return valueOrUnknown(gitCommit)
+}
+
+// BuildTime returns the link-time build time, or "unknown" when none was recorded.
+func BuildTime() string {
+	return valueOrUnknown(buildTime)
+}
+
+// valueOrUnknown returns value, or "unknown" when it is empty.
+func valueOrUnknown(value string) string {
+	if value == constants.EmptyString {
+		return unknownValue
+	}
+	return value
+}
@@ -0,0 +1,24 @@
+package proxy
+
+import (
+	"hash/fnv"
+)
+
+// abTestPercentageScale is the number of buckets a request hash is reduced to when choosing an A/B test arm.
+const abTestPercentageScale = 100
+
+// selectABTestModel returns candidateModel for the configured percentage of requests and defaultModel otherwise.
+// The choice is a deterministic function of the prompts, so retries of the same request are served by the same model.
+func selectABTestModel(defaultModel string, candidateModel string, candidatePercentage int, systemPrompt string, userPrompt string) string {
+	if candidateModel == defaultModel || candidatePercentage <= 0 {
+		return defaultModel
+	}
+	requestHash := fnv.New32a()
+	_, _ = requestHash.Write([]byte(systemPrompt))
+	_, _ = requestHash.Write([]byte{0})
+	_, _ = requestHash.Write([]byte(userPrompt))
+	if int(requestHash.Sum32()%abTestPercentageScale) < candidatePercentage {
+		return candidateModel
+	}
+	return defaultModel
+}
@@ -0,0 +1,51 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// commonLogFormatLine formats one Common Log Format entry: host, ident, authuser, date, request line, status
+	// and response size.
+	commonLogFormatLine = "%s - - [%s] \"%s %s %s\" %d %s\n"
+	// commonLogFormatTimeLayout is the timestamp layout of a Common Log Format entry.
+	commonLogFormatTimeLayout = "02/Jan/2006:15:04:05 -0700"
+	// commonLogFormatMissingValue stands for an unknown field such as the size of an empty response.
+	commonLogFormatMissingValue = "-"
+)
+
+var (
+	// AccessLogWriter receives the access log lines written when AccessLogFormat is AccessLogFormatCLF.
+	AccessLogWriter io.Writer = os.Stdout
+)
+
+// commonLogFormatLogger writes one Common Log Format line per request to accessLogWriter. The key query parameter
+// is redacted from the logged request line, as are the prompt query parameters when redactPrompts is set.
+func commonLogFormatLogger(accessLogWriter io.Writer, redactPrompts bool) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		requestStart := time.Now()
+		ginContext.Next()
+
+		responseSize := commonLogFormatMissingValue
+		if writtenBytes := ginContext.Writer.Size(); writtenBytes > 0 {
+			responseSize = strconv.Itoa(writtenBytes)
+		}
+		_, _ = fmt.Fprintf(
+			accessLogWriter,
+			commonLogFormatLine,
+			ginContext.ClientIP(),
+			requestStart.Format(commonLogFormatTimeLayout),
+			ginContext.Request.Method,
+			sanitizeRequestURI(ginContext.Request.URL, redactPrompts),
+			ginContext.Request.Proto,
+			ginContext.Writer.Status(),
+			responseSize,
+		)
+	}
+}
@@ -0,0 +1,71 @@
+package proxy
+
+import (
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/utils"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// auditLogFilePermissions restricts the audit log to the proxy's user.
+	auditLogFilePermissions = 0o600
+	// auditLogFileOpenFlags appends to an existing audit log or creates a new one.
+	auditLogFileOpenFlags = os.O_APPEND | os.O_CREATE | os.O_WRONLY
+	// auditTimestampLayout formats the timestamp recorded in each audit entry.
+	auditTimestampLayout = time.RFC3339Nano
+
+	// contextKeyAuditModel carries the resolved model from chatHandler to the audit middleware.
+	contextKeyAuditModel = "audit_model"
+	// contextKeyAuditPrompt carries the forwarded prompt from chatHandler to the audit middleware.
+	contextKeyAuditPrompt = "audit_prompt"
+	// contextKeyAuditResponse carries the model output from chatHandler to the audit middleware.
+	contextKeyAuditResponse = "audit_response"
+)
+
+// newAuditLogger opens auditLogPath for appending and returns a JSON logger dedicated to audit records.
+// The audit entry carries its own timestamp, so the encoder omits zap's level, time and caller keys.
+func newAuditLogger(auditLogPath string) (*zap.Logger, error) {
+	auditFile, openError := os.OpenFile(auditLogPath, auditLogFileOpenFlags, auditLogFilePermissions)
+	if openError != nil {
+		return nil, openError
+	}
+	encoderConfiguration := zap.NewProductionEncoderConfig()
+	encoderConfiguration.TimeKey = constants.EmptyString
+	encoderConfiguration.LevelKey = constants.EmptyString
+	encoderConfiguration.CallerKey = constants.EmptyString
+	auditCore := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfiguration), zapcore.AddSync(auditFile), zapcore.InfoLevel)
+	return zap.New(auditCore), nil
+}
+
+// auditMiddleware records one content-free entry per request: the timestamp, a fingerprint of the client address,
+// the resolved model, SHA-256 hashes of the prompt and response, the status code, and the latency.
+// chatHandler publishes the model, prompt and response through the gin context; requests rejected before the
+// handler runs fall back to the raw prompt parameter and carry no model or response hash.
+func auditMiddleware(auditLogger *zap.Logger) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		requestStart := time.Now()
+		ginContext.Next()
+
+		promptText, promptPublished := ginContext.Get(contextKeyAuditPrompt)
+		if !promptPublished {
+			promptText = ginContext.Query(queryParameterPrompt)
+		}
+		auditFields := []zap.Field{
+			zap.String(logFieldTimestamp, requestStart.UTC().Format(auditTimestampLayout)),
+			zap.String(logFieldClientFingerprint, utils.Fingerprint(ginContext.ClientIP())),
+			zap.String(logFieldModel, ginContext.GetString(contextKeyAuditModel)),
+			zap.String(logFieldPromptHash, utils.ContentHash(promptText.(string))),
+			zap.Int(logFieldStatus, ginContext.Writer.Status()),
+			zap.Int64(constants.LogFieldLatencyMilliseconds, time.Since(requestStart).Milliseconds()),
+		}
+		if responseText, responsePublished := ginContext.Get(contextKeyAuditResponse); responsePublished {
+			auditFields = append(auditFields, zap.String(logFieldResponseHash, utils.ContentHash(responseText.(string))))
+		}
+		auditLogger.Info(logEventAudit, auditFields...)
+	}
+}
@@ -0,0 +1,27 @@
+package proxy
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/buildinfo"
+)
+
+// versionPath serves the build version, git commit and build time of the running proxy.
+const versionPath = "/version"
+
+// buildInfoDocument is the JSON document served by the version endpoint.
+type buildInfoDocument struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+}
+
+// versionHandler reports the build details recorded at link time so that operators can confirm which build is
+// deployed. The details are fixed for the life of the process and carry no secrets, so no client key is required.
+func versionHandler() gin.HandlerFunc {
+	document := buildInfoDocument{Version: buildinfo.Version(), Commit: buildinfo.Commit(), BuildTime: buildinfo.BuildTime()}
+	return func(ginContext *gin.Context) {
+		ginContext.JSON(http.StatusOK, document)
+	}
+}
@@ -0,0 +1,41 @@
+package proxy
+
+// maxCandidateCount is the largest number of candidate answers a request may ask for through the n parameter.
+const maxCandidateCount = 4
+
+// completeCandidates calls complete candidateCount times and returns the first reply together with the text of every
+// reply, in order. The latency and token usage of all calls are added to the returned reply. The first failure ends
+// the calls and is returned. A candidateCount of one or less makes a single call and returns no candidate texts.
+func completeCandidates(candidateCount int, complete func() (upstreamResponse, error)) (upstreamResponse, []string, error) {
+	firstReply, requestError := complete()
+	if candidateCount <= 1 || requestError != nil {
+		return firstReply, nil, requestError
+	}
+	candidateTexts := []string{firstReply.text}
+	for len(candidateTexts) < candidateCount {
+		candidateReply, candidateError := complete()
+		firstReply.latencyMillis += candidateReply.latencyMillis
+		if candidateError != nil {
+			return firstReply, nil, candidateError
+		}
+		firstReply.usage = addTokenUsage(firstReply.usage, candidateReply.usage)
+		firstReply.fallbackUsed = firstReply.fallbackUsed || candidateReply.fallbackUsed
+		candidateTexts = append(candidateTexts, candidateReply.text)
+	}
+	return firstReply, candidateTexts, nil
+}
+
+// addTokenUsage returns the sum of two token usages, either of which may be nil.
+func addTokenUsage(firstUsage *tokenUsage, secondUsage *tokenUsage) *tokenUsage {
+	if firstUsage == nil {
+		return secondUsage
+	}
+	if secondUsage == nil {
+		return firstUsage
+	}
+	return &tokenUsage{
+		inputTokens:  firstUsage.inputTokens + secondUsage.inputTokens,
+		outputTokens: firstUsage.outputTokens + secondUsage.outputTokens,
+		totalTokens:  firstUsage.totalTokens + secondUsage.totalTokens,
+	}
+}
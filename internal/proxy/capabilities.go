@@ -0,0 +1,54 @@
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+)
+
+// capabilitiesPath serves the payload capabilities of a model.
+const capabilitiesPath = "/capabilities"
+
+// modelCapabilities describes which request settings the proxy forwards for one model.
+type modelCapabilities struct {
+	Model                string   `json:"model"`
+	AllowedRequestFields []string `json:"allowed_request_fields"`
+	SupportsTemperature  bool     `json:"supports_temperature"`
+	SupportsTools        bool     `json:"supports_tools"`
+	SupportsReasoning    bool     `json:"supports_reasoning"`
+}
+
+// describeModelCapabilities derives the capability map of modelIdentifier from its payload schema.
+func describeModelCapabilities(modelIdentifier string) modelCapabilities {
+	return modelCapabilities{
+		Model:                modelIdentifier,
+		AllowedRequestFields: ResolveModelPayloadSchema(modelIdentifier).AllowedRequestFields,
+		SupportsTemperature:  modelAllowsRequestField(modelIdentifier, keyTemperature),
+		SupportsTools:        modelAllowsRequestField(modelIdentifier, keyTools),
+		SupportsReasoning:    modelAllowsRequestField(modelIdentifier, keyReasoning),
+	}
+}
+
+// capabilitiesHandler returns the capability map of the model named by the model query parameter, or of defaultModel
+// when it is omitted. Aliases resolve through modelAliases; unknown models yield 400.
+func capabilitiesHandler(validator *modelValidator, defaultModel string, modelAliases map[string]string) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		modelIdentifier := strings.TrimSpace(ginContext.Query(queryParameterModel))
+		if modelIdentifier == constants.EmptyString {
+			modelIdentifier = defaultModel
+		}
+		modelIdentifier = resolveModelAlias(modelIdentifier, modelAliases)
+		if verificationError := validator.Verify(modelIdentifier); verificationError != nil {
+			verificationStatus := http.StatusServiceUnavailable
+			if errors.Is(verificationError, ErrUnknownModel) {
+				verificationStatus = http.StatusBadRequest
+			}
+			ginContext.String(verificationStatus, verificationError.Error())
+			return
+		}
+		ginContext.JSON(http.StatusOK, describeModelCapabilities(modelIdentifier))
+	}
+}
@@ -0,0 +1,392 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"go.uber.org/zap"
+)
+
+const (
+	// chatCompletionsPath accepts OpenAI chat completions request bodies.
+	chatCompletionsPath = "/v1/chat/completions"
+	// chatCompletionObject is the object type of a non-streamed chat completion.
+	chatCompletionObject = "chat.completion"
+	// chatCompletionChunkObject is the object type of a streamed chat completion chunk.
+	chatCompletionChunkObject = "chat.completion.chunk"
+	// chatCompletionIDPrefix precedes the request identifier in chat completion ids.
+	chatCompletionIDPrefix = "chatcmpl-"
+	// chatRoleSystem and chatRoleDeveloper mark messages that become the system prompt.
+	chatRoleSystem    = "system"
+	chatRoleDeveloper = "developer"
+	// chatRoleUser marks a message written by the user.
+	chatRoleUser = "user"
+	// chatRoleAssistant marks a message produced by the model.
+	chatRoleAssistant = "assistant"
+	// chatFinishReasonStop is reported when the worker gave no finish reason.
+	chatFinishReasonStop = "stop"
+	// chatErrorTypeInvalidRequest and chatErrorTypeServer classify errors in the chat completions error object.
+	chatErrorTypeInvalidRequest = "invalid_request_error"
+	chatErrorTypeServer         = "server_error"
+	// chatStreamDone terminates a streamed chat completion.
+	chatStreamDone = "[DONE]"
+	// chatTranscriptLineFormat renders one message of a multi-message conversation as role: content.
+	chatTranscriptLineFormat = "%s: %s"
+)
+
+// chatCompletionRequest is the subset of the chat completions request body understood by the proxy.
+type chatCompletionRequest struct {
+	Model           string               `json:"model"`
+	Messages        []chatMessageRequest `json:"messages"`
+	Temperature     *float64             `json:"temperature"`
+	MaxTokens       int                  `json:"max_tokens"`
+	ReasoningEffort string               `json:"reasoning_effort"`
+	Seed            *int64               `json:"seed"`
+	Stream          bool                 `json:"stream"`
+}
+
+// chatMessageRequest is one message of a chat completions request. Content is either a string or an array of parts.
+type chatMessageRequest struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+}
+
+// chatCompletionMessage is a message in a chat completion response or a delta in a streamed chunk.
+type chatCompletionMessage struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// chatCompletionChoice is one choice of a chat completion response or chunk.
+type chatCompletionChoice struct {
+	Index        int                    `json:"index"`
+	Message      *chatCompletionMessage `json:"message,omitempty"`
+	Delta        *chatCompletionMessage `json:"delta,omitempty"`
+	FinishReason *string                `json:"finish_reason"`
+}
+
+// chatCompletionUsage reports token counts in chat completions terms.
+type chatCompletionUsage struct {
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+	TotalTokens      int64 `json:"total_tokens"`
+}
+
+// chatCompletionResponse is a chat completion or, when Object is chatCompletionChunkObject, one streamed chunk.
+type chatCompletionResponse struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []chatCompletionChoice `json:"choices"`
+	Usage   *chatCompletionUsage   `json:"usage,omitempty"`
+}
+
+// chatCompletionError is the error body returned by the chat completions endpoint.
+type chatCompletionError struct {
+	Error chatCompletionErrorDetail `json:"error"`
+}
+
+// chatCompletionErrorDetail describes a failed chat completions request.
+type chatCompletionErrorDetail struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// writeChatCompletionError writes an OpenAI-style error object with the given status.
+func writeChatCompletionError(ginContext *gin.Context, status int, message string) {
+	errorType := chatErrorTypeInvalidRequest
+	if status >= http.StatusInternalServerError {
+		errorType = chatErrorTypeServer
+	}
+	ginContext.JSON(status, chatCompletionError{Error: chatCompletionErrorDetail{Message: message, Type: errorType}})
+}
+
+// messageText returns the text of a message whose content is a string or an array of content parts.
+func (message chatMessageRequest) messageText() (string, error) {
+	if len(message.Content) == 0 || string(message.Content) == "null" {
+		return constants.EmptyString, nil
+	}
+	var contentText string
+	if json.Unmarshal(message.Content, &contentText) == nil {
+		return strings.TrimSpace(contentText), nil
+	}
+	var contentParts []contentPart
+	if json.Unmarshal(message.Content, &contentParts) == nil {
+		return joinParts(contentParts), nil
+	}
+	return constants.EmptyString, errors.New(errorInvalidMessageContent)
+}
+
+// chatPrompts splits messages into the system prompt and the user prompt forwarded upstream. System and developer
+// messages are joined into the system prompt. A single remaining message is forwarded as is; several are rendered as
+// a role: content transcript so that the model sees the whole conversation.
+func chatPrompts(messages []chatMessageRequest) (string, string, error) {
+	var systemParts, conversationParts []string
+	var conversationRoles []string
+	for _, message := range messages {
+		text, textError := message.messageText()
+		if textError != nil {
+			return constants.EmptyString, constants.EmptyString, textError
+		}
+		if text == constants.EmptyString {
+			continue
+		}
+		role := strings.ToLower(strings.TrimSpace(message.Role))
+		if role == chatRoleSystem || role == chatRoleDeveloper {
+			systemParts = append(systemParts, text)
+			continue
+		}
+		if role == constants.EmptyString {
+			role = chatRoleUser
+		}
+		conversationRoles = append(conversationRoles, role)
+		conversationParts = append(conversationParts, text)
+	}
+	systemPrompt := strings.Join(systemParts, "\n\n")
+	if len(conversationParts) == 1 && conversationRoles[0] == chatRoleUser {
+		return systemPrompt, conversationParts[0], nil
+	}
+	transcriptLines := make([]string, len(conversationParts))
+	for index, text := range conversationParts {
+		transcriptLines[index] = fmt.Sprintf(chatTranscriptLineFormat, conversationRoles[index], text)
+	}
+	return systemPrompt, strings.Join(transcriptLines, "\n\n"), nil
+}
+
+// chatCompletionsHandler returns a handler for the OpenAI-compatible chat completions endpoint. It translates the
+// messages into a requestTask, queues it like chatHandler does, and answers with a chat completion built from the
+// extracted text, or with chat completion chunks when the body asks for a stream. System messages replace the
+// configured system prompt unless DisableSystemPromptOverride is set. Requests share requestSlots with chatHandler.
+func chatCompletionsHandler(taskQueues *modelQueues, overflowQueue *diskOverflowQueue, configuration Configuration, validator *modelValidator, modelLimiter *modelRateLimiter, breaker *circuitBreaker, requestSlots chan struct{}, requestTimeout time.Duration, structuredLogger *zap.SugaredLogger) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		correlatedLogger := requestLogger(structuredLogger, ginContext.GetString(contextKeyRequestID))
+		if requestSlots != nil {
+			select {
+			case requestSlots <- struct{}{}:
+				defer func() { <-requestSlots }()
+			default:
+				writeChatCompletionError(ginContext, http.StatusServiceUnavailable, errorConcurrencyLimitReached)
+				return
+			}
+		}
+		ginContext.Request.Body = http.MaxBytesReader(ginContext.Writer, ginContext.Request.Body, maxPromptBodyBytes)
+		var completionRequest chatCompletionRequest
+		if decodeError := json.NewDecoder(ginContext.Request.Body).Decode(&completionRequest); decodeError != nil {
+			writeChatCompletionError(ginContext, http.StatusBadRequest, errorInvalidRequestBody)
+			return
+		}
+		messageSystemPrompt, userPrompt, promptError := chatPrompts(completionRequest.Messages)
+		if promptError != nil {
+			writeChatCompletionError(ginContext, http.StatusBadRequest, promptError.Error())
+			return
+		}
+		if userPrompt == constants.EmptyString {
+			writeChatCompletionError(ginContext, http.StatusBadRequest, errorMissingChatMessages)
+			return
+		}
+		systemPrompt := configuration.SystemPrompt
+		if messageSystemPrompt != constants.EmptyString {
+			if !configuration.DisableSystemPromptOverride {
+				systemPrompt = messageSystemPrompt
+			} else {
+				correlatedLogger.Debugw(logEventSystemMessageIgnored)
+			}
+		}
+
+		modelIdentifier := resolveModelAlias(strings.TrimSpace(completionRequest.Model), configuration.ModelAliases)
+		if modelIdentifier == constants.EmptyString {
+			modelIdentifier = configuration.DefaultModel
+		}
+		ginContext.Set(contextKeyAuditModel, modelIdentifier)
+		ginContext.Set(contextKeyAuditPrompt, userPrompt)
+		if verificationError := validator.Verify(modelIdentifier); verificationError != nil {
+			writeChatCompletionError(ginContext, http.StatusBadRequest, verificationError.Error())
+			return
+		}
+		writeDeprecationWarning(ginContext, modelIdentifier, configuration.DeprecatedModels)
+
+		if completionRequest.MaxTokens < 0 {
+			writeChatCompletionError(ginContext, http.StatusBadRequest, errorInvalidMaxTokens)
+			return
+		}
+		if completionRequest.MaxTokens > configuration.MaxOutputTokensCeiling {
+			writeChatCompletionError(ginContext, http.StatusBadRequest, fmt.Sprintf(errorMaxTokensAboveCeilingFormat, configuration.MaxOutputTokensCeiling))
+			return
+		}
+		if requestedTemperature := completionRequest.Temperature; requestedTemperature != nil && (math.IsNaN(*requestedTemperature) || *requestedTemperature < MinTemperature || *requestedTemperature > MaxTemperature) {
+			writeChatCompletionError(ginContext, http.StatusBadRequest, errorInvalidTemperature)
+			return
+		}
+		requestedReasoningEffort := strings.ToLower(strings.TrimSpace(completionRequest.ReasoningEffort))
+		if requestedReasoningEffort != constants.EmptyString && !isSupportedReasoningEffort(requestedReasoningEffort) {
+			writeChatCompletionError(ginContext, http.StatusBadRequest, errorInvalidReasoningEffort)
+			return
+		}
+
+		if allowed, wait := modelLimiter.allow(modelIdentifier, time.Now()); !allowed {
+			ginContext.Header(headerRetryAfter, retryAfterSeconds(wait))
+			writeChatCompletionError(ginContext, http.StatusTooManyRequests, fmt.Sprintf(errorModelRateLimitedFormat, modelIdentifier))
+			return
+		}
+		if rejecting, wait := breaker.rejecting(time.Now()); rejecting {
+			ginContext.Header(headerRetryAfter, retryAfterSeconds(wait))
+			writeChatCompletionError(ginContext, http.StatusServiceUnavailable, errorUpstreamCircuitOpen)
+			return
+		}
+
+		replyChannel := make(chan result, 1)
+		pendingTask := requestTask{
+			prompt:             userPrompt,
+			systemPrompt:       systemPrompt,
+			model:              modelIdentifier,
+			maxOutputTokens:    completionRequest.MaxTokens,
+			temperature:        completionRequest.Temperature,
+			reasoningEffort:    requestedReasoningEffort,
+			seed:               completionRequest.Seed,
+			passthroughHeaders: passthroughHeaders(ginContext.Request, configuration.PassthroughHeaders),
+			requestID:          ginContext.GetString(contextKeyRequestID),
+			sendRequestID:      configuration.SendRequestIDToUpstream,
+			traceContext:       detachedTraceContext(ginContext),
+			reply:              replyChannel,
+		}
+		completionID := chatCompletionIDPrefix + newRequestID()
+		if completionRequest.Stream {
+			streamContext, streamCancel := context.WithCancel(ginContext.Request.Context())
+			defer streamCancel()
+			pendingTask.streamDeltas = make(chan string)
+			pendingTask.streamContext = streamContext
+		}
+		if !enqueueTask(ginContext, taskQueues.queueFor(modelIdentifier), overflowQueue, pendingTask, requestTimeout, correlatedLogger) {
+			ginContext.Header(headerRetryAfter, retryAfterSeconds(queueFullRetryAfter))
+			writeChatCompletionError(ginContext, http.StatusServiceUnavailable, errorQueueFull)
+			return
+		}
+
+		requestContext, requestCancel := context.WithTimeout(ginContext.Request.Context(), requestTimeout)
+		defer requestCancel()
+		completion := chatCompletionResponse{ID: completionID, Created: time.Now().Unix(), Model: modelIdentifier}
+		if completionRequest.Stream {
+			relayChatCompletionStream(ginContext, requestContext, completion, pendingTask.streamDeltas, replyChannel, configuration.MirrorUpstreamStatus)
+			return
+		}
+		select {
+		case outcome := <-replyChannel:
+			if outcome.requestError != nil {
+				errorStatus, errorMessage := requestErrorStatus(outcome.requestError, configuration.MirrorUpstreamStatus)
+				writeUpstreamRetryAfter(ginContext, outcome.requestError)
+				writeChatCompletionError(ginContext, errorStatus, errorMessage)
+				return
+			}
+			if configuration.RejectFallbackAnswer && outcome.fallbackUsed {
+				writeChatCompletionError(ginContext, http.StatusBadGateway, errorFallbackAnswer)
+				return
+			}
+			ginContext.Set(contextKeyAuditResponse, outcome.text)
+			if outcome.servedModel != constants.EmptyString {
+				completion.Model = outcome.servedModel
+			}
+			finishReason := chatFinishReason(outcome.finishReason)
+			completion.Object = chatCompletionObject
+			completion.Choices = []chatCompletionChoice{{
+				Message:      &chatCompletionMessage{Role: chatRoleAssistant, Content: outcome.text},
+				FinishReason: &finishReason,
+			}}
+			if outcome.usage != nil {
+				completion.Usage = &chatCompletionUsage{
+					PromptTokens:     outcome.usage.inputTokens,
+					CompletionTokens: outcome.usage.outputTokens,
+					TotalTokens:      outcome.usage.totalTokens,
+				}
+			}
+			writeOutcomeHeaders(ginContext, configuration, outcome, modelIdentifier)
+			ginContext.JSON(http.StatusOK, completion)
+		case <-requestContext.Done():
+			writeChatCompletionError(ginContext, http.StatusGatewayTimeout, errorRequestTimedOut)
+		}
+	}
+}
+
+// chatFinishReason returns the finish reason reported to chat completions clients, defaulting to stop.
+func chatFinishReason(finishReason string) string {
+	if finishReason == constants.EmptyString {
+		return chatFinishReasonStop
+	}
+	return finishReason
+}
+
+// relayChatCompletionStream forwards text deltas as chat completion chunks until the worker replies, then sends a
+// chunk carrying the finish reason followed by the [DONE] marker. Like relayStream, failures before the first chunk
+// keep their usual status codes and later failures end the stream with an error object.
+func relayChatCompletionStream(ginContext *gin.Context, requestContext context.Context, completion chatCompletionResponse, streamDeltas <-chan string, replyChannel <-chan result, mirrorUpstreamStatus bool) {
+	completion.Object = chatCompletionChunkObject
+	streamStarted := false
+	sendData := func(eventData any) {
+		if !streamStarted {
+			streamStarted = true
+			ginContext.Header(headerContentType, mimeTextEventStream)
+			ginContext.Header(headerCacheControl, cacheControlNoCache)
+			ginContext.Status(http.StatusOK)
+		}
+		encodedData, isText := eventData.(string)
+		if !isText {
+			encodedBytes, _ := json.Marshal(eventData)
+			encodedData = string(encodedBytes)
+		}
+		_, _ = fmt.Fprintf(ginContext.Writer, "data: %s\n\n", encodedData)
+		ginContext.Writer.Flush()
+	}
+	sendDelta := func(textDelta string) {
+		delta := &chatCompletionMessage{Content: textDelta}
+		if !streamStarted {
+			delta.Role = chatRoleAssistant
+		}
+		completion.Choices = []chatCompletionChoice{{Delta: delta}}
+		sendData(completion)
+	}
+	for {
+		select {
+		case textDelta, deltasOpen := <-streamDeltas:
+			if !deltasOpen {
+				streamDeltas = nil
+				continue
+			}
+			sendDelta(textDelta)
+		case outcome := <-replyChannel:
+			if outcome.requestError != nil {
+				errorStatus, errorMessage := requestErrorStatus(outcome.requestError, mirrorUpstreamStatus)
+				if !streamStarted {
+					writeUpstreamRetryAfter(ginContext, outcome.requestError)
+					writeChatCompletionError(ginContext, errorStatus, errorMessage)
+					return
+				}
+				sendData(chatCompletionError{Error: chatCompletionErrorDetail{Message: errorMessage, Type: chatErrorTypeServer}})
+				return
+			}
+			ginContext.Set(contextKeyAuditResponse, outcome.text)
+			if !streamStarted {
+				sendDelta(outcome.text)
+			}
+			finishReason := chatFinishReason(outcome.finishReason)
+			completion.Choices = []chatCompletionChoice{{Delta: &chatCompletionMessage{}, FinishReason: &finishReason}}
+			sendData(completion)
+			sendData(chatStreamDone)
+			return
+		case <-requestContext.Done():
+			if !streamStarted {
+				writeChatCompletionError(ginContext, http.StatusGatewayTimeout, errorRequestTimedOut)
+				return
+			}
+			sendData(chatCompletionError{Error: chatCompletionErrorDetail{Message: errorRequestTimedOut, Type: chatErrorTypeServer}})
+			return
+		}
+	}
+}
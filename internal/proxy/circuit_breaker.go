@@ -0,0 +1,116 @@
+package proxy
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker stops calling the upstream after failureThreshold consecutive failures within failureWindow.
+// While open it rejects calls for cooldown, then lets a single probe through: a successful probe closes the circuit
+// and a failed one opens it for another cooldown, while a probe cancelled by its client leaves the circuit open for
+// the next caller to probe. A nil breaker allows every call.
+type circuitBreaker struct {
+	accessMutex      sync.Mutex
+	failureThreshold int
+	failureWindow    time.Duration
+	cooldown         time.Duration
+	// consecutiveFailures counts the failures since the last success that fall within failureWindow of firstFailure.
+	consecutiveFailures int
+	firstFailure        time.Time
+	// openedAt is when the circuit last opened; zero while it is closed.
+	openedAt time.Time
+	// probeInFlight reports that the half-open circuit has admitted its probe and awaits the outcome.
+	probeInFlight bool
+}
+
+// newCircuitBreaker returns a breaker opening after failureThreshold failures within failureWindow, or nil when
+// failureThreshold is not positive.
+func newCircuitBreaker(failureThreshold int, failureWindow time.Duration, cooldown time.Duration) *circuitBreaker {
+	if failureThreshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{failureThreshold: failureThreshold, failureWindow: failureWindow, cooldown: cooldown}
+}
+
+// rejecting reports whether new requests should be turned away without queueing them, and how long until the
+// circuit admits a probe. It does not change the breaker state.
+func (breaker *circuitBreaker) rejecting(now time.Time) (bool, time.Duration) {
+	if breaker == nil {
+		return false, 0
+	}
+	breaker.accessMutex.Lock()
+	defer breaker.accessMutex.Unlock()
+	if breaker.openedAt.IsZero() {
+		return false, 0
+	}
+	remainingCooldown := breaker.openedAt.Add(breaker.cooldown).Sub(now)
+	if remainingCooldown > 0 {
+		return true, remainingCooldown
+	}
+	return breaker.probeInFlight, 0
+}
+
+// allow reports whether an upstream call may proceed and whether it is the half-open probe. Once the cooldown has
+// elapsed, the first caller becomes the probe and later callers are rejected until its outcome is recorded.
+func (breaker *circuitBreaker) allow(now time.Time) (bool, bool) {
+	if breaker == nil {
+		return true, false
+	}
+	breaker.accessMutex.Lock()
+	defer breaker.accessMutex.Unlock()
+	if breaker.openedAt.IsZero() {
+		return true, false
+	}
+	if now.Before(breaker.openedAt.Add(breaker.cooldown)) || breaker.probeInFlight {
+		return false, false
+	}
+	breaker.probeInFlight = true
+	return true, true
+}
+
+// recordSuccess closes the circuit and clears the failure streak.
+func (breaker *circuitBreaker) recordSuccess() {
+	if breaker == nil {
+		return
+	}
+	breaker.accessMutex.Lock()
+	defer breaker.accessMutex.Unlock()
+	breaker.consecutiveFailures = 0
+	breaker.openedAt = time.Time{}
+	breaker.probeInFlight = false
+}
+
+// recordFailure extends the failure streak, restarting it when its first failure is older than failureWindow, and
+// opens the circuit once the streak reaches failureThreshold or the failed call was the half-open probe.
+func (breaker *circuitBreaker) recordFailure(now time.Time, probe bool) {
+	if breaker == nil {
+		return
+	}
+	breaker.accessMutex.Lock()
+	defer breaker.accessMutex.Unlock()
+	if probe {
+		breaker.probeInFlight = false
+		breaker.openedAt = now
+		return
+	}
+	if breaker.consecutiveFailures == 0 || now.Sub(breaker.firstFailure) > breaker.failureWindow {
+		breaker.consecutiveFailures = 0
+		breaker.firstFailure = now
+	}
+	breaker.consecutiveFailures++
+	if breaker.consecutiveFailures >= breaker.failureThreshold {
+		breaker.consecutiveFailures = 0
+		breaker.openedAt = now
+	}
+}
+
+// releaseProbe ends a half-open probe that finished without an outcome, such as one cancelled by its client, so that
+// the next caller probes the upstream instead.
+func (breaker *circuitBreaker) releaseProbe() {
+	if breaker == nil {
+		return
+	}
+	breaker.accessMutex.Lock()
+	defer breaker.accessMutex.Unlock()
+	breaker.probeInFlight = false
+}
@@ -0,0 +1,130 @@
+package proxy
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+)
+
+const (
+	// contentEncodingGzip names the gzip content coding.
+	contentEncodingGzip = "gzip"
+	// contentEncodingDeflate names the deflate content coding.
+	contentEncodingDeflate = "deflate"
+	// acceptEncodingSeparator separates the entries of an Accept-Encoding header.
+	acceptEncodingSeparator = ","
+	// acceptEncodingParameterSeparator separates a content coding from its parameters.
+	acceptEncodingParameterSeparator = ";"
+	// contentEncodingQualityPrefix introduces the quality value of an Accept-Encoding entry.
+	contentEncodingQualityPrefix = "q="
+)
+
+// compressingResponseWriter compresses the response body with the negotiated content coding. The decision to
+// compress is taken when the body is first written, so that streamed event responses and responses that already
+// carry a content coding pass through unchanged.
+type compressingResponseWriter struct {
+	gin.ResponseWriter
+	contentEncoding string
+	decided         bool
+	compressor      io.WriteCloser
+	flusher         interface{ Flush() error }
+}
+
+// decide selects whether the body is compressed, based on the headers set by the handler so far.
+func (writer *compressingResponseWriter) decide() {
+	if writer.decided {
+		return
+	}
+	writer.decided = true
+	headers := writer.ResponseWriter.Header()
+	if headers.Get(headerContentEncoding) != constants.EmptyString || strings.HasPrefix(headers.Get(headerContentType), mimeTextEventStream) {
+		return
+	}
+	headers.Set(headerContentEncoding, writer.contentEncoding)
+	headers.Del(headerContentLength)
+	if writer.contentEncoding == contentEncodingGzip {
+		gzipWriter := gzip.NewWriter(writer.ResponseWriter)
+		writer.compressor, writer.flusher = gzipWriter, gzipWriter
+		return
+	}
+	deflateWriter, _ := flate.NewWriter(writer.ResponseWriter, flate.DefaultCompression)
+	writer.compressor, writer.flusher = deflateWriter, deflateWriter
+}
+
+// Write compresses data when compression was selected and writes it through otherwise.
+func (writer *compressingResponseWriter) Write(data []byte) (int, error) {
+	writer.decide()
+	if writer.compressor == nil {
+		return writer.ResponseWriter.Write(data)
+	}
+	writer.ResponseWriter.WriteHeaderNow()
+	return writer.compressor.Write(data)
+}
+
+// WriteString compresses text when compression was selected and writes it through otherwise.
+func (writer *compressingResponseWriter) WriteString(text string) (int, error) {
+	return writer.Write([]byte(text))
+}
+
+// Flush emits the data compressed so far before flushing the underlying writer.
+func (writer *compressingResponseWriter) Flush() {
+	writer.decide()
+	if writer.flusher != nil {
+		_ = writer.flusher.Flush()
+	}
+	writer.ResponseWriter.Flush()
+}
+
+// close completes the compressed stream, if one was started.
+func (writer *compressingResponseWriter) close() {
+	if writer.compressor != nil {
+		_ = writer.compressor.Close()
+	}
+}
+
+// negotiateContentEncoding returns the content coding to apply for the Accept-Encoding header value, preferring gzip
+// over deflate, or an empty string when the client accepts neither.
+func negotiateContentEncoding(acceptEncoding string) string {
+	accepted := map[string]bool{}
+	for _, entry := range strings.Split(acceptEncoding, acceptEncodingSeparator) {
+		entryParts := strings.Split(entry, acceptEncodingParameterSeparator)
+		coding := strings.ToLower(strings.TrimSpace(entryParts[0]))
+		acceptable := true
+		for _, parameter := range entryParts[1:] {
+			parameter = strings.TrimSpace(parameter)
+			if strings.HasPrefix(parameter, contentEncodingQualityPrefix) {
+				quality, parseError := strconv.ParseFloat(strings.TrimPrefix(parameter, contentEncodingQualityPrefix), 64)
+				acceptable = parseError == nil && quality > 0
+			}
+		}
+		accepted[coding] = acceptable
+	}
+	for _, coding := range []string{contentEncodingGzip, contentEncodingDeflate} {
+		if accepted[coding] {
+			return coding
+		}
+	}
+	return constants.EmptyString
+}
+
+// compressionMiddleware compresses response bodies with gzip or deflate when the client's Accept-Encoding header
+// allows it. Server-sent event streams are never compressed.
+func compressionMiddleware() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		ginContext.Writer.Header().Add(headerVary, headerAcceptEncoding)
+		contentEncoding := negotiateContentEncoding(ginContext.GetHeader(headerAcceptEncoding))
+		if contentEncoding == constants.EmptyString {
+			ginContext.Next()
+			return
+		}
+		compressingWriter := &compressingResponseWriter{ResponseWriter: ginContext.Writer, contentEncoding: contentEncoding}
+		ginContext.Writer = compressingWriter
+		defer compressingWriter.close()
+		ginContext.Next()
+	}
+}
@@ -2,10 +2,13 @@ package proxy
 
 import (
 	"errors"
+	"math"
 	"strings"
 
 	"github.com/temirov/llm-proxy/internal/apperrors"
 	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/utils"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -15,17 +18,68 @@ const (
 	DefaultWorkers = 4
 	// DefaultQueueSize is the capacity of the internal request queue.
 	DefaultQueueSize = 100
-	// DefaultModel is the model identifier used when the client does not supply one.
+	// DefaultModel is the model identifier used when neither the client nor Configuration.DefaultModel supplies one.
 	DefaultModel = ModelNameGPT41
 
 	DefaultRequestTimeoutSeconds      = 180 // overall app-side request timeout
 	DefaultUpstreamPollTimeoutSeconds = 60  // poll budget after "incomplete"
 	DefaultMaxOutputTokens            = 1024
+	// DefaultPollIntervalMillis is the wait between polls of an incomplete response when PollIntervalMillis is not set.
+	DefaultPollIntervalMillis = 500
+	// DefaultUpstreamHealthCacheMillis is how long an upstream health probe outcome is reused when
+	// UpstreamHealthCacheMillis is not set.
+	DefaultUpstreamHealthCacheMillis = 5000
+	// DefaultMaxOutputTokensCeiling is the largest max_tokens value a request may ask for unless configured otherwise.
+	DefaultMaxOutputTokensCeiling = 16384
+	// DefaultMinRequestTimeoutSeconds is the shortest timeout a request may ask for unless configured otherwise.
+	DefaultMinRequestTimeoutSeconds = 1
+	// DefaultMaxRequestTimeoutSeconds is the longest timeout a request may ask for unless configured otherwise.
+	DefaultMaxRequestTimeoutSeconds = 600
+	// DefaultDiskQueueMaxEntries bounds the disk overflow queue when DiskQueuePath is set without a size.
+	DefaultDiskQueueMaxEntries = 1000
+	// DefaultMaxTools bounds the number of tools a request may attach when MaxTools is not set. Each tool type is
+	// attached at most once, so it equals the number of supported tool types and lets a request attach them all.
+	DefaultMaxTools = 3
+	// DefaultShutdownGraceSeconds bounds a graceful shutdown when ShutdownGraceSeconds is not set.
+	DefaultShutdownGraceSeconds = 30
+	// DefaultResponseCacheTTLSeconds is how long cached answers live when ResponseCacheTTLSeconds is not set.
+	DefaultResponseCacheTTLSeconds = 300
+	// DefaultCircuitBreakerWindowSeconds is the failure window when CircuitBreakerWindowSeconds is not set.
+	DefaultCircuitBreakerWindowSeconds = 60
+	// DefaultCircuitBreakerCooldownSeconds is the open-circuit cooldown when CircuitBreakerCooldownSeconds is not set.
+	DefaultCircuitBreakerCooldownSeconds = 30
+	// ExtractionStrategyOutputTextFirst reads output_text before the assistant message.
+	ExtractionStrategyOutputTextFirst = "output_text_first"
+	// ExtractionStrategyMessageFirst reads the assistant message before output_text.
+	ExtractionStrategyMessageFirst = "message_first"
+	// AccessLogFormatJSON logs requests and responses as structured JSON events.
+	AccessLogFormatJSON = "json"
+	// AccessLogFormatCLF logs each request as one Common Log Format line.
+	AccessLogFormatCLF = "clf"
+	// ProviderOpenAI addresses the OpenAI API or an OpenAI-compatible gateway.
+	ProviderOpenAI = "openai"
+	// ProviderAzure addresses Azure OpenAI deployments.
+	ProviderAzure = "azure"
+	// DefaultAzureAPIVersion is the api-version sent to Azure OpenAI when AzureAPIVersion is not set.
+	DefaultAzureAPIVersion = "2025-04-01-preview"
+	// ResponseFormatJSON names the JSON envelope response format.
+	ResponseFormatJSON = "json"
+	// ResponseFormatXML names the XML envelope response format.
+	ResponseFormatXML = "xml"
+	// ResponseFormatYAML names the YAML response format.
+	ResponseFormatYAML = "yaml"
+	// ResponseFormatCSV names the CSV response format.
+	ResponseFormatCSV = "csv"
+	// ResponseFormatText names the plain text response format, which is always enabled.
+	ResponseFormatText = "text"
 )
 
 // Configuration holds runtime settings.
 type Configuration struct {
-	ServiceSecret              string
+	ServiceSecret string
+	// ServiceSecrets lists additional client keys accepted alongside ServiceSecret, which allows rotating secrets
+	// without downtime.
+	ServiceSecrets             []string
 	OpenAIKey                  string
 	Port                       int
 	LogLevel                   string
@@ -35,7 +89,209 @@ type Configuration struct {
 	RequestTimeoutSeconds      int
 	UpstreamPollTimeoutSeconds int
 	MaxOutputTokens            int
-	Endpoints                  *Endpoints
+	// MaxOutputTokensCeiling is the largest output token limit a request may ask for through max_tokens.
+	MaxOutputTokensCeiling int
+	// MaxPromptBytes enables POST /ask-file, which takes the prompt from an uploaded file of at most this many bytes
+	// and the other parameters, including key, from form fields; zero disables the endpoint.
+	MaxPromptBytes int
+	// MinRequestTimeoutSeconds and MaxRequestTimeoutSeconds bound the timeout query parameter, which replaces
+	// RequestTimeoutSeconds as the deadline for queueing a request and waiting for its reply. Upstream calls keep
+	// the RequestTimeoutSeconds budget, so both bounds are capped at it and a request may only shorten its deadline.
+	MinRequestTimeoutSeconds int
+	MaxRequestTimeoutSeconds int
+	// DisableSystemPromptOverride stops clients from replacing SystemPrompt through the system_prompt query
+	// parameter; the zero value keeps overrides enabled.
+	DisableSystemPromptOverride bool
+	// SystemPromptTemplates maps names to operator-curated system prompts that clients select with the
+	// system_prompt_name query parameter. A permitted system_prompt override still takes precedence.
+	SystemPromptTemplates map[string]string
+	// EnableCompression compresses responses to prompt requests with gzip or deflate when the client's
+	// Accept-Encoding header allows it. Streamed responses are sent uncompressed.
+	EnableCompression bool
+	// PromptPrefix and PromptSuffix wrap the input sent upstream: the prefix precedes the system prompt and the suffix
+	// follows the user prompt. A system_prompt override replaces only the part between them.
+	PromptPrefix string
+	PromptSuffix string
+	// PromptPrefixModelMap maps prompt directives such as "@fast:" to model identifiers.
+	// A recognized directive is stripped from the prompt and selects the model unless the model parameter is present.
+	PromptPrefixModelMap map[string]string
+	// DefaultModel is the model used when a request names none; blank selects the package DefaultModel.
+	// BuildRouter rejects a model that is not recognized.
+	DefaultModel string
+	// ModelAliases maps client-facing model names such as "fast" to model identifiers.
+	// The model parameter is resolved through this table before validation; names without an alias are validated as given.
+	ModelAliases map[string]string
+	// LogRedactedFields lists JSON paths such as output[].content[].text whose values are masked in logged upstream bodies.
+	// Listing output_text also masks the extracted response text in the info-level response log.
+	LogRedactedFields []string
+	// RedactPrompts replaces prompt and response text in logged upstream bodies, the logged response text and the
+	// prompt query parameters of request logs with redactedPlaceholder, leaving status and latency fields intact.
+	RedactPrompts bool
+	// DiskQueuePath enables a disk-backed overflow buffer in this directory for tasks that do not fit in the in-memory queue.
+	DiskQueuePath string
+	// DiskQueueMaxEntries bounds the number of tasks held in the disk overflow buffer.
+	DiskQueueMaxEntries int
+	// AuditLogPath enables an append-only audit log of hashed prompts and responses at this file path.
+	AuditLogPath string
+	// PassthroughHeaders lists inbound request headers, such as X-Trace-Id, copied onto the upstream OpenAI requests
+	// made for the request. Authorization may not be listed.
+	PassthroughHeaders []string
+	// TrustedProxies lists proxy addresses or CIDR ranges whose forwarding headers determine the client IP.
+	// When empty, no proxy is trusted and the client IP is always the direct remote address.
+	TrustedProxies []string
+	// MaxFormattedBytes caps the size of a formatted response body; zero disables the cap.
+	MaxFormattedBytes int
+	// RejectDuplicateParams answers 400 when key, model or web_search appears more than once in the query string.
+	RejectDuplicateParams bool
+	// WarmupEnabled sends a tiny prompt to the default model while the router is built.
+	WarmupEnabled bool
+	// WarmupFailureFatal makes BuildRouter fail when the warm-up request fails instead of only logging it.
+	WarmupFailureFatal bool
+	// StartupTimeoutSeconds bounds how long BuildRouter waits for the warm-up request; zero waits for the request
+	// timeout. The model validator uses a static table, so the warm-up is the only upstream call made at startup.
+	StartupTimeoutSeconds int
+	// ShutdownGraceSeconds bounds how long a graceful shutdown waits for in-flight requests and queued tasks.
+	ShutdownGraceSeconds int
+	// ResponseCacheSize is the number of answers kept in the in-memory LRU response cache; zero disables caching.
+	// Identical non-streamed requests within the time to live are answered without contacting OpenAI.
+	ResponseCacheSize int
+	// ResponseCacheTTLSeconds is how long a cached answer may be served.
+	ResponseCacheTTLSeconds int
+	// EnabledFormats lists the response formats (json, xml, yaml, csv) offered to clients; empty enables all of them.
+	// Plain text is always enabled. A disabled format negotiated through the Accept header falls back to plain text,
+	// while one named by the format parameter is rejected with 406.
+	EnabledFormats []string
+	// DisableFormatting returns the model text unchanged as text/plain, ignoring the format parameter and Accept header.
+	DisableFormatting bool
+	// RejectFallbackAnswer answers 502 instead of the "Model did not provide a final answer" text when the model
+	// finished a web search without producing an answer.
+	RejectFallbackAnswer bool
+	// ExposeVersionHeader reports the proxy build version in the X-Proxy-Version header of every response.
+	// The command-line interface enables it by default.
+	ExposeVersionHeader bool
+	// IncludeFinishReason exposes why the model stopped generating in the X-Finish-Reason response header.
+	IncludeFinishReason bool
+	// RetryOnLengthTruncation re-issues a request once with a doubled output budget, capped at the model ceiling,
+	// when the answer stops at the output token limit.
+	RetryOnLengthTruncation bool
+	// RetryOnParseFailure re-issues a request once when the initial response body is not valid JSON, as happens
+	// with a truncated upstream reply.
+	RetryOnParseFailure bool
+	// SynthesisBudgetFraction limits each synthesis poll phase to this fraction, between 0 and 1, of the request
+	// budget remaining when the phase starts. Zero leaves only UpstreamPollTimeoutSeconds in effect.
+	SynthesisBudgetFraction float64
+	// ExposeUpstreamLatency reports the cumulative latency of the upstream calls in the X-Upstream-Latency-Ms header.
+	ExposeUpstreamLatency bool
+	// ExposeQueueWait reports how long a request waited for a worker in the X-Queue-Wait-Ms header.
+	ExposeQueueWait bool
+	// SkipModelValidation accepts every model identifier instead of rejecting models missing from the payload schema
+	// table, so that unknown models are forwarded to the upstream with the full-capability payload.
+	SkipModelValidation bool
+	// SendRequestIDToUpstream attaches the X-Request-ID correlation id of each request to the upstream metadata.
+	SendRequestIDToUpstream bool
+	// TrimTrailingNewline removes trailing whitespace and newlines from the model text before it is formatted.
+	TrimTrailingNewline bool
+	// ExtractionStrategy selects which part of the upstream response is read first: ExtractionStrategyOutputTextFirst
+	// (the default) or ExtractionStrategyMessageFirst.
+	ExtractionStrategy string
+	// AccessLogFormat selects how requests are logged at info and debug levels: AccessLogFormatJSON (the default)
+	// emits structured events, and AccessLogFormatCLF writes Common Log Format lines to AccessLogWriter.
+	AccessLogFormat string
+	// RecentBufferSize is the number of request summaries kept for GET /recent; zero disables the endpoint.
+	RecentBufferSize int
+	// RateLimitPerSecond is the sustained number of requests per second allowed from one client address; zero
+	// disables rate limiting.
+	RateLimitPerSecond float64
+	// RateLimitBurst is the number of requests a client address may send at once before RateLimitPerSecond applies.
+	RateLimitBurst int
+	// ModelRateLimits caps the requests per minute served by each model across all clients, in addition to any
+	// per-client limit. Models without an entry are not limited.
+	ModelRateLimits map[string]int
+	// ModelOutputTokenCeilings caps the output token budget sent upstream for each model; larger budgets are clamped
+	// to the ceiling. Models without an entry are bounded only by MaxOutputTokensCeiling.
+	ModelOutputTokenCeilings map[string]int
+	// ModelPricing maps model identifiers to operator-configured token prices served by GET /pricing.
+	// The endpoint is registered only when at least one price is configured.
+	ModelPricing map[string]ModelPrice
+	// ReportCost adds an X-Estimated-Cost-USD header to non-streamed responses, pricing the token usage reported
+	// upstream with ModelPricing. Responses without usage or from unpriced models carry no header.
+	ReportCost bool
+	// PollIntervalMillis is the wait between polls of an incomplete upstream response; zero uses
+	// DefaultPollIntervalMillis.
+	PollIntervalMillis int
+	// UpstreamHealthCacheMillis is how long the outcome of an upstream health probe is reused before OpenAI is probed
+	// again; zero uses DefaultUpstreamHealthCacheMillis.
+	UpstreamHealthCacheMillis int
+	// RetryInitialIntervalMilliseconds is the wait before the first retry of a failed upstream request; zero keeps
+	// the backoff library default of 500 ms.
+	RetryInitialIntervalMilliseconds int
+	// RetryMultiplier scales the wait after each retry of an upstream request; zero keeps the library default of 1.5.
+	RetryMultiplier float64
+	// RetryMaxElapsedMilliseconds stops retrying an upstream request once this much time has passed since the first
+	// attempt; zero keeps the library default of 15 minutes. Retries never outlast the request deadline.
+	RetryMaxElapsedMilliseconds int
+	// VerboseQueueFull renders the 503 sent when the request queue is full in the negotiated response format,
+	// reporting the current queue length, the queue capacity and the suggested Retry-After seconds.
+	VerboseQueueFull bool
+	// StuckSessionPollThreshold escalates a session resumed through the continue endpoint to a synthesis
+	// continuation once this many consecutive polls report the same status and output; zero keeps polling until
+	// the poll budget runs out.
+	StuckSessionPollThreshold int
+	// DegradeReasoningUnderLoad lowers the reasoning effort sent to reasoning models by one level, high to medium
+	// and medium to low, while more than DegradeReasoningQueueDepth requests wait in the queue, so that a backlog
+	// drains faster. Requests taken from a shorter queue keep their usual effort.
+	DegradeReasoningUnderLoad bool
+	// DegradeReasoningQueueDepth is the queue length above which reasoning effort is degraded; zero selects half
+	// of QueueSize.
+	DegradeReasoningQueueDepth int
+	// MirrorUpstreamStatus answers a request that failed on an upstream error response with that response's status
+	// code and error message instead of mapping it to 502 or 504.
+	MirrorUpstreamStatus bool
+	// ModelPools partitions the workers into named pools, each with its own queue and workers, serving the listed
+	// models. Models outside every pool are served by the WorkerCount workers of the QueueSize default queue.
+	ModelPools map[string]ModelPool
+	// DeprecatedModels lists models that are still served but answered with a Warning header announcing their
+	// deprecation, so that clients can migrate before the models are retired.
+	DeprecatedModels []string
+	// MaxTools caps the number of tools, web_search included, that a request may attach; requests exceeding it get
+	// 400. Zero selects DefaultMaxTools.
+	MaxTools int
+	// TracerProvider receives a root span per request with child spans around the upstream calls; nil disables
+	// tracing.
+	TracerProvider trace.TracerProvider
+	// MaxConcurrentRequests bounds the requests handled at once, queued or in progress; requests beyond it get 503
+	// at once instead of waiting for a queue slot. Zero disables the cap.
+	MaxConcurrentRequests int
+	// CircuitBreakerFailureThreshold opens the upstream circuit after this many consecutive failed upstream calls
+	// within CircuitBreakerWindowSeconds; zero disables the breaker. While open, requests get 503 until
+	// CircuitBreakerCooldownSeconds pass, after which a single probe decides whether the circuit closes again.
+	CircuitBreakerFailureThreshold int
+	// CircuitBreakerWindowSeconds is the span within which consecutive failures count toward the threshold.
+	CircuitBreakerWindowSeconds int
+	// CircuitBreakerCooldownSeconds is how long an open circuit rejects requests before probing the upstream.
+	CircuitBreakerCooldownSeconds int
+	// FallbackModels lists models tried in order when a non-streamed request fails upstream. Upstream server errors
+	// are retried only briefly when fallbacks are configured so the next model still fits in the request budget.
+	// BuildRouter rejects a model that is not recognized.
+	FallbackModels []string
+	// ABTestModel is a candidate model that serves ABTestPercentage percent of the requests that would use the
+	// default model. Requests naming a model explicitly, or through a prompt directive, are never split.
+	ABTestModel string
+	// ABTestPercentage is the share of default-model requests, from 0 to 100, routed to ABTestModel.
+	ABTestPercentage int
+	// OpenAIOrganization is sent upstream in the OpenAI-Organization header when set.
+	OpenAIOrganization string
+	// OpenAIProject is sent upstream in the OpenAI-Project header when set.
+	OpenAIProject string
+	// OpenAIBaseURL points the proxy at an OpenAI-compatible API such as Azure OpenAI or a local gateway.
+	// It is ignored when Endpoints is set; blank keeps the public OpenAI API.
+	OpenAIBaseURL string
+	// Provider selects the upstream URL and authentication scheme: ProviderOpenAI (the default) or ProviderAzure,
+	// which addresses <OpenAIBaseURL>/openai/deployments/<model>/responses with an api-key header.
+	Provider string
+	// AzureAPIVersion is the api-version query parameter sent to Azure OpenAI; blank uses DefaultAzureAPIVersion.
+	AzureAPIVersion string
+	Endpoints       *Endpoints
 }
 
 // validateConfig confirms required settings are present.
@@ -46,21 +302,204 @@ func validateConfig(config Configuration) error {
 	if strings.TrimSpace(config.OpenAIKey) == constants.EmptyString {
 		return apperrors.ErrMissingOpenAIKey
 	}
+	if config.SynthesisBudgetFraction < 0 || config.SynthesisBudgetFraction > 1 {
+		return ErrInvalidSynthesisBudgetFraction
+	}
+	if config.ABTestPercentage < 0 || config.ABTestPercentage > abTestPercentageScale {
+		return ErrInvalidABTestPercentage
+	}
+	if config.RateLimitPerSecond < 0 {
+		return ErrInvalidRateLimit
+	}
+	for _, modelLimit := range config.ModelRateLimits {
+		if modelLimit < 0 {
+			return ErrInvalidRateLimit
+		}
+	}
+	for _, outputTokenCeiling := range config.ModelOutputTokenCeilings {
+		if outputTokenCeiling <= 0 {
+			return ErrInvalidModelOutputTokenCeiling
+		}
+	}
+	if config.MinRequestTimeoutSeconds < 0 || config.MaxRequestTimeoutSeconds < 0 {
+		return ErrInvalidRequestTimeoutRange
+	}
+	if config.MinRequestTimeoutSeconds > 0 && config.MaxRequestTimeoutSeconds > 0 && config.MinRequestTimeoutSeconds > config.MaxRequestTimeoutSeconds {
+		return ErrInvalidRequestTimeoutRange
+	}
+	if config.PollIntervalMillis < 0 {
+		return ErrInvalidPollInterval
+	}
+	if config.RetryInitialIntervalMilliseconds < 0 || config.RetryMaxElapsedMilliseconds < 0 {
+		return ErrInvalidRetryBackoff
+	}
+	if config.RetryMultiplier != 0 && config.RetryMultiplier < 1 {
+		return ErrInvalidRetryBackoff
+	}
+	for _, enabledFormat := range config.EnabledFormats {
+		switch enabledFormat {
+		case ResponseFormatJSON, ResponseFormatXML, ResponseFormatYAML, ResponseFormatCSV, ResponseFormatText:
+		default:
+			return ErrInvalidEnabledFormat
+		}
+	}
+	for _, modelPrice := range config.ModelPricing {
+		if modelPrice.InputPerMillionTokens < 0 || modelPrice.OutputPerMillionTokens < 0 {
+			return ErrInvalidModelPricing
+		}
+	}
+	if passthroughError := validatePassthroughHeaders(config.PassthroughHeaders); passthroughError != nil {
+		return passthroughError
+	}
+	if modelPoolsError := validateModelPools(config.ModelPools, config.ModelAliases); modelPoolsError != nil {
+		return modelPoolsError
+	}
+	switch config.ExtractionStrategy {
+	case constants.EmptyString, ExtractionStrategyOutputTextFirst, ExtractionStrategyMessageFirst:
+	default:
+		return ErrInvalidExtractionStrategy
+	}
+	if !utils.IsBlank(config.OpenAIBaseURL) {
+		if _, baseURLError := NewEndpointsFromBaseURL(config.OpenAIBaseURL); baseURLError != nil {
+			return baseURLError
+		}
+	}
+	switch config.AccessLogFormat {
+	case constants.EmptyString, AccessLogFormatJSON, AccessLogFormatCLF:
+	default:
+		return ErrInvalidAccessLogFormat
+	}
+	switch config.Provider {
+	case constants.EmptyString, ProviderOpenAI:
+	case ProviderAzure:
+		if config.Endpoints == nil && utils.IsBlank(config.OpenAIBaseURL) {
+			return ErrMissingAzureBaseURL
+		}
+	default:
+		return ErrInvalidProvider
+	}
 	return nil
 }
 
 // ErrUpstreamIncomplete indicates that the upstream provider returned an incomplete response before the poll deadline.
 var ErrUpstreamIncomplete = errors.New(errorUpstreamIncomplete)
 
+// ErrWarmupFailed indicates that the startup warm-up request did not succeed.
+var ErrWarmupFailed = errors.New(errorWarmupFailed)
+
+// ErrInvalidRateLimit indicates a negative per-client or per-model rate limit.
+var ErrInvalidRateLimit = errors.New(errorRateLimit)
+
+// errSessionStuck indicates that a continued session kept returning the same non-terminal state.
+var errSessionStuck = errors.New(errorSessionStuck)
+
+// ErrUpstreamCircuitOpen indicates that the upstream circuit breaker is open and the request was not sent.
+var ErrUpstreamCircuitOpen = errors.New(errorUpstreamCircuitOpen)
+
+// ErrUpstreamRateLimited indicates that the upstream provider still answered 429 after the retries were exhausted.
+var ErrUpstreamRateLimited = errors.New(errorUpstreamRateLimited)
+
+// ErrInvalidEnabledFormat indicates an enabled response format other than the supported values.
+var ErrInvalidEnabledFormat = errors.New(errorEnabledFormat)
+
+// ErrInvalidRequestTimeoutRange indicates a negative request timeout bound or a minimum above the maximum.
+var ErrInvalidRequestTimeoutRange = errors.New(errorRequestTimeoutRange)
+
+// ErrInvalidRetryBackoff indicates a negative retry interval or elapsed time, or a retry multiplier below one.
+var ErrInvalidRetryBackoff = errors.New(errorRetryBackoff)
+
+// ErrInvalidPollInterval indicates a negative interval between polls of an incomplete response.
+var ErrInvalidPollInterval = errors.New(errorPollInterval)
+
+// ErrInvalidModelOutputTokenCeiling indicates a per-model output token ceiling that is zero or negative.
+var ErrInvalidModelOutputTokenCeiling = errors.New(errorModelOutputTokenCeiling)
+
+// ErrInvalidPassthroughHeaders indicates a passthrough header allowlist naming the Authorization header.
+var ErrInvalidPassthroughHeaders = errors.New(errorPassthroughHeaders)
+
+// ErrInvalidModelPricing indicates a negative model token price.
+var ErrInvalidModelPricing = errors.New(errorModelPricing)
+
+// ErrInvalidModelPools indicates a model pool without models or workers, or a model assigned to two pools.
+var ErrInvalidModelPools = errors.New(errorModelPools)
+
+// ErrStartupTimeout indicates that the startup warm-up did not finish within StartupTimeoutSeconds.
+var ErrStartupTimeout = errors.New(errorStartupTimeout)
+
+// ErrInvalidABTestPercentage indicates that the A/B test percentage is outside the 0-100 range.
+var ErrInvalidABTestPercentage = errors.New(errorABTestPercentage)
+
+// ErrInvalidSynthesisBudgetFraction indicates that the synthesis budget fraction is outside the 0-1 range.
+var ErrInvalidSynthesisBudgetFraction = errors.New(errorSynthesisBudgetFraction)
+
+// ErrInvalidExtractionStrategy indicates that the extraction strategy is not one of the supported values.
+var ErrInvalidExtractionStrategy = errors.New(errorExtractionStrategy)
+
+// ErrInvalidAccessLogFormat indicates that the access log format is not one of the supported values.
+var ErrInvalidAccessLogFormat = errors.New(errorAccessLogFormat)
+
+// ErrInvalidProvider indicates that the provider is not one of the supported values.
+var ErrInvalidProvider = errors.New(errorProvider)
+
+// ErrMissingAzureBaseURL indicates the azure provider configured without the base URL of the Azure resource.
+var ErrMissingAzureBaseURL = errors.New(errorMissingAzureBaseURL)
+
+// ErrUpstreamErrorObject indicates that the upstream provider returned an error object in a successful HTTP response.
+var ErrUpstreamErrorObject = errors.New(errorOpenAIAPI)
+
+// ErrMalformedUpstreamResponse indicates that a successful upstream response body is not valid JSON.
+var ErrMalformedUpstreamResponse = errors.New(errorOpenAIAPI)
+
 // ApplyTunables ensures tunable configuration values have sensible defaults.
 func (configuration *Configuration) ApplyTunables() {
 	if configuration.RequestTimeoutSeconds <= 0 {
 		configuration.RequestTimeoutSeconds = DefaultRequestTimeoutSeconds
 	}
+	if configuration.CircuitBreakerWindowSeconds <= 0 {
+		configuration.CircuitBreakerWindowSeconds = DefaultCircuitBreakerWindowSeconds
+	}
+	if configuration.CircuitBreakerCooldownSeconds <= 0 {
+		configuration.CircuitBreakerCooldownSeconds = DefaultCircuitBreakerCooldownSeconds
+	}
 	if configuration.UpstreamPollTimeoutSeconds <= 0 {
 		configuration.UpstreamPollTimeoutSeconds = DefaultUpstreamPollTimeoutSeconds
 	}
 	if configuration.MaxOutputTokens <= 0 {
 		configuration.MaxOutputTokens = DefaultMaxOutputTokens
 	}
+	if utils.IsBlank(configuration.DefaultModel) {
+		configuration.DefaultModel = DefaultModel
+	}
+	if utils.IsBlank(configuration.AzureAPIVersion) {
+		configuration.AzureAPIVersion = DefaultAzureAPIVersion
+	}
+	if configuration.MaxOutputTokensCeiling <= 0 {
+		configuration.MaxOutputTokensCeiling = DefaultMaxOutputTokensCeiling
+	}
+	if configuration.MinRequestTimeoutSeconds <= 0 {
+		configuration.MinRequestTimeoutSeconds = DefaultMinRequestTimeoutSeconds
+	}
+	if configuration.MaxRequestTimeoutSeconds <= 0 {
+		configuration.MaxRequestTimeoutSeconds = max(DefaultMaxRequestTimeoutSeconds, configuration.MinRequestTimeoutSeconds)
+	}
+	configuration.MaxRequestTimeoutSeconds = min(configuration.MaxRequestTimeoutSeconds, configuration.RequestTimeoutSeconds)
+	configuration.MinRequestTimeoutSeconds = min(configuration.MinRequestTimeoutSeconds, configuration.MaxRequestTimeoutSeconds)
+	if configuration.RateLimitPerSecond > 0 && configuration.RateLimitBurst <= 0 {
+		configuration.RateLimitBurst = max(1, int(math.Ceil(configuration.RateLimitPerSecond)))
+	}
+	if configuration.ResponseCacheTTLSeconds <= 0 {
+		configuration.ResponseCacheTTLSeconds = DefaultResponseCacheTTLSeconds
+	}
+	if configuration.ShutdownGraceSeconds <= 0 {
+		configuration.ShutdownGraceSeconds = DefaultShutdownGraceSeconds
+	}
+	if configuration.DiskQueueMaxEntries <= 0 {
+		configuration.DiskQueueMaxEntries = DefaultDiskQueueMaxEntries
+	}
+	if configuration.MaxTools <= 0 {
+		configuration.MaxTools = DefaultMaxTools
+	}
+	if configuration.DegradeReasoningQueueDepth <= 0 {
+		configuration.DegradeReasoningQueueDepth = configuration.QueueSize / 2
+	}
 }
@@ -11,6 +11,23 @@ const (
 	headerContentType         = "Content-Type"
 	headerAccept              = "Accept"
 	headerAuthorizationPrefix = "Bearer "
+	// headerAcceptEncoding lists the content codings a client accepts.
+	headerAcceptEncoding = "Accept-Encoding"
+	// headerContentEncoding names the content coding applied to a response body.
+	headerContentEncoding = "Content-Encoding"
+	// headerContentLength carries the size of a response body.
+	headerContentLength = "Content-Length"
+	// headerVary lists the request headers that select between response variants.
+	headerVary = "Vary"
+
+	// headerOpenAIOrganization attributes upstream usage to an OpenAI organization.
+	headerOpenAIOrganization = "OpenAI-Organization"
+	// headerOpenAIProject attributes upstream usage to an OpenAI project.
+	headerOpenAIProject = "OpenAI-Project"
+	// headerAzureAPIKey carries the API key of an Azure OpenAI resource.
+	headerAzureAPIKey = "api-key"
+	// queryParameterAzureAPIVersion selects the Azure OpenAI API version.
+	queryParameterAzureAPIVersion = "api-version"
 
 	// rootPath defines the HTTP path for the root endpoint.
 	rootPath = "/"
@@ -21,16 +38,133 @@ const (
 	queryParameterWebSearch    = "web_search"
 	queryParameterSystemPrompt = "system_prompt"
 	queryParameterFormat       = "format"
+	// queryParameterTimeout overrides the request timeout, in seconds, for a single request.
+	queryParameterTimeout = "timeout"
+	// queryParameterMaxTokens overrides the output token limit for a single request.
+	queryParameterMaxTokens = "max_tokens"
+	// queryParameterStream switches the response to server-sent events relaying upstream text deltas.
+	queryParameterStream = "stream"
+	// queryParameterTemperature overrides the sampling temperature for models that accept one.
+	queryParameterTemperature = "temperature"
+	// queryParameterReasoningEffort selects the reasoning effort for reasoning-capable models.
+	queryParameterReasoningEffort = "reasoning_effort"
+	// queryParameterResponseSchema carries a JSON schema that constrains the answer to structured output.
+	queryParameterResponseSchema = "response_schema"
+	// queryParameterSeed requests deterministic sampling with the given integer seed.
+	queryParameterSeed = "seed"
+	// queryParameterTools lists further hosted tools, separated by commas, to attach to the upstream request.
+	queryParameterTools = "tools"
+	// queryParameterCandidateCount requests several candidate answers for the same prompt.
+	queryParameterCandidateCount = "n"
+	// candidateSeparator joins candidate answers in non-JSON responses.
+	candidateSeparator = "\n"
+	// queryParameterSearchLocation narrows web search results to a country, optionally followed by a city.
+	queryParameterSearchLocation = "search_location"
+	// queryParameterSearchContext selects how much search context the web search tool gathers.
+	queryParameterSearchContext = "search_context"
+	// searchLocationSeparator separates the country from the city in the search_location parameter.
+	searchLocationSeparator = "/"
+	// toolListSeparator separates tool types in the tools parameter.
+	toolListSeparator = ","
+	// queryParameterCSVMode selects a single CSV cell or one CSV row per response line.
+	queryParameterCSVMode = "csv_mode"
+	// queryParameterCSVPrompt adds the prompt as the first column of each row in csv_mode=rows.
+	queryParameterCSVPrompt = "csv_prompt"
+	// queryParameterCSVHeader starts CSV responses with a request,response header row.
+	queryParameterCSVHeader = "header"
+	// csvModeSingle wraps the whole response in one CSV cell.
+	csvModeSingle = "single"
+	// csvModeRows emits one CSV row per non-blank response line.
+	csvModeRows = "rows"
+	// carriageReturn ends a line together with a line feed in CRLF text.
+	carriageReturn = "\r"
+	// queryParameterDebugEcho requests that JSON responses include the resolved request parameters.
+	queryParameterDebugEcho = "debug_echo"
+	// queryParameterSystemPromptName selects one of the configured system prompt templates.
+	queryParameterSystemPromptName = "system_prompt_name"
+	// queryParameterPreviousResponseID continues the conversation of an earlier upstream response.
+	queryParameterPreviousResponseID = "previous_response_id"
+	// queryParameterDryRun returns the upstream request body instead of sending it.
+	queryParameterDryRun = "dry_run"
 
 	redactedPlaceholder = "***REDACTED***"
 
+	// overridePromptPrefix names the prompt directive override in request echoes.
+	overridePromptPrefix = "prompt_prefix"
+
 	mimeApplicationJSON = "application/json"
 	mimeApplicationXML  = "application/xml"
 	mimeTextXML         = "text/xml"
 	mimeTextCSV         = "text/csv"
+	// mimeApplicationYAML is the media type of YAML documents.
+	mimeApplicationYAML = "application/yaml"
 	mimeTextPlain       = "text/plain; charset=utf-8"
+	// mimeTextEventStream is the media type of server-sent event streams.
+	mimeTextEventStream = "text/event-stream"
+	// mimeTextPlainBase is the text/plain media type without parameters, as reported by request content types.
+	mimeTextPlainBase = "text/plain"
+	// mimeApplicationFormURLEncoded is the media type of HTML form bodies.
+	mimeApplicationFormURLEncoded = "application/x-www-form-urlencoded"
+
+	// maxPromptBodyBytes bounds the size of a POST request body carrying a prompt.
+	maxPromptBodyBytes = 1 << 20
 
 	errorMissingPrompt = "missing prompt parameter"
+	// errorInvalidMaxTokens indicates a max_tokens value that is not a positive integer.
+	errorInvalidMaxTokens = "max_tokens must be a positive integer"
+	// errorInvalidTimeoutFormat reports a timeout value that is not a whole number of seconds within the configured bounds.
+	errorInvalidTimeoutFormat = "timeout must be a whole number of seconds between %d and %d"
+	// errorMaxTokensAboveCeilingFormat reports a max_tokens value above the configured ceiling.
+	errorMaxTokensAboveCeilingFormat = "max_tokens must not exceed %d"
+	// errorInvalidTemperature indicates a temperature value outside the accepted range.
+	errorInvalidTemperature = "temperature must be a number between 0 and 2"
+	// errorRateLimited indicates a client that exceeded its request rate.
+	errorRateLimited = "rate limit exceeded"
+	// errorModelRateLimitedFormat indicates a model that exceeded its request rate.
+	errorModelRateLimitedFormat = "rate limit exceeded for model %s"
+	// errorInvalidCSVMode indicates a csv_mode value other than the supported modes.
+	errorInvalidCSVMode = "csv_mode must be single or rows"
+	// errorInvalidReasoningEffort indicates a reasoning_effort value outside the supported levels.
+	errorInvalidReasoningEffort = "reasoning_effort must be one of minimal, low, medium, high"
+	// errorInvalidResponseSchema indicates a response_schema value that is not a JSON object.
+	errorInvalidResponseSchema = "response_schema must be a JSON object"
+	// errorInvalidSeed indicates a seed value that is not an integer.
+	errorInvalidSeed = "seed must be an integer"
+	// errorUnsupportedToolFormat indicates a tools entry that names no supported hosted tool, followed by the
+	// supported tool types.
+	errorUnsupportedToolFormat = "unsupported tool %q; supported tools are %s"
+	// supportedToolListSeparator separates the supported tool types listed in errorUnsupportedToolFormat.
+	supportedToolListSeparator = ", "
+	// errorInvalidCandidateCountFormat indicates an n value that is not an integer between one and the maximum.
+	errorInvalidCandidateCountFormat = "n must be an integer between 1 and %d"
+	// errorStreamedCandidates indicates a streamed request asking for more than one candidate answer.
+	errorStreamedCandidates = "n above 1 cannot be combined with stream"
+	// errorInvalidSearchLocation indicates a search_location value that is not a country code optionally followed by a city.
+	errorInvalidSearchLocation = "search_location must be a two-letter country code optionally followed by /city"
+	// errorInvalidSearchContext indicates a search_context value outside the supported sizes.
+	errorInvalidSearchContext = "search_context must be one of low, medium, high"
+	// errorInvalidPreviousResponseID indicates a previous_response_id value that cannot name an upstream response.
+	errorInvalidPreviousResponseID = "previous_response_id must be 1-128 letters, digits, underscores or hyphens"
+	// errorUnknownSystemPromptName indicates a system_prompt_name value that names no configured template.
+	errorUnknownSystemPromptName = "system_prompt_name does not name a configured system prompt template"
+	// errorTooManyToolsFormat indicates a request attaching more tools than Configuration.MaxTools allows.
+	errorTooManyToolsFormat = "at most %d tools may be attached"
+	// errorInvalidRequestBody indicates that a POST body could not be read or parsed.
+	errorInvalidRequestBody = "invalid request body"
+	// errorUnsupportedUploadMediaType indicates an ask-file request that is not a multipart form.
+	errorUnsupportedUploadMediaType = "unsupported media type; use multipart/form-data"
+	// errorMissingPromptFile indicates an ask-file request without a prompt file.
+	errorMissingPromptFile = "missing prompt file"
+	// errorPromptTooLarge indicates an uploaded prompt larger than the configured maximum.
+	errorPromptTooLarge = "prompt file too large"
+	// errorMissingChatMessages indicates a chat completions request without any message text.
+	errorMissingChatMessages = "messages must contain at least one message with text"
+	// errorInvalidMessageContent indicates a chat message whose content is neither a string nor an array of parts.
+	errorInvalidMessageContent = "message content must be a string or an array of content parts"
+	// errorUnsupportedMediaType indicates a POST body that is neither form-encoded nor plain text.
+	errorUnsupportedMediaType = "unsupported media type; use application/x-www-form-urlencoded or text/plain"
+	// errorDuplicateParameterPrefix precedes the name of a query parameter that was supplied more than once.
+	errorDuplicateParameterPrefix = "duplicate query parameter: "
 	// errorMissingClientKey indicates that the key query parameter is missing.
 	errorMissingClientKey   = "unknown client key"
 	errorRequestTimedOut    = "request timed out"
@@ -45,14 +179,101 @@ const (
 	// errorUnknownModel indicates that a model identifier is not recognized.
 	errorUnknownModel   = "unknown model"
 	errorResponseFormat = "response formatting error"
+	// errorResponseTooLarge indicates that the formatted response would exceed the configured size cap.
+	errorResponseTooLarge = "response too large to format"
 	// errorQueueFull indicates that the internal request queue cannot accept additional tasks.
 	errorQueueFull = "request queue full"
+	// errorConcurrencyLimitReached indicates that Configuration.MaxConcurrentRequests requests are already in progress.
+	errorConcurrencyLimitReached = "too many concurrent requests"
+	// errorSynthesisBudgetFraction indicates a synthesis budget fraction outside the 0-1 range.
+	errorSynthesisBudgetFraction = "synthesis budget fraction must be between 0 and 1"
+	// errorExtractionStrategy indicates an extraction strategy other than the supported values.
+	errorExtractionStrategy = "extraction strategy must be output_text_first or message_first"
+	// errorAccessLogFormat indicates an access log format other than the supported values.
+	errorAccessLogFormat = "access log format must be json or clf"
+	// errorProvider indicates a provider other than the supported values.
+	errorProvider = "provider must be openai or azure"
+	// errorMissingAzureBaseURL indicates the azure provider configured without a base URL.
+	errorMissingAzureBaseURL = "azure provider requires the openai base url of the azure resource"
+	// errorOpenAIBaseURL indicates an upstream base URL that is not an absolute http or https URL.
+	errorOpenAIBaseURL = "openai base url must be an absolute http or https url"
+	// errorRequestTimeoutRange indicates negative request timeout bounds or a minimum above the maximum.
+	errorRequestTimeoutRange = "request timeout bounds must not be negative and the minimum must not exceed the maximum"
+	// errorRateLimit indicates a negative rate limit.
+	errorRateLimit = "rate limits must not be negative"
+	// errorSessionStuck indicates a continued session that stopped advancing.
+	errorSessionStuck = "continued session made no progress"
+	// errorUpstreamCircuitOpen indicates a request rejected because sustained upstream failures opened the circuit.
+	errorUpstreamCircuitOpen = "upstream unavailable; circuit open"
+	// errorUpstreamRateLimited indicates a request the upstream kept rejecting with 429 until retries were exhausted.
+	errorUpstreamRateLimited = "upstream rate limit exceeded"
+	// errorEnabledFormat indicates an enabled response format other than the supported values.
+	errorEnabledFormat = "enabled formats must be json, xml, yaml, csv or text"
+	// errorFormatDisabled indicates a format parameter naming a response format this proxy does not offer.
+	errorFormatDisabled = "requested format is disabled"
+	// errorRetryBackoff indicates retry backoff settings outside their valid ranges.
+	errorRetryBackoff = "retry intervals must not be negative and the retry multiplier must be at least 1"
+	// errorPollInterval indicates a negative poll interval.
+	errorPollInterval = "poll interval must not be negative"
+	// errorModelOutputTokenCeiling indicates a per-model output token ceiling that is not positive.
+	errorModelOutputTokenCeiling = "model output token ceilings must be positive"
+	// errorPassthroughHeaders indicates a passthrough header allowlist naming the Authorization header.
+	errorPassthroughHeaders = "passthrough headers must not include Authorization"
+	// errorModelPricing indicates a negative model token price.
+	errorModelPricing = "model prices must not be negative"
+	// errorModelPools indicates a model pool without models or workers, or a model assigned to two pools.
+	errorModelPools = "model pools need models and workers, a non-negative queue size, and distinct models"
+	// errorABTestPercentage indicates an A/B test percentage outside the 0-100 range.
+	errorABTestPercentage = "A/B test percentage must be between 0 and 100"
+	// errorDiskQueueFull indicates that the on-disk overflow buffer cannot accept additional tasks.
+	errorDiskQueueFull = "disk overflow queue full"
+	// errorUpstreamRequestRejectedFormat reports the message of an upstream error object describing a bad request.
+	errorUpstreamRequestRejectedFormat = "OpenAI rejected the request: %s"
+	// errorUpstreamDetailsFormat appends the type and parameter of an upstream error object to its message.
+	errorUpstreamDetailsFormat = "%s (%s)"
+	// errorUpstreamTypeFormat describes the type of an upstream error object.
+	errorUpstreamTypeFormat = "type: %s"
+	// errorUpstreamParamFormat describes the parameter an upstream error object refers to.
+	errorUpstreamParamFormat = "param: %s"
+	// errorUpstreamDetailSeparator separates the details of an upstream error object.
+	errorUpstreamDetailSeparator = ", "
+	// errorWrapWithDetailFormat appends upstream detail to a sentinel error.
+	errorWrapWithDetailFormat = "%w: %s"
+	// errorWarmupFailed indicates that the startup warm-up request did not succeed.
+	errorWarmupFailed = "upstream warm-up failed"
+	// errorStartupTimeout indicates that the startup warm-up did not finish within the startup timeout.
+	errorStartupTimeout = "startup timed out waiting for the upstream warm-up"
+	// errorWrapWithCauseFormat wraps a sentinel error together with the sentinel error that caused it.
+	errorWrapWithCauseFormat = "%w: %w"
+
+	// warmupPrompt is the throwaway prompt sent during the startup warm-up.
+	warmupPrompt = "ping"
+	// warmupMaxOutputTokens keeps the warm-up response as small as the upstream permits.
+	warmupMaxOutputTokens = 16
 
 	toolTypeWebSearch = "web_search"
+	// toolTypeWebSearchPreview identifies the preview release of the hosted web search tool.
+	toolTypeWebSearchPreview = "web_search_preview"
+	// toolTypeCodeInterpreter identifies the hosted code interpreter tool.
+	toolTypeCodeInterpreter = "code_interpreter"
+	// toolContainerTypeAuto lets the upstream create the code interpreter container.
+	toolContainerTypeAuto = "auto"
+	// userLocationTypeApproximate marks a web search user location as approximate, the only type the upstream accepts.
+	userLocationTypeApproximate = "approximate"
+	// searchContextSizeLow requests the least web search context.
+	searchContextSizeLow = "low"
+	// searchContextSizeMedium requests the default amount of web search context.
+	searchContextSizeMedium = "medium"
+	// searchContextSizeHigh requests the most web search context.
+	searchContextSizeHigh = "high"
 	// reasoningEffortMedium denotes a medium reasoning effort level.
 	reasoningEffortMedium = "medium"
 	// reasoningEffortMinimal denotes a minimal reasoning effort level.
 	reasoningEffortMinimal = "minimal"
+	// reasoningEffortLow denotes a low reasoning effort level.
+	reasoningEffortLow = "low"
+	// reasoningEffortHigh denotes a high reasoning effort level.
+	reasoningEffortHigh = "high"
 
 	// responseTypeMessage identifies a message output item in the upstream response.
 	responseTypeMessage = "message"
@@ -71,6 +292,8 @@ const (
 
 	// fallbackFinalAnswerFormat formats a message when the model does not provide a final answer.
 	fallbackFinalAnswerFormat = "Model did not provide a final answer. Last web search: \"%s\""
+	// errorFallbackAnswer reports that the model produced no answer and fallback answers are rejected.
+	errorFallbackAnswer = "OpenAI API error (no final answer)"
 
 	keyModel              = "model"
 	keyInput              = "input"
@@ -82,6 +305,8 @@ const (
 	keyReasoning          = "reasoning"
 	keyAuto               = "auto"
 	keyPreviousResponseID = "previous_response_id"
+	keyMetadata           = "metadata"
+	keySeed               = "seed"
 	keyEffort             = "effort"
 	keyText               = "text"
 	keyFormat             = "format"
@@ -89,11 +314,77 @@ const (
 	toolChoiceNone        = "none"
 	textFormatType        = "text"
 	verbosityLow          = "low"
+	// textFormatJSONSchema selects structured output constrained by a JSON schema.
+	textFormatJSONSchema = "json_schema"
+	// textFormatSchemaName names the JSON schema attached to structured output requests.
+	textFormatSchemaName = "response"
+	// metadataKeyProxyRequestID names the upstream metadata entry carrying the proxy correlation id.
+	metadataKeyProxyRequestID = "proxy_request_id"
 
 	jsonFieldID         = "id"
 	jsonFieldStatus     = "status"
 	jsonFieldOutputText = "output_text"
 	jsonFieldResponse   = "response"
+	// jsonFieldEcho holds the resolved request parameters in JSON responses.
+	jsonFieldEcho = "echo"
+	// jsonFieldError holds an error object in an upstream response body.
+	jsonFieldError = "error"
+	// jsonFieldMessage holds the human-readable message of an upstream error object.
+	jsonFieldMessage = "message"
+	// jsonFieldCode holds the machine-readable code of an upstream error object.
+	jsonFieldCode = "code"
+	// jsonFieldParam names the request parameter an upstream error object refers to.
+	jsonFieldParam = "param"
+	// jsonFieldOutput holds the output items of a Responses API payload.
+	jsonFieldOutput = "output"
+	// jsonFieldIncompleteDetails holds the reason an upstream response stopped early.
+	jsonFieldIncompleteDetails = "incomplete_details"
+	// jsonFieldReason holds the reason inside incomplete_details.
+	jsonFieldReason = "reason"
+	// jsonFieldChoices holds the choices array of a chat-completions response.
+	jsonFieldChoices = "choices"
+	// jsonFieldFinishReason holds the finish reason of a chat-completions choice.
+	jsonFieldFinishReason = "finish_reason"
+
+	// incompleteReasonMaxOutputTokens is the Responses API reason for hitting the output token limit.
+	incompleteReasonMaxOutputTokens = "max_output_tokens"
+	// finishReasonStop reports that generation finished naturally.
+	finishReasonStop = "stop"
+	// finishReasonLength reports that generation stopped at the output token limit.
+	finishReasonLength = "length"
+
+	// headerCacheControl controls caching of streamed responses.
+	headerCacheControl = "Cache-Control"
+	// cacheControlNoCache disables caching of streamed responses.
+	cacheControlNoCache = "no-cache"
+
+	// streamEventNameDelta labels server-sent events that carry a text increment.
+	streamEventNameDelta = "delta"
+	// streamEventNameDone labels the final server-sent event; its data is the finish reason when known.
+	streamEventNameDone = "done"
+	// streamEventNameError labels a server-sent event reporting a failure after streaming started.
+	streamEventNameError = "error"
+
+	// headerUpstreamLatency reports the cumulative upstream latency of a request in milliseconds.
+	headerUpstreamLatency = "X-Upstream-Latency-Ms"
+	// headerQueueWait reports how long a request waited for a worker, in milliseconds.
+	headerQueueWait = "X-Queue-Wait-Ms"
+	// headerResponseID identifies the upstream response that produced the answer.
+	headerResponseID = "X-Response-Id"
+	// headerServedModel reports which model served a request routed through the A/B test.
+	headerServedModel = "X-Served-Model"
+	// headerWarning carries RFC 7234 warnings such as the deprecation of the requested model.
+	headerWarning = "Warning"
+	// deprecatedModelWarningFormat is the miscellaneous persistent warning sent for a deprecated model.
+	deprecatedModelWarningFormat = `299 - "model %s is deprecated and will be retired"`
+	// headerRequestID carries the correlation id supplied by the client or assigned by the proxy.
+	headerRequestID = "X-Request-ID"
+	// headerRetryAfter tells a rejected client how many seconds to wait before retrying.
+	headerRetryAfter = "Retry-After"
+	// headerFinishReason exposes why the model stopped generating.
+	headerFinishReason = "X-Finish-Reason"
+	// headerProxyVersion reports the build version of the proxy that served a response.
+	headerProxyVersion = "X-Proxy-Version"
 
 	statusCompleted = "completed"
 	statusSucceeded = "succeeded"
@@ -101,6 +392,8 @@ const (
 	statusCancelled = "cancelled"
 	statusFailed    = "failed"
 	statusErrored   = "errored"
+	// statusIncomplete marks a response that stopped early, for example at the output token limit.
+	statusIncomplete = "incomplete"
 
 	logFieldHTTPStatus   = "http_status"
 	logFieldAPIStatus    = "api_status"
@@ -114,11 +407,36 @@ const (
 	logFieldValue        = "value"
 	// logFieldParameter identifies the request parameter related to a log entry.
 	logFieldParameter = "parameter"
+	// logFieldRequestID identifies the correlation id of the request a log entry belongs to.
+	logFieldRequestID = "request_id"
 	// logFieldID identifies the response identifier logged for traceability.
 	logFieldID = "id"
 
-	// logFieldExpectedFingerprint identifies the fingerprint of the expected client key.
+	// logFieldTimestamp records when an audited request started.
+	logFieldTimestamp = "timestamp"
+	// logFieldClientFingerprint identifies the hashed client address in audit entries.
+	logFieldClientFingerprint = "client_fingerprint"
+	// logFieldModel identifies the resolved model identifier.
+	logFieldModel = "model"
+	// logFieldFailedModel identifies the model whose failure triggered a fallback attempt.
+	logFieldFailedModel = "failed_model"
+	// logFieldMaxOutputTokens identifies the output token budget of an upstream request.
+	logFieldMaxOutputTokens = "max_output_tokens"
+	// logFieldPromptHash identifies the SHA-256 digest of the forwarded prompt.
+	logFieldPromptHash = "prompt_hash"
+	// logFieldResponseHash identifies the SHA-256 digest of the model output.
+	logFieldResponseHash = "response_hash"
+
+	// logFieldExpectedFingerprint identifies the fingerprints of the accepted client keys.
 	logFieldExpectedFingerprint = "expected_fingerprint"
+	// logFieldSecretFingerprint identifies the fingerprint of the accepted secret a client key matched.
+	logFieldSecretFingerprint = "secret_fingerprint"
+	// logFieldPollCount identifies a number of upstream polls.
+	logFieldPollCount = "poll_count"
+	// logFieldQueueDepth identifies the number of requests waiting in the task queue.
+	logFieldQueueDepth = "queue_depth"
+	// logFieldReasoningEffort identifies the reasoning effort sent upstream.
+	logFieldReasoningEffort = "reasoning_effort"
 
 	logEventOpenAIRequestError           = "OpenAI request error"
 	logEventOpenAIResponse               = "OpenAI API response"
@@ -134,9 +452,17 @@ const (
 	// logEventMissingFinalMessage indicates that the response completed without a final assistant message.
 	logEventMissingFinalMessage = "response is 'completed' but lacks final message; starting synthesis continuation"
 	// logEventRetryingSynthesis reports a retry of synthesis due to an empty initial attempt.
-	logEventRetryingSynthesis             = "first synthesis continuation yielded no text; retrying once with stricter settings"
-	logEventParseOpenAIResponseFailed     = "parse OpenAI response failed"
-	logEventForbiddenRequest              = "forbidden request"
+	logEventRetryingSynthesis         = "first synthesis continuation yielded no text; retrying once with stricter settings"
+	logEventParseOpenAIResponseFailed = "parse OpenAI response failed"
+	logEventForbiddenRequest          = "forbidden request"
+	// logEventAuthorizedRequest reports a request whose client key matched an accepted secret.
+	logEventAuthorizedRequest = "authorized request"
+	// logEventShutdownStarted reports that a shutdown signal arrived and the server stopped accepting connections.
+	logEventShutdownStarted = "shutting down; draining in-flight requests"
+	// logEventShutdownCompleted reports that all in-flight requests and queued tasks finished before exit.
+	logEventShutdownCompleted = "shutdown completed"
+	// logEventResponseCacheHit reports a request answered from the response cache.
+	logEventResponseCacheHit              = "response served from cache"
 	logEventRequestReceived               = "request received"
 	logEventResponseSent                  = "response sent"
 	logEventMarshalRequestPayload         = "marshal request payload failed"
@@ -144,6 +470,42 @@ const (
 	logEventBuildHTTPRequest              = "build HTTP request failed"
 	logEventRetryingWithoutParam          = "retrying without parameter"
 	logEventParseWebSearchParameterFailed = "parse web_search parameter failed"
+	// logEventSystemPromptOverrideIgnored reports that a system_prompt override was dropped because overrides are disabled.
+	logEventSystemPromptOverrideIgnored = "system_prompt override ignored"
+	// logEventSystemMessageIgnored reports that a chat completions system message was dropped because overrides are disabled.
+	logEventSystemMessageIgnored = "system message ignored"
+	// logEventDiskQueueReadFailed reports a spilled task that could not be read back from disk.
+	logEventDiskQueueReadFailed = "disk overflow task read failed"
+	// logEventDiskQueueSpillFailed reports a task that could not be written to the disk overflow queue.
+	logEventDiskQueueSpillFailed = "disk overflow spill failed"
+	// logEventDiskQueueTaskAbandoned reports a spilled task dropped because its client stopped waiting.
+	logEventDiskQueueTaskAbandoned = "disk overflow task abandoned by client"
+	// logEventRetryingTruncatedResponse reports a request re-issued with a larger budget after length truncation.
+	logEventRetryingTruncatedResponse = "response truncated at output token limit; retrying with a larger budget"
+	// logEventRetryingMalformedResponse reports a request re-issued after its response body failed to parse.
+	logEventRetryingMalformedResponse = "response body is not valid JSON; retrying"
+	// logEventParseResponseFailed reports an upstream response body that is not valid JSON.
+	logEventParseResponseFailed = "parse upstream response failed"
+	// logEventSessionStuck reports a continued session that stopped advancing and is escalated to synthesis.
+	logEventSessionStuck = "continued session made no progress; escalating to synthesis"
+	// logEventUpstreamHealthProbeFailed reports an upstream health probe that did not get a 200 from the models endpoint.
+	logEventUpstreamHealthProbeFailed = "upstream health probe failed"
+	// logEventMaxOutputTokensClamped reports an output token budget lowered to the ceiling configured for the model.
+	logEventMaxOutputTokensClamped = "max output tokens clamped to model ceiling"
+	// logFieldRequestedMaxOutputTokens identifies the output token budget before it was clamped.
+	logFieldRequestedMaxOutputTokens = "requested_max_output_tokens"
+	// logEventReasoningDegraded reports a reasoning effort lowered because the task queue is deep.
+	logEventReasoningDegraded = "reasoning effort degraded under load"
+	// logEventFallbackModel reports a request retried with a fallback model after an upstream failure.
+	logEventFallbackModel = "upstream request failed; retrying with fallback model"
+	// logEventABTestRouted records the model chosen for a default-model request during an A/B test.
+	logEventABTestRouted = "A/B test routed request"
+	// logEventWarmupCompleted reports a successful startup warm-up request.
+	logEventWarmupCompleted = "upstream warm-up completed"
+	// logEventWarmupFailed reports a failed startup warm-up request.
+	logEventWarmupFailed = "upstream warm-up failed"
+	// logEventAudit labels entries written to the audit log.
+	logEventAudit = "audit"
 
 	responseRequestAttribute = "request"
 )
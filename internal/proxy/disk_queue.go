@@ -0,0 +1,276 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/temirov/llm-proxy/internal/constants"
+	"go.uber.org/zap"
+)
+
+const (
+	// diskQueueFileExtension identifies spilled task files inside the overflow directory.
+	diskQueueFileExtension = ".task.json"
+	// diskQueueFileNameFormat produces lexically ordered file names from a sequence number.
+	diskQueueFileNameFormat = "%020d" + diskQueueFileExtension
+	// diskQueueDirectoryPermissions is applied when creating the overflow directory.
+	diskQueueDirectoryPermissions = 0o700
+	// diskQueueFilePermissions is applied to spilled task files.
+	diskQueueFilePermissions = 0o600
+)
+
+// ErrDiskQueueFull indicates that the on-disk overflow buffer has reached its configured capacity.
+var ErrDiskQueueFull = errors.New(errorDiskQueueFull)
+
+// diskTaskRecord is the serialized form of a requestTask stored in the overflow directory.
+type diskTaskRecord struct {
+	Prompt             string        `json:"prompt"`
+	SystemPrompt       string        `json:"system_prompt"`
+	Model              string        `json:"model"`
+	WebSearchEnabled   bool          `json:"web_search_enabled"`
+	Tools              []string      `json:"tools,omitempty"`
+	SearchLocation     *UserLocation `json:"search_location,omitempty"`
+	SearchContextSize  string        `json:"search_context_size,omitempty"`
+	MaxOutputTokens    int           `json:"max_output_tokens"`
+	Temperature        *float64      `json:"temperature,omitempty"`
+	ReasoningEffort    string        `json:"reasoning_effort,omitempty"`
+	ResponseSchema     string        `json:"response_schema,omitempty"`
+	Seed               *int64        `json:"seed,omitempty"`
+	CandidateCount     int           `json:"candidate_count,omitempty"`
+	PreviousResponseID string        `json:"previous_response_id,omitempty"`
+	PassthroughHeaders http.Header   `json:"passthrough_headers,omitempty"`
+	RequestID          string        `json:"request_id,omitempty"`
+	SendRequestID      bool          `json:"send_request_id,omitempty"`
+	EnqueuedAt         time.Time     `json:"enqueued_at"`
+}
+
+// diskQueueEntry indexes one spilled task: the file holding it, the channel its client waits on and the context that
+// ends when the client goes away. The stream channel and context of a streaming task and the context carrying the
+// request span cannot be serialized either and are kept here as well.
+type diskQueueEntry struct {
+	fileName      string
+	reply         chan result
+	clientContext context.Context
+	streamDeltas  chan string
+	streamContext context.Context
+	traceContext  context.Context
+}
+
+// diskQueueBacklog holds the spilled tasks of one in-memory queue, oldest first, and wakes the loop replaying them.
+type diskQueueBacklog struct {
+	entries    []diskQueueEntry
+	wakeSignal chan struct{}
+}
+
+// diskOverflowQueue spills tasks to disk when their in-memory queue is full and replays them in order once workers
+// free up capacity, keeping the task bodies out of memory. Every model pool queue has its own backlog and replay
+// loop, so a busy pool does not hold up the tasks of another. It is a memory spill, not a durable queue: reply
+// channels cannot be serialized, so a spilled task can only be answered by the process that spilled it. Task files
+// left over from a previous process have no waiting client and are discarded at startup, and tasks whose client has
+// gone are dropped instead of being sent upstream.
+type diskOverflowQueue struct {
+	directory    string
+	maxEntries   int
+	taskQueues   *modelQueues
+	accessMutex  sync.Mutex
+	nextSequence uint64
+	entryCount   int
+	backlogs     map[chan requestTask]*diskQueueBacklog
+}
+
+// newDiskOverflowQueue prepares directory for spilled tasks, removing the task files of earlier runs, whose clients
+// are gone.
+func newDiskOverflowQueue(directory string, maxEntries int, taskQueues *modelQueues) (*diskOverflowQueue, error) {
+	if mkdirError := os.MkdirAll(directory, diskQueueDirectoryPermissions); mkdirError != nil {
+		return nil, mkdirError
+	}
+	staleFiles, globError := filepath.Glob(filepath.Join(directory, "*"+diskQueueFileExtension))
+	if globError != nil {
+		return nil, globError
+	}
+	for _, staleFile := range staleFiles {
+		if removeError := os.Remove(staleFile); removeError != nil {
+			return nil, removeError
+		}
+	}
+	backlogs := make(map[chan requestTask]*diskQueueBacklog)
+	for _, taskQueue := range taskQueues.distinctQueues() {
+		backlogs[taskQueue] = &diskQueueBacklog{wakeSignal: make(chan struct{}, 1)}
+	}
+	return &diskOverflowQueue{
+		directory:  directory,
+		maxEntries: maxEntries,
+		taskQueues: taskQueues,
+		backlogs:   backlogs,
+	}, nil
+}
+
+// Enqueue writes task to disk behind the tasks already spilled. clientContext ends when the waiting client goes away.
+// It returns ErrDiskQueueFull when the buffer already holds maxEntries tasks.
+func (queue *diskOverflowQueue) Enqueue(task requestTask, clientContext context.Context) error {
+	recordBytes, marshalError := json.Marshal(diskTaskRecord{
+		Prompt:             task.prompt,
+		SystemPrompt:       task.systemPrompt,
+		Model:              task.model,
+		WebSearchEnabled:   task.webSearchEnabled,
+		Tools:              task.tools,
+		SearchLocation:     task.searchLocation,
+		SearchContextSize:  task.searchContextSize,
+		MaxOutputTokens:    task.maxOutputTokens,
+		Temperature:        task.temperature,
+		ReasoningEffort:    task.reasoningEffort,
+		ResponseSchema:     task.responseSchema,
+		Seed:               task.seed,
+		CandidateCount:     task.candidateCount,
+		PreviousResponseID: task.previousResponseID,
+		PassthroughHeaders: task.passthroughHeaders,
+		RequestID:          task.requestID,
+		SendRequestID:      task.sendRequestID,
+		EnqueuedAt:         task.enqueuedAt,
+	})
+	if marshalError != nil {
+		return marshalError
+	}
+
+	queue.accessMutex.Lock()
+	if queue.entryCount >= queue.maxEntries {
+		queue.accessMutex.Unlock()
+		return ErrDiskQueueFull
+	}
+	fileName := fmt.Sprintf(diskQueueFileNameFormat, queue.nextSequence)
+	queue.nextSequence++
+	if writeError := os.WriteFile(filepath.Join(queue.directory, fileName), recordBytes, diskQueueFilePermissions); writeError != nil {
+		queue.accessMutex.Unlock()
+		return writeError
+	}
+	backlog := queue.backlogs[queue.taskQueues.queueFor(task.model)]
+	backlog.entries = append(backlog.entries, diskQueueEntry{
+		fileName:      fileName,
+		reply:         task.reply,
+		clientContext: clientContext,
+		streamDeltas:  task.streamDeltas,
+		streamContext: task.streamContext,
+		traceContext:  task.traceContext,
+	})
+	queue.entryCount++
+	queue.accessMutex.Unlock()
+
+	select {
+	case backlog.wakeSignal <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Len reports the number of tasks currently buffered on disk.
+func (queue *diskOverflowQueue) Len() int {
+	queue.accessMutex.Lock()
+	defer queue.accessMutex.Unlock()
+	return queue.entryCount
+}
+
+// backlogged reports whether spilled tasks are waiting for taskQueue.
+func (queue *diskOverflowQueue) backlogged(taskQueue chan requestTask) bool {
+	queue.accessMutex.Lock()
+	defer queue.accessMutex.Unlock()
+	return len(queue.backlogs[taskQueue].entries) > 0
+}
+
+// startReplay starts one replay loop per in-memory queue. The loops end when stopSignal is closed.
+func (queue *diskOverflowQueue) startReplay(stopSignal <-chan struct{}, structuredLogger *zap.SugaredLogger) {
+	for taskQueue, backlog := range queue.backlogs {
+		go queue.replay(taskQueue, backlog, stopSignal, structuredLogger)
+	}
+}
+
+// replay moves the spilled tasks of backlog into taskQueue, oldest first, blocking until workers accept each task, its
+// client goes away or stopSignal is closed.
+func (queue *diskOverflowQueue) replay(taskQueue chan requestTask, backlog *diskQueueBacklog, stopSignal <-chan struct{}, structuredLogger *zap.SugaredLogger) {
+	for {
+		select {
+		case <-backlog.wakeSignal:
+		case <-stopSignal:
+			return
+		}
+		for {
+			task, clientContext, found := queue.dequeue(backlog, structuredLogger)
+			if !found {
+				break
+			}
+			if clientContext.Err() == nil {
+				select {
+				case taskQueue <- task:
+					continue
+				case <-clientContext.Done():
+				case <-stopSignal:
+					return
+				}
+			}
+			structuredLogger.Debugw(logEventDiskQueueTaskAbandoned, logFieldRequestID, task.requestID)
+		}
+	}
+}
+
+// dequeue removes the oldest spilled task of backlog whose client is still waiting from disk and returns it with its reply
+// and stream channels and its trace context restored, together with the context of its client. Tasks of clients that
+// have gone are deleted without being returned.
+func (queue *diskOverflowQueue) dequeue(backlog *diskQueueBacklog, structuredLogger *zap.SugaredLogger) (requestTask, context.Context, bool) {
+	queue.accessMutex.Lock()
+	defer queue.accessMutex.Unlock()
+	for len(backlog.entries) > 0 {
+		oldestEntry := backlog.entries[0]
+		backlog.entries[0] = diskQueueEntry{}
+		backlog.entries = backlog.entries[1:]
+		queue.entryCount--
+
+		taskPath := filepath.Join(queue.directory, oldestEntry.fileName)
+		if oldestEntry.clientContext.Err() != nil {
+			_ = os.Remove(taskPath)
+			structuredLogger.Debugw(logEventDiskQueueTaskAbandoned, logFieldID, oldestEntry.fileName)
+			continue
+		}
+		recordBytes, readError := os.ReadFile(taskPath)
+		_ = os.Remove(taskPath)
+		var record diskTaskRecord
+		if readError == nil {
+			readError = json.Unmarshal(recordBytes, &record)
+		}
+		if readError != nil {
+			structuredLogger.Errorw(logEventDiskQueueReadFailed, logFieldID, oldestEntry.fileName, constants.LogFieldError, readError)
+			oldestEntry.reply <- result{text: constants.EmptyString, requestError: readError}
+			continue
+		}
+		return requestTask{
+			prompt:             record.Prompt,
+			systemPrompt:       record.SystemPrompt,
+			model:              record.Model,
+			webSearchEnabled:   record.WebSearchEnabled,
+			tools:              record.Tools,
+			searchLocation:     record.SearchLocation,
+			searchContextSize:  record.SearchContextSize,
+			maxOutputTokens:    record.MaxOutputTokens,
+			temperature:        record.Temperature,
+			reasoningEffort:    record.ReasoningEffort,
+			responseSchema:     record.ResponseSchema,
+			seed:               record.Seed,
+			candidateCount:     record.CandidateCount,
+			previousResponseID: record.PreviousResponseID,
+			passthroughHeaders: record.PassthroughHeaders,
+			requestID:          record.RequestID,
+			sendRequestID:      record.SendRequestID,
+			enqueuedAt:         record.EnqueuedAt,
+			streamDeltas:       oldestEntry.streamDeltas,
+			streamContext:      oldestEntry.streamContext,
+			traceContext:       oldestEntry.traceContext,
+			reply:              oldestEntry.reply,
+		}, oldestEntry.clientContext, true
+	}
+	return requestTask{}, nil, false
+}
@@ -1,17 +1,51 @@
 package proxy
 
-import "sync"
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/temirov/llm-proxy/internal/constants"
+)
 
 const (
-	defaultResponsesURL = "https://api.openai.com/v1/responses"
-	defaultModelsURL    = "https://api.openai.com/v1/models"
+	// defaultOpenAIBaseURL is the root of the public OpenAI API.
+	defaultOpenAIBaseURL = "https://api.openai.com"
+	// responsesPathSuffix is appended to a base URL to address the responses endpoint.
+	responsesPathSuffix = "/v1/responses"
+	// modelsPathSuffix is appended to a base URL to address the models endpoint.
+	modelsPathSuffix = "/v1/models"
+	// azureResponsesPathSuffix is appended to an Azure resource URL to address stored responses.
+	azureResponsesPathSuffix = "/openai/responses"
+	// azureModelsPathSuffix is appended to an Azure resource URL to address the models endpoint.
+	azureModelsPathSuffix = "/openai/models"
+	// azureDeploymentsPathSuffix is appended to an Azure resource URL to address its deployments.
+	azureDeploymentsPathSuffix = "/openai/deployments"
+	// azureDeploymentResponsesPathSuffix is appended to a deployment URL to address its responses endpoint.
+	azureDeploymentResponsesPathSuffix = "/responses"
+
+	defaultResponsesURL = defaultOpenAIBaseURL + responsesPathSuffix
+	defaultModelsURL    = defaultOpenAIBaseURL + modelsPathSuffix
+
+	// schemeHTTP and schemeHTTPS are the URL schemes accepted for a base URL.
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+	// urlPathSeparator separates URL path segments.
+	urlPathSeparator = "/"
 )
 
+// ErrInvalidOpenAIBaseURL indicates a base URL that is not an absolute http or https URL.
+var ErrInvalidOpenAIBaseURL = errors.New(errorOpenAIBaseURL)
+
 // Endpoints provides concurrency-safe access to OpenAI endpoint URLs.
 type Endpoints struct {
 	accessMutex  sync.RWMutex
 	responsesURL string
 	modelsURL    string
+	// deploymentsURL addresses the deployments of an Azure OpenAI resource; blank for the OpenAI URL scheme.
+	deploymentsURL string
 }
 
 // NewEndpoints creates an Endpoints instance initialized with default URLs.
@@ -22,6 +56,46 @@ func NewEndpoints() *Endpoints {
 	}
 }
 
+// NewEndpointsFromBaseURL creates an Endpoints instance addressing <baseURL>/v1/responses and <baseURL>/v1/models,
+// for OpenAI-compatible gateways. The base URL must be an absolute http or https URL; a trailing slash is ignored.
+func NewEndpointsFromBaseURL(baseURL string) (*Endpoints, error) {
+	normalizedBaseURL, baseURLError := normalizeBaseURL(baseURL)
+	if baseURLError != nil {
+		return nil, baseURLError
+	}
+	return &Endpoints{
+		responsesURL: normalizedBaseURL + responsesPathSuffix,
+		modelsURL:    normalizedBaseURL + modelsPathSuffix,
+	}, nil
+}
+
+// NewAzureEndpoints creates an Endpoints instance for the Azure OpenAI resource at baseURL, such as
+// https://example.openai.azure.com. New responses are created at <baseURL>/openai/deployments/<model>/responses,
+// while stored responses are addressed under <baseURL>/openai/responses.
+func NewAzureEndpoints(baseURL string) (*Endpoints, error) {
+	normalizedBaseURL, baseURLError := normalizeBaseURL(baseURL)
+	if baseURLError != nil {
+		return nil, baseURLError
+	}
+	return &Endpoints{
+		responsesURL:   normalizedBaseURL + azureResponsesPathSuffix,
+		modelsURL:      normalizedBaseURL + azureModelsPathSuffix,
+		deploymentsURL: normalizedBaseURL + azureDeploymentsPathSuffix,
+	}, nil
+}
+
+// normalizeBaseURL validates that baseURL is an absolute http or https URL and returns it without a trailing slash.
+func normalizeBaseURL(baseURL string) (string, error) {
+	parsedURL, parseError := url.Parse(strings.TrimSpace(baseURL))
+	if parseError != nil {
+		return constants.EmptyString, fmt.Errorf(errorWrapWithCauseFormat, ErrInvalidOpenAIBaseURL, parseError)
+	}
+	if (parsedURL.Scheme != schemeHTTP && parsedURL.Scheme != schemeHTTPS) || parsedURL.Host == constants.EmptyString {
+		return constants.EmptyString, fmt.Errorf(errorWrapWithDetailFormat, ErrInvalidOpenAIBaseURL, baseURL)
+	}
+	return strings.TrimRight(parsedURL.String(), urlPathSeparator), nil
+}
+
 // GetResponsesURL returns the URL used for the OpenAI responses endpoint.
 func (endpointConfiguration *Endpoints) GetResponsesURL() string {
 	endpointConfiguration.accessMutex.RLock()
@@ -29,6 +103,17 @@ func (endpointConfiguration *Endpoints) GetResponsesURL() string {
 	return endpointConfiguration.responsesURL
 }
 
+// ResponsesURLForModel returns the URL at which new responses for modelIdentifier are created: the deployment named
+// after the model for Azure endpoints and the responses endpoint otherwise.
+func (endpointConfiguration *Endpoints) ResponsesURLForModel(modelIdentifier string) string {
+	endpointConfiguration.accessMutex.RLock()
+	defer endpointConfiguration.accessMutex.RUnlock()
+	if endpointConfiguration.deploymentsURL == constants.EmptyString {
+		return endpointConfiguration.responsesURL
+	}
+	return endpointConfiguration.deploymentsURL + urlPathSeparator + url.PathEscape(modelIdentifier) + azureDeploymentResponsesPathSuffix
+}
+
 // SetResponsesURL sets the URL for the OpenAI responses endpoint.
 func (endpointConfiguration *Endpoints) SetResponsesURL(newURL string) {
 	endpointConfiguration.accessMutex.Lock()
@@ -0,0 +1,63 @@
+package proxy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+// TestNewAzureEndpoints verifies that new responses address the deployment named after the model, that stored
+// responses and models address the resource, and that invalid URLs are rejected.
+func TestNewAzureEndpoints(testingInstance *testing.T) {
+	testCases := []struct {
+		name                 string
+		baseURL              string
+		modelIdentifier      string
+		expectedCreateURL    string
+		expectedResponsesURL string
+		expectedModelsURL    string
+		expectedError        error
+	}{
+		{
+			name:                 "resource",
+			baseURL:              "https://example.openai.azure.com/",
+			modelIdentifier:      "gpt-4.1",
+			expectedCreateURL:    "https://example.openai.azure.com/openai/deployments/gpt-4.1/responses",
+			expectedResponsesURL: "https://example.openai.azure.com/openai/responses",
+			expectedModelsURL:    "https://example.openai.azure.com/openai/models",
+		},
+		{
+			name:                 "escaped deployment",
+			baseURL:              "https://example.openai.azure.com",
+			modelIdentifier:      "team/model",
+			expectedCreateURL:    "https://example.openai.azure.com/openai/deployments/team%2Fmodel/responses",
+			expectedResponsesURL: "https://example.openai.azure.com/openai/responses",
+			expectedModelsURL:    "https://example.openai.azure.com/openai/models",
+		},
+		{name: "missing scheme", baseURL: "example.openai.azure.com", expectedError: proxy.ErrInvalidOpenAIBaseURL},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints, endpointsError := proxy.NewAzureEndpoints(testCase.baseURL)
+			if testCase.expectedError != nil {
+				if !errors.Is(endpointsError, testCase.expectedError) {
+					subTest.Fatalf("error=%v want=%v", endpointsError, testCase.expectedError)
+				}
+				return
+			}
+			if endpointsError != nil {
+				subTest.Fatalf("unexpected error: %v", endpointsError)
+			}
+			if createURL := endpoints.ResponsesURLForModel(testCase.modelIdentifier); createURL != testCase.expectedCreateURL {
+				subTest.Fatalf("createURL=%s want=%s", createURL, testCase.expectedCreateURL)
+			}
+			if endpoints.GetResponsesURL() != testCase.expectedResponsesURL {
+				subTest.Fatalf("responsesURL=%s want=%s", endpoints.GetResponsesURL(), testCase.expectedResponsesURL)
+			}
+			if endpoints.GetModelsURL() != testCase.expectedModelsURL {
+				subTest.Fatalf("modelsURL=%s want=%s", endpoints.GetModelsURL(), testCase.expectedModelsURL)
+			}
+		})
+	}
+}
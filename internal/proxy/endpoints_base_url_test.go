@@ -0,0 +1,44 @@
+package proxy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+// TestNewEndpointsFromBaseURL verifies that both endpoint URLs derive from the base URL and that invalid URLs are rejected.
+func TestNewEndpointsFromBaseURL(testingInstance *testing.T) {
+	testCases := []struct {
+		name                 string
+		baseURL              string
+		expectedResponsesURL string
+		expectedModelsURL    string
+		expectedError        error
+	}{
+		{name: "gateway", baseURL: "https://gateway.local", expectedResponsesURL: "https://gateway.local/v1/responses", expectedModelsURL: "https://gateway.local/v1/models"},
+		{name: "trailing slash and path", baseURL: "http://localhost:8000/openai/", expectedResponsesURL: "http://localhost:8000/openai/v1/responses", expectedModelsURL: "http://localhost:8000/openai/v1/models"},
+		{name: "missing scheme", baseURL: "gateway.local", expectedError: proxy.ErrInvalidOpenAIBaseURL},
+		{name: "unsupported scheme", baseURL: "ftp://gateway.local", expectedError: proxy.ErrInvalidOpenAIBaseURL},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints, endpointsError := proxy.NewEndpointsFromBaseURL(testCase.baseURL)
+			if testCase.expectedError != nil {
+				if !errors.Is(endpointsError, testCase.expectedError) {
+					subTest.Fatalf("error=%v want=%v", endpointsError, testCase.expectedError)
+				}
+				return
+			}
+			if endpointsError != nil {
+				subTest.Fatalf("unexpected error: %v", endpointsError)
+			}
+			if endpoints.GetResponsesURL() != testCase.expectedResponsesURL {
+				subTest.Fatalf("responsesURL=%s want=%s", endpoints.GetResponsesURL(), testCase.expectedResponsesURL)
+			}
+			if endpoints.GetModelsURL() != testCase.expectedModelsURL {
+				subTest.Fatalf("modelsURL=%s want=%s", endpoints.GetModelsURL(), testCase.expectedModelsURL)
+			}
+		})
+	}
+}
@@ -1,16 +1,86 @@
 package proxy
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"encoding/xml"
-	"fmt"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/utils"
 	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
 )
 
+// ErrFormattedResponseTooLarge indicates that a response exceeds Configuration.MaxFormattedBytes.
+var ErrFormattedResponseTooLarge = errors.New(errorResponseTooLarge)
+
+// ErrFormatDisabled indicates a format parameter naming a response format outside Configuration.EnabledFormats.
+var ErrFormatDisabled = errors.New(errorFormatDisabled)
+
+// ErrInvalidCSVMode indicates a csv_mode value other than single or rows.
+var ErrInvalidCSVMode = errors.New(errorInvalidCSVMode)
+
+// csvLayout controls how CSV responses are laid out.
+type csvLayout struct {
+	// rowPerLine emits one row per non-blank line of the response instead of a single cell.
+	rowPerLine bool
+	// includePrompt adds the original prompt as the first column of every row in row-per-line mode.
+	includePrompt bool
+	// includeHeader starts the document with a request,response header row and adds the prompt column in every mode.
+	includeHeader bool
+}
+
+// requestCSVLayout reads the csv_mode, csv_prompt and header query parameters. csv_mode defaults to single.
+func requestCSVLayout(ginContext *gin.Context) (csvLayout, error) {
+	var layout csvLayout
+	switch strings.ToLower(strings.TrimSpace(ginContext.Query(queryParameterCSVMode))) {
+	case constants.EmptyString, csvModeSingle:
+	case csvModeRows:
+		layout.rowPerLine = true
+	default:
+		return csvLayout{}, ErrInvalidCSVMode
+	}
+	layout.includePrompt, _ = strconv.ParseBool(strings.TrimSpace(ginContext.Query(queryParameterCSVPrompt)))
+	layout.includeHeader, _ = strconv.ParseBool(strings.TrimSpace(ginContext.Query(queryParameterCSVHeader)))
+	return layout, nil
+}
+
+// encodeCSV writes modelText to writer as RFC 4180 CSV according to layout, ending every row with a newline. Fields
+// are quoted when they contain separators, quotes or line breaks, and line breaks inside a field, including CRLF, are
+// preserved. It returns the first error of writer.
+func encodeCSV(writer io.Writer, modelText string, originalPrompt string, layout csvLayout) error {
+	csvWriter := csv.NewWriter(writer)
+	if layout.includeHeader {
+		_ = csvWriter.Write([]string{responseRequestAttribute, jsonFieldResponse})
+	}
+	includePrompt := layout.includeHeader || (layout.rowPerLine && layout.includePrompt)
+	writeRow := func(responseText string) {
+		if includePrompt {
+			_ = csvWriter.Write([]string{originalPrompt, responseText})
+			return
+		}
+		_ = csvWriter.Write([]string{responseText})
+	}
+	if !layout.rowPerLine {
+		writeRow(modelText)
+	} else {
+		for _, responseLine := range strings.Split(modelText, constants.LineBreak) {
+			responseLine = strings.TrimSuffix(responseLine, carriageReturn)
+			if !utils.IsBlank(responseLine) {
+				writeRow(responseLine)
+			}
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
 // preferredMime determines the response MIME type using the format query parameter or the Accept header.
 func preferredMime(ginContext *gin.Context) string {
 	if explicitFormat := ginContext.Query(queryParameterFormat); explicitFormat != constants.EmptyString {
@@ -19,33 +89,125 @@ func preferredMime(ginContext *gin.Context) string {
 	return strings.ToLower(strings.TrimSpace(ginContext.GetHeader(headerAccept)))
 }
 
-// formatResponse renders a textual model output into the requested MIME type and returns the body and content type.
-// Encoding failures are logged and result in a plain text error message.
-func formatResponse(modelText string, preferred string, originalPrompt string, structuredLogger *zap.SugaredLogger) (string, string) {
+// responseFormatOf names the response format encodeResponse renders for the preferred MIME type.
+func responseFormatOf(preferred string) string {
 	switch {
 	case strings.Contains(preferred, mimeApplicationJSON):
-		encodedJSON, marshalError := json.Marshal(map[string]string{responseRequestAttribute: originalPrompt, jsonFieldResponse: modelText})
+		return ResponseFormatJSON
+	case strings.Contains(preferred, mimeApplicationXML) || strings.Contains(preferred, mimeTextXML):
+		return ResponseFormatXML
+	case strings.Contains(preferred, mimeApplicationYAML):
+		return ResponseFormatYAML
+	case strings.Contains(preferred, mimeTextCSV):
+		return ResponseFormatCSV
+	default:
+		return ResponseFormatText
+	}
+}
+
+// negotiateResponseMime resolves the preferred MIME type against enabledFormats. An empty list enables every format.
+// A disabled format negotiated through the Accept header degrades to plain text, while one named by the format
+// parameter yields ErrFormatDisabled.
+func negotiateResponseMime(ginContext *gin.Context, enabledFormats []string) (string, error) {
+	preferred := preferredMime(ginContext)
+	requestedFormat := responseFormatOf(preferred)
+	if len(enabledFormats) == 0 || requestedFormat == ResponseFormatText || slices.Contains(enabledFormats, requestedFormat) {
+		return preferred, nil
+	}
+	if ginContext.Query(queryParameterFormat) != constants.EmptyString {
+		return constants.EmptyString, ErrFormatDisabled
+	}
+	return mimeTextPlain, nil
+}
+
+// formattedBodyWriter collects a formatted body and refuses writes that would take it beyond limit bytes, so that
+// an oversized body is abandoned while it is being encoded. A limit of zero or less disables the cap.
+type formattedBodyWriter struct {
+	body     strings.Builder
+	limit    int
+	exceeded bool
+}
+
+// Write appends chunk to the body, or reports ErrFormattedResponseTooLarge when the body would exceed the limit.
+func (bodyWriter *formattedBodyWriter) Write(chunk []byte) (int, error) {
+	if bodyWriter.limit > 0 && bodyWriter.body.Len()+len(chunk) > bodyWriter.limit {
+		bodyWriter.exceeded = true
+		return 0, ErrFormattedResponseTooLarge
+	}
+	return bodyWriter.body.Write(chunk)
+}
+
+// minimumFormattedBytes returns a lower bound of the size of the body encodeResponse renders: the model text plus
+// the prompt for the formats that embed it.
+func minimumFormattedBytes(modelText string, preferred string, originalPrompt string, layout csvLayout) int {
+	switch responseFormatOf(preferred) {
+	case ResponseFormatText:
+		return len(modelText)
+	case ResponseFormatCSV:
+		if !layout.includeHeader && !(layout.rowPerLine && layout.includePrompt) {
+			return len(modelText)
+		}
+	}
+	return len(modelText) + len(originalPrompt)
+}
+
+// formatResponse renders a textual model output into the requested MIME type and returns the body and content type.
+// When several candidates are given, JSON responses list them in an array and other formats render modelText, which
+// joins them. A non-nil echo is embedded in JSON responses only, and layout applies to CSV responses only. Encoding failures are
+// logged and result in a plain text error message.
+// When maxFormattedBytes is positive, bodies larger than it yield ErrFormattedResponseTooLarge. Bodies that cannot fit
+// are refused before encoding, and the others are encoded through a writer that stops once the cap is exceeded.
+func formatResponse(modelText string, candidates []string, preferred string, originalPrompt string, echo *requestEcho, layout csvLayout, maxFormattedBytes int, structuredLogger *zap.SugaredLogger) (string, string, error) {
+	if maxFormattedBytes > 0 && minimumFormattedBytes(modelText, preferred, originalPrompt, layout) > maxFormattedBytes {
+		return constants.EmptyString, constants.EmptyString, ErrFormattedResponseTooLarge
+	}
+	bodyWriter := &formattedBodyWriter{limit: maxFormattedBytes}
+	contentType, encodeError := encodeResponse(bodyWriter, modelText, candidates, preferred, originalPrompt, echo, layout)
+	if bodyWriter.exceeded {
+		return constants.EmptyString, constants.EmptyString, ErrFormattedResponseTooLarge
+	}
+	if encodeError != nil {
+		structuredLogger.Errorw(logEventMarshalResponsePayload, constants.LogFieldError, encodeError)
+		return errorResponseFormat, mimeTextPlain, nil
+	}
+	return bodyWriter.body.String(), contentType, nil
+}
+
+// encodeResponse writes modelText, or the candidates when JSON is selected and there are several, to writer in the
+// MIME type selected by preferred and returns that MIME type.
+func encodeResponse(writer io.Writer, modelText string, candidates []string, preferred string, originalPrompt string, echo *requestEcho, layout csvLayout) (string, error) {
+	switch responseFormatOf(preferred) {
+	case ResponseFormatJSON:
+		jsonEnvelope := map[string]any{responseRequestAttribute: originalPrompt, jsonFieldResponse: modelText}
+		if len(candidates) > 1 {
+			jsonEnvelope[jsonFieldResponse] = candidates
+		}
+		if echo != nil {
+			jsonEnvelope[jsonFieldEcho] = echo
+		}
+		encodedJSON, marshalError := json.Marshal(jsonEnvelope)
 		if marshalError != nil {
-			structuredLogger.Errorw(logEventMarshalResponsePayload, constants.LogFieldError, marshalError)
-			return errorResponseFormat, mimeTextPlain
+			return constants.EmptyString, marshalError
 		}
-		return string(encodedJSON), mimeApplicationJSON
-	case strings.Contains(preferred, mimeApplicationXML) || strings.Contains(preferred, mimeTextXML):
+		_, writeError := writer.Write(encodedJSON)
+		return mimeApplicationJSON, writeError
+	case ResponseFormatXML:
 		type xmlEnvelope struct {
 			XMLName xml.Name `xml:"response"`
 			Request string   `xml:"request,attr"`
 			Text    string   `xml:",chardata"`
 		}
-		encodedXML, marshalError := xml.Marshal(xmlEnvelope{Request: originalPrompt, Text: modelText})
-		if marshalError != nil {
-			structuredLogger.Errorw(logEventMarshalResponsePayload, constants.LogFieldError, marshalError)
-			return errorResponseFormat, mimeTextPlain
+		return mimeApplicationXML, xml.NewEncoder(writer).Encode(xmlEnvelope{Request: originalPrompt, Text: modelText})
+	case ResponseFormatYAML:
+		yamlEncoder := yaml.NewEncoder(writer)
+		if encodeError := yamlEncoder.Encode(map[string]string{responseRequestAttribute: originalPrompt, jsonFieldResponse: modelText}); encodeError != nil {
+			return constants.EmptyString, encodeError
 		}
-		return string(encodedXML), mimeApplicationXML
-	case strings.Contains(preferred, mimeTextCSV):
-		escaped := strings.ReplaceAll(modelText, `"`, `""`)
-		return fmt.Sprintf(`"%s"`+"\n", escaped), mimeTextCSV
+		return mimeApplicationYAML, yamlEncoder.Close()
+	case ResponseFormatCSV:
+		return mimeTextCSV, encodeCSV(writer, modelText, originalPrompt, layout)
 	default:
-		return modelText, mimeTextPlain
+		_, writeError := io.WriteString(writer, modelText)
+		return mimeTextPlain, writeError
 	}
 }
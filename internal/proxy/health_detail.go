@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// healthDetailPath reports the upstream, model table, queue and worker signals as one JSON document.
+const healthDetailPath = "/health/detail"
+
+// healthDetailReport is the JSON document served by the health detail endpoint. Status is ok when the upstream
+// models endpoint last answered 200 and unavailable otherwise.
+type healthDetailReport struct {
+	Status        string               `json:"status"`
+	Upstream      upstreamHealthReport `json:"upstream"`
+	Models        modelTableDetail     `json:"models"`
+	QueueDepth    int                  `json:"queue_depth"`
+	QueueCapacity int                  `json:"queue_capacity"`
+	WorkerCount   int                  `json:"worker_count"`
+	InFlight      int64                `json:"in_flight"`
+	LoadShedding  loadSheddingState    `json:"load_shedding"`
+}
+
+// modelTableDetail describes the table of recognized models: how many it holds and how long ago it was loaded.
+type modelTableDetail struct {
+	Count      int   `json:"count"`
+	AgeSeconds int64 `json:"age_seconds"`
+}
+
+// healthDetailHandler answers with a healthDetailReport, using 200 when the upstream is reachable and 503
+// otherwise, like the upstream health endpoint. modelsLoadedAt is when validator's model table was loaded, and
+// loadShedding reports the overload protections engaged.
+func healthDetailHandler(probe *upstreamHealthProbe, validator *modelValidator, modelsLoadedAt time.Time, taskQueues *modelQueues, workers *workerPool, loadShedding *loadSheddingMonitor, structuredLogger *zap.SugaredLogger) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		now := time.Now()
+		upstreamStatus := probe.upstreamStatus(now, structuredLogger)
+		queueDepth, queueCapacity := taskQueues.depth()
+		report := healthDetailReport{
+			Status:   upstreamHealthStatusOK,
+			Upstream: upstreamHealthReport{Status: upstreamHealthStatusOK, UpstreamStatus: upstreamStatus},
+			Models: modelTableDetail{
+				Count:      len(validator.models()),
+				AgeSeconds: int64(now.Sub(modelsLoadedAt).Seconds()),
+			},
+			QueueDepth:    queueDepth,
+			QueueCapacity: queueCapacity,
+			WorkerCount:   workers.workerCount,
+			InFlight:      workers.inFlight.Load(),
+			LoadShedding:  loadShedding.state(now),
+		}
+		if upstreamStatus != http.StatusOK {
+			report.Status = upstreamHealthStatusUnavailable
+			report.Upstream.Status = upstreamHealthStatusUnavailable
+			ginContext.JSON(http.StatusServiceUnavailable, report)
+			return
+		}
+		ginContext.JSON(http.StatusOK, report)
+	}
+}
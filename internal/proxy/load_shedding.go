@@ -0,0 +1,59 @@
+package proxy
+
+import "time"
+
+// loadSheddingState reports which overload protections are turning requests away or cheapening them at the moment.
+// Engaged is set when any of them is.
+type loadSheddingState struct {
+	Engaged            bool `json:"engaged"`
+	CircuitOpen        bool `json:"circuit_open"`
+	ConcurrencyLimited bool `json:"concurrency_limited"`
+	DegradingReasoning bool `json:"degrading_reasoning"`
+	QueueFull          bool `json:"queue_full"`
+}
+
+// loadSheddingMonitor reads the state of the overload protections configured for a router.
+type loadSheddingMonitor struct {
+	breaker       *circuitBreaker
+	requestSlots  chan struct{}
+	taskQueues    *modelQueues
+	overflowQueue *diskOverflowQueue
+	// degradeQueueDepth is the queue length above which reasoning effort is degraded; negative when degradation is
+	// disabled.
+	degradeQueueDepth int
+}
+
+// newLoadSheddingMonitor creates a monitor of the protections configuration enables on the given breaker,
+// concurrency slots and queues.
+func newLoadSheddingMonitor(configuration Configuration, breaker *circuitBreaker, requestSlots chan struct{}, taskQueues *modelQueues, overflowQueue *diskOverflowQueue) *loadSheddingMonitor {
+	degradeQueueDepth := -1
+	if configuration.DegradeReasoningUnderLoad {
+		degradeQueueDepth = configuration.DegradeReasoningQueueDepth
+	}
+	return &loadSheddingMonitor{
+		breaker:           breaker,
+		requestSlots:      requestSlots,
+		taskQueues:        taskQueues,
+		overflowQueue:     overflowQueue,
+		degradeQueueDepth: degradeQueueDepth,
+	}
+}
+
+// state reports the protections engaged at now. A queue counts as full when it has no free slot and no disk overflow
+// buffer can take its excess.
+func (monitor *loadSheddingMonitor) state(now time.Time) loadSheddingState {
+	var current loadSheddingState
+	current.CircuitOpen, _ = monitor.breaker.rejecting(now)
+	current.ConcurrencyLimited = monitor.requestSlots != nil && len(monitor.requestSlots) == cap(monitor.requestSlots)
+	overflowFull := monitor.overflowQueue == nil || monitor.overflowQueue.Len() >= monitor.overflowQueue.maxEntries
+	for _, taskQueue := range monitor.taskQueues.distinctQueues() {
+		if monitor.degradeQueueDepth >= 0 && len(taskQueue) > monitor.degradeQueueDepth {
+			current.DegradingReasoning = true
+		}
+		if len(taskQueue) == cap(taskQueue) && overflowFull {
+			current.QueueFull = true
+		}
+	}
+	current.Engaged = current.CircuitOpen || current.ConcurrencyLimited || current.DegradingReasoning || current.QueueFull
+	return current
+}
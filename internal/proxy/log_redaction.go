@@ -0,0 +1,98 @@
+package proxy
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+const (
+	// redactionPathSeparator separates segments of a redaction path such as output[].content[].text.
+	redactionPathSeparator = "."
+	// redactionArraySuffix marks a path segment whose value is an array to traverse element by element.
+	redactionArraySuffix = "[]"
+)
+
+// promptTextRedactionPaths addresses the prompt and response text of upstream bodies masked when
+// Configuration.RedactPrompts is set.
+var promptTextRedactionPaths = []string{
+	"input",
+	"instructions",
+	jsonFieldOutputText,
+	"output[].content[].text",
+	"output[].summary[].text",
+}
+
+// logRedactionPaths returns the configured redaction paths, extended with promptTextRedactionPaths when
+// redactPrompts is set.
+func logRedactionPaths(configuredPaths []string, redactPrompts bool) []string {
+	if !redactPrompts {
+		return configuredPaths
+	}
+	return slices.Concat(configuredPaths, promptTextRedactionPaths)
+}
+
+// redactJSONFields replaces the values addressed by fieldPaths in rawPayload with redactedPlaceholder.
+// Paths use dot-separated object keys, and a segment ending in [] descends into every array element.
+// Payloads that are not valid JSON are returned unchanged so that logging never fails.
+func redactJSONFields(rawPayload []byte, fieldPaths []string) []byte {
+	if len(fieldPaths) == 0 {
+		return rawPayload
+	}
+	var decodedPayload any
+	if json.Unmarshal(rawPayload, &decodedPayload) != nil {
+		return rawPayload
+	}
+	for _, fieldPath := range fieldPaths {
+		redactPathSegments(decodedPayload, strings.Split(strings.TrimSpace(fieldPath), redactionPathSeparator))
+	}
+	redactedPayload, marshalError := json.Marshal(decodedPayload)
+	if marshalError != nil {
+		return rawPayload
+	}
+	return redactedPayload
+}
+
+// redactPathSegments walks node following pathSegments and replaces the addressed leaves in place.
+func redactPathSegments(node any, pathSegments []string) {
+	objectNode, isObject := node.(map[string]any)
+	if !isObject || len(pathSegments) == 0 {
+		return
+	}
+	currentSegment := pathSegments[0]
+	remainingSegments := pathSegments[1:]
+	fieldName, traverseArray := strings.CutSuffix(currentSegment, redactionArraySuffix)
+	childNode, present := objectNode[fieldName]
+	if !present {
+		return
+	}
+	if len(remainingSegments) == 0 && !traverseArray {
+		objectNode[fieldName] = redactedPlaceholder
+		return
+	}
+	if !traverseArray {
+		redactPathSegments(childNode, remainingSegments)
+		return
+	}
+	arrayNode, isArray := childNode.([]any)
+	if !isArray {
+		return
+	}
+	for elementIndex := range arrayNode {
+		if len(remainingSegments) == 0 {
+			arrayNode[elementIndex] = redactedPlaceholder
+			continue
+		}
+		redactPathSegments(arrayNode[elementIndex], remainingSegments)
+	}
+}
+
+// redactLoggedText returns redactedPlaceholder when the extracted output_text field is configured for redaction.
+func redactLoggedText(outputText string, fieldPaths []string) string {
+	for _, fieldPath := range fieldPaths {
+		if strings.TrimSpace(fieldPath) == jsonFieldOutputText {
+			return redactedPlaceholder
+		}
+	}
+	return outputText
+}
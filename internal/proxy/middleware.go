@@ -14,26 +14,43 @@ import (
 	"go.uber.org/zap"
 )
 
-// sanitizeRequestURI replaces sensitive query parameter values with a placeholder.
-func sanitizeRequestURI(requestURL *url.URL) string {
+// sanitizeRequestURI replaces sensitive query parameter values with a placeholder. The prompt and system_prompt
+// values are replaced as well when redactPrompts is set.
+func sanitizeRequestURI(requestURL *url.URL, redactPrompts bool) string {
 	queryParameters := requestURL.Query()
-	if queryParameters.Has(queryParameterKey) {
-		queryParameters.Set(queryParameterKey, redactedPlaceholder)
+	sensitiveParameters := []string{queryParameterKey}
+	if redactPrompts {
+		sensitiveParameters = append(sensitiveParameters, queryParameterPrompt, queryParameterSystemPrompt)
+	}
+	for _, sensitiveParameter := range sensitiveParameters {
+		if queryParameters.Has(sensitiveParameter) {
+			queryParameters.Set(sensitiveParameter, redactedPlaceholder)
+		}
 	}
 	sanitizedURL := *requestURL
 	sanitizedURL.RawQuery = queryParameters.Encode()
 	return sanitizedURL.RequestURI()
 }
 
-// requestResponseLogger emits structured request and response metadata for traceability.
-func requestResponseLogger(structuredLogger *zap.SugaredLogger) gin.HandlerFunc {
+// versionHeaderMiddleware reports the proxy build version in the X-Proxy-Version header of every response.
+func versionHeaderMiddleware(proxyVersion string) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		ginContext.Header(headerProxyVersion, proxyVersion)
+		ginContext.Next()
+	}
+}
+
+// requestResponseLogger emits structured request and response metadata for traceability. Prompt query values are
+// redacted from the logged path when redactPrompts is set.
+func requestResponseLogger(structuredLogger *zap.SugaredLogger, redactPrompts bool) gin.HandlerFunc {
 	return func(ginContext *gin.Context) {
 		requestStart := time.Now()
 		requestMethod := ginContext.Request.Method
-		requestPath := sanitizeRequestURI(ginContext.Request.URL)
+		requestPath := sanitizeRequestURI(ginContext.Request.URL, redactPrompts)
 		requestClientIP := ginContext.ClientIP()
+		correlatedLogger := requestLogger(structuredLogger, ginContext.GetString(contextKeyRequestID))
 
-		structuredLogger.Infow(
+		correlatedLogger.Infow(
 			logEventRequestReceived,
 			logFieldMethod, requestMethod,
 			logFieldPath, requestPath,
@@ -44,7 +61,7 @@ func requestResponseLogger(structuredLogger *zap.SugaredLogger) gin.HandlerFunc
 
 		responseStatus := ginContext.Writer.Status()
 		responseLatencyMillis := time.Since(requestStart).Milliseconds()
-		structuredLogger.Infow(
+		correlatedLogger.Infow(
 			logEventResponseSent,
 			logFieldStatus, responseStatus,
 			constants.LogFieldLatencyMilliseconds, responseLatencyMillis,
@@ -52,21 +69,50 @@ func requestResponseLogger(structuredLogger *zap.SugaredLogger) gin.HandlerFunc
 	}
 }
 
-// secretMiddleware enforces the shared secret through a constant-time comparison of the `key` query parameter.
-func secretMiddleware(sharedSecret string, structuredLogger *zap.SugaredLogger) gin.HandlerFunc {
-	normalizedSecret := strings.TrimSpace(sharedSecret)
-	expectedSecretFingerprint := utils.Fingerprint(normalizedSecret)
+// presentedClientKey returns the client key from an `Authorization: Bearer` header, falling back to the `key` query
+// parameter when the header is absent. The query string is parsed afresh rather than through gin's query cache, so
+// that parameters merged in later by the ask-file middlewares stay visible to the handlers.
+func presentedClientKey(ginContext *gin.Context) string {
+	authorizationValue := strings.TrimSpace(ginContext.GetHeader(headerAuthorization))
+	bearerPrefixLength := len(headerAuthorizationPrefix)
+	if len(authorizationValue) > bearerPrefixLength && strings.EqualFold(authorizationValue[:bearerPrefixLength], headerAuthorizationPrefix) {
+		return strings.TrimSpace(authorizationValue[bearerPrefixLength:])
+	}
+	return strings.TrimSpace(ginContext.Request.URL.Query().Get(queryParameterKey))
+}
+
+// secretMiddleware enforces the shared secrets through constant-time comparisons of the client key, read from an
+// `Authorization: Bearer` header or the `key` query parameter.
+// The presented key is compared against every accepted secret, so the timing does not reveal which one matched.
+func secretMiddleware(acceptedSecrets []string, structuredLogger *zap.SugaredLogger) gin.HandlerFunc {
+	normalizedSecrets := make([]string, 0, len(acceptedSecrets))
+	expectedSecretFingerprints := make([]string, 0, len(acceptedSecrets))
+	for _, acceptedSecret := range acceptedSecrets {
+		normalizedSecret := strings.TrimSpace(acceptedSecret)
+		if normalizedSecret == constants.EmptyString {
+			continue
+		}
+		normalizedSecrets = append(normalizedSecrets, normalizedSecret)
+		expectedSecretFingerprints = append(expectedSecretFingerprints, utils.Fingerprint(normalizedSecret))
+	}
 	return func(ginContext *gin.Context) {
-		presentedKey := strings.TrimSpace(ginContext.Query(queryParameterKey))
-		if !constantTimeEquals(normalizedSecret, presentedKey) {
+		presentedKey := presentedClientKey(ginContext)
+		matchedFingerprint := constants.EmptyString
+		for secretIndex, normalizedSecret := range normalizedSecrets {
+			if constantTimeEquals(normalizedSecret, presentedKey) && matchedFingerprint == constants.EmptyString {
+				matchedFingerprint = expectedSecretFingerprints[secretIndex]
+			}
+		}
+		if matchedFingerprint == constants.EmptyString {
 			structuredLogger.Warnw(
 				logEventForbiddenRequest,
-				logFieldExpectedFingerprint, expectedSecretFingerprint,
+				logFieldExpectedFingerprint, expectedSecretFingerprints,
 			)
 			ginContext.String(http.StatusForbidden, errorMissingClientKey)
 			ginContext.Abort()
 			return
 		}
+		structuredLogger.Debugw(logEventAuthorizedRequest, logFieldSecretFingerprint, matchedFingerprint)
 		ginContext.Next()
 	}
 }
@@ -0,0 +1,10 @@
+package proxy
+
+// resolveModelAlias maps modelIdentifier through the configured alias table.
+// Identifiers without an alias are returned unchanged so that they reach normal model validation.
+func resolveModelAlias(modelIdentifier string, modelAliases map[string]string) string {
+	if aliasedModel, aliasFound := modelAliases[modelIdentifier]; aliasFound {
+		return aliasedModel
+	}
+	return modelIdentifier
+}
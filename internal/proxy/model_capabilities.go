@@ -1,12 +1,20 @@
 package proxy
 
 import (
+	"encoding/json"
+	"slices"
 	"strings"
+
+	"github.com/temirov/llm-proxy/internal/constants"
 )
 
 const (
 	// defaultTemperature specifies the sampling temperature for supported models.
 	defaultTemperature = 0.7
+	// MinTemperature is the lowest sampling temperature a request may ask for.
+	MinTemperature = 0.0
+	// MaxTemperature is the highest sampling temperature a request may ask for.
+	MaxTemperature = 2.0
 )
 
 // --- Request Payload Structs ---
@@ -14,7 +22,7 @@ const (
 
 // Reasoning specifies configuration options for reasoning-capable models.
 // Effort indicates the desired reasoning intensity and uses constants such as
-// reasoningEffortMinimal or reasoningEffortMedium.
+// reasoningEffortMinimal, reasoningEffortLow, reasoningEffortMedium or reasoningEffortHigh.
 type Reasoning struct {
 	Effort string `json:"effort"`
 }
@@ -24,6 +32,28 @@ type requestPayloadBase struct {
 	Model           string `json:"model"`
 	Input           string `json:"input"`
 	MaxOutputTokens int    `json:"max_output_tokens"`
+	// Stream requests server-sent events instead of a single JSON response.
+	Stream bool `json:"stream,omitempty"`
+	// Metadata carries caller-defined key-value pairs recorded with the upstream response.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Text selects the output format; omitted for plain text.
+	Text *TextOptions `json:"text,omitempty"`
+	// Seed requests deterministic sampling from models that accept it.
+	Seed *int64 `json:"seed,omitempty"`
+	// PreviousResponseID continues the conversation of an earlier response.
+	PreviousResponseID string `json:"previous_response_id,omitempty"`
+}
+
+// TextOptions configures the output text of a response.
+type TextOptions struct {
+	Format TextFormat `json:"format"`
+}
+
+// TextFormat selects plain text or, with textFormatJSONSchema, JSON output constrained by Schema.
+type TextFormat struct {
+	Type   string          `json:"type"`
+	Name   string          `json:"name,omitempty"`
+	Schema json.RawMessage `json:"schema,omitempty"`
 }
 
 // requestPayloadWithTools is for models supporting tools but not temperature (e.g., gpt-5).
@@ -51,39 +81,170 @@ type requestPayloadFull struct {
 // Tool represents a tool available to the model.
 type Tool struct {
 	Type string `json:"type"`
+	// UserLocation narrows web search results to an approximate location.
+	UserLocation *UserLocation `json:"user_location,omitempty"`
+	// SearchContextSize selects how much context the web search tool gathers.
+	SearchContextSize string `json:"search_context_size,omitempty"`
+	// Container selects the sandbox the code interpreter tool runs in.
+	Container *ToolContainer `json:"container,omitempty"`
+}
+
+// ToolContainer describes the sandbox of the code interpreter tool.
+type ToolContainer struct {
+	Type string `json:"type"`
+}
+
+// UserLocation describes the approximate location of the user for the web search tool.
+type UserLocation struct {
+	Type    string `json:"type"`
+	Country string `json:"country,omitempty"`
+	City    string `json:"city,omitempty"`
+}
+
+// RequestPayloadOptions carries the per-request settings applied by BuildRequestPayloadWithOptions.
+// Settings a model does not accept are left out of its payload.
+type RequestPayloadOptions struct {
+	// WebSearchEnabled attaches the web_search tool for models that support tools.
+	WebSearchEnabled bool
+	// Tools lists further hosted tool types attached for models that support tools.
+	Tools []string
+	// SearchLocation narrows the results of the web_search tool when it is attached.
+	SearchLocation *UserLocation
+	// SearchContextSize selects the search context of the web_search tool when it is attached.
+	SearchContextSize string
+	// MaxOutputTokens limits the length of the answer.
+	MaxOutputTokens int
+	// Stream requests server-sent events instead of a single JSON response.
+	Stream bool
+	// Metadata is attached for models whose schema allows request metadata.
+	Metadata map[string]string
+	// Temperature overrides defaultTemperature for models that accept a sampling temperature.
+	Temperature *float64
+	// ReasoningEffort selects the reasoning effort for models that accept reasoning settings.
+	ReasoningEffort string
+	// ResponseSchema constrains the answer to JSON matching this schema for models that accept text settings.
+	ResponseSchema json.RawMessage
+	// Seed requests deterministic sampling for models whose schema allows a seed.
+	Seed *int64
+	// PreviousResponseID continues the conversation of an earlier upstream response.
+	PreviousResponseID string
+}
+
+// supportedReasoningEfforts lists the reasoning effort levels a request may ask for.
+var supportedReasoningEfforts = []string{reasoningEffortMinimal, reasoningEffortLow, reasoningEffortMedium, reasoningEffortHigh}
+
+// isSupportedReasoningEffort reports whether effort names a known reasoning effort level.
+func isSupportedReasoningEffort(effort string) bool {
+	return slices.Contains(supportedReasoningEfforts, effort)
+}
+
+// degradedReasoningEffort returns the reasoning effort one level below effort. An empty effort stands for the
+// upstream default of medium. Low and minimal efforts are already the cheapest and are returned unchanged.
+func degradedReasoningEffort(effort string) string {
+	switch effort {
+	case reasoningEffortHigh:
+		return reasoningEffortMedium
+	case reasoningEffortMedium, constants.EmptyString:
+		return reasoningEffortLow
+	default:
+		return effort
+	}
+}
+
+// supportedToolTypes lists the hosted tool types a request may attach through the tools parameter. DefaultMaxTools
+// equals its length.
+var supportedToolTypes = []string{toolTypeWebSearch, toolTypeWebSearchPreview, toolTypeCodeInterpreter}
+
+// supportedSearchContextSizes lists the web search context sizes a request may ask for.
+var supportedSearchContextSizes = []string{searchContextSizeLow, searchContextSizeMedium, searchContextSizeHigh}
+
+// attachedTools returns the web_search tool when WebSearchEnabled is set followed by the listed Tools, each tool
+// type appearing once. The web_search tool carries the requested search location and context size, and the
+// code_interpreter tool runs in an automatically created container.
+func (options RequestPayloadOptions) attachedTools() []Tool {
+	var toolTypes []string
+	if options.WebSearchEnabled {
+		toolTypes = append(toolTypes, toolTypeWebSearch)
+	}
+	for _, toolType := range options.Tools {
+		if !slices.Contains(toolTypes, toolType) {
+			toolTypes = append(toolTypes, toolType)
+		}
+	}
+	tools := make([]Tool, 0, len(toolTypes))
+	for _, toolType := range toolTypes {
+		attachedTool := Tool{Type: toolType}
+		switch toolType {
+		case toolTypeWebSearch:
+			attachedTool.UserLocation = options.SearchLocation
+			attachedTool.SearchContextSize = options.SearchContextSize
+		case toolTypeCodeInterpreter:
+			attachedTool.Container = &ToolContainer{Type: toolContainerTypeAuto}
+		}
+		tools = append(tools, attachedTool)
+	}
+	return tools
+}
+
+// samplingTemperature returns the requested temperature, or defaultTemperature when none was requested.
+func (options RequestPayloadOptions) samplingTemperature() *float64 {
+	temperature := defaultTemperature
+	if options.Temperature != nil {
+		temperature = *options.Temperature
+	}
+	return &temperature
 }
 
 // BuildRequestPayload selects the correct struct for the given model and returns it.
 func BuildRequestPayload(modelIdentifier string, combinedPrompt string, webSearchEnabled bool, maxTokens int) any {
+	return BuildRequestPayloadWithOptions(modelIdentifier, combinedPrompt, RequestPayloadOptions{WebSearchEnabled: webSearchEnabled, MaxOutputTokens: maxTokens})
+}
+
+// BuildRequestPayloadWithOptions selects the correct struct for the given model, applies the options the model
+// accepts, and returns it.
+func BuildRequestPayloadWithOptions(modelIdentifier string, combinedPrompt string, options RequestPayloadOptions) any {
 	base := requestPayloadBase{
-		Model:           modelIdentifier,
-		Input:           combinedPrompt,
-		MaxOutputTokens: maxTokens,
+		Model:              modelIdentifier,
+		Input:              combinedPrompt,
+		MaxOutputTokens:    options.MaxOutputTokens,
+		Stream:             options.Stream,
+		PreviousResponseID: options.PreviousResponseID,
+	}
+	if len(options.Metadata) > 0 && modelAllowsRequestField(modelIdentifier, keyMetadata) {
+		base.Metadata = options.Metadata
+	}
+	if options.Seed != nil && modelAllowsRequestField(modelIdentifier, keySeed) {
+		base.Seed = options.Seed
+	}
+	if len(options.ResponseSchema) > 0 && modelAllowsRequestField(modelIdentifier, keyText) {
+		base.Text = &TextOptions{Format: TextFormat{Type: textFormatJSONSchema, Name: textFormatSchemaName, Schema: options.ResponseSchema}}
 	}
+	attachedTools := options.attachedTools()
 
 	// Declaratively choose the payload structure based on the model.
 	switch modelIdentifier {
 	case ModelNameGPT4o, ModelNameGPT41:
 		payload := requestPayloadFull{requestPayloadBase: base}
-		temperature := defaultTemperature
-		payload.Temperature = &temperature
-		if webSearchEnabled {
-			payload.Tools = []Tool{{Type: toolTypeWebSearch}}
+		payload.Temperature = options.samplingTemperature()
+		if len(attachedTools) > 0 {
+			payload.Tools = attachedTools
 			payload.ToolChoice = keyAuto
 		}
 		return payload
 	case ModelNameGPT5:
 		payload := requestPayloadWithTools{requestPayloadBase: base}
-		if webSearchEnabled {
-			payload.Tools = []Tool{{Type: toolTypeWebSearch}}
+		if len(attachedTools) > 0 {
+			payload.Tools = attachedTools
 			payload.ToolChoice = keyAuto
 			payload.Reasoning = &Reasoning{Effort: reasoningEffortMedium}
 		}
+		if options.ReasoningEffort != constants.EmptyString {
+			payload.Reasoning = &Reasoning{Effort: options.ReasoningEffort}
+		}
 		return payload
 	case ModelNameGPT4oMini:
 		payload := requestPayloadWithTemperature{requestPayloadBase: base}
-		temperature := defaultTemperature
-		payload.Temperature = &temperature
+		payload.Temperature = options.samplingTemperature()
 		return payload
 	case ModelNameGPT5Mini:
 		// This model has no optional parameters, so we use the base struct directly.
@@ -91,10 +252,9 @@ func BuildRequestPayload(modelIdentifier string, combinedPrompt string, webSearc
 	default:
 		// Fallback for any unknown models, assuming full capabilities as a sensible default.
 		payload := requestPayloadFull{requestPayloadBase: base}
-		temperature := defaultTemperature
-		payload.Temperature = &temperature
-		if webSearchEnabled {
-			payload.Tools = []Tool{{Type: toolTypeWebSearch}}
+		payload.Temperature = options.samplingTemperature()
+		if len(attachedTools) > 0 {
+			payload.Tools = attachedTools
 			payload.ToolChoice = keyAuto
 		}
 		return payload
@@ -124,15 +284,15 @@ const (
 
 var (
 	// SchemaGPT4oMini defines allowed payload fields for the GPT-4o-mini model.
-	SchemaGPT4oMini = ModelPayloadSchema{AllowedRequestFields: []string{keyModel, keyInput, keyMaxOutputTokens, keyTemperature}}
+	SchemaGPT4oMini = ModelPayloadSchema{AllowedRequestFields: []string{keyModel, keyInput, keyMaxOutputTokens, keyTemperature, keyMetadata, keyText}}
 	// SchemaGPT4o defines allowed payload fields for the GPT-4o model.
-	SchemaGPT4o = ModelPayloadSchema{AllowedRequestFields: []string{keyModel, keyInput, keyMaxOutputTokens, keyTemperature, keyTools, keyToolChoice}}
+	SchemaGPT4o = ModelPayloadSchema{AllowedRequestFields: []string{keyModel, keyInput, keyMaxOutputTokens, keyTemperature, keyTools, keyToolChoice, keyMetadata, keyText, keySeed}}
 	// SchemaGPT41 defines allowed payload fields for the GPT-4.1 model.
-	SchemaGPT41 = ModelPayloadSchema{AllowedRequestFields: []string{keyModel, keyInput, keyMaxOutputTokens, keyTemperature, keyTools, keyToolChoice}}
+	SchemaGPT41 = ModelPayloadSchema{AllowedRequestFields: []string{keyModel, keyInput, keyMaxOutputTokens, keyTemperature, keyTools, keyToolChoice, keyMetadata, keyText, keySeed}}
 	// SchemaGPT5Mini defines allowed payload fields for the GPT-5-mini model.
-	SchemaGPT5Mini = ModelPayloadSchema{AllowedRequestFields: []string{keyModel, keyInput, keyMaxOutputTokens}}
+	SchemaGPT5Mini = ModelPayloadSchema{AllowedRequestFields: []string{keyModel, keyInput, keyMaxOutputTokens, keyMetadata, keyText}}
 	// SchemaGPT5 defines allowed payload fields for the GPT-5 model.
-	SchemaGPT5 = ModelPayloadSchema{AllowedRequestFields: []string{keyModel, keyInput, keyMaxOutputTokens, keyTools, keyToolChoice, keyReasoning}}
+	SchemaGPT5 = ModelPayloadSchema{AllowedRequestFields: []string{keyModel, keyInput, keyMaxOutputTokens, keyTools, keyToolChoice, keyReasoning, keyMetadata, keyText}}
 )
 
 // modelPayloadSchemas associates model identifiers with their payload schemas.
@@ -144,6 +304,37 @@ var modelPayloadSchemas = map[string]ModelPayloadSchema{
 	ModelNameGPT5:      SchemaGPT5,
 }
 
+// modelOutputTokenCeilings records the largest output token budget each known model accepts.
+var modelOutputTokenCeilings = map[string]int{
+	ModelNameGPT4oMini: 16384,
+	ModelNameGPT4o:     16384,
+	ModelNameGPT41:     32768,
+	ModelNameGPT5Mini:  128000,
+	ModelNameGPT5:      128000,
+}
+
+// defaultOutputTokenCeiling bounds the output token budget of models without a recorded ceiling.
+const defaultOutputTokenCeiling = 16384
+
+// resolveOutputTokenCeiling returns the largest output token budget accepted by a model.
+func resolveOutputTokenCeiling(modelIdentifier string) int {
+	normalized := strings.ToLower(strings.TrimSpace(modelIdentifier))
+	if ceiling, found := modelOutputTokenCeilings[normalized]; found {
+		return ceiling
+	}
+	return defaultOutputTokenCeiling
+}
+
+// modelAllowsRequestField reports whether a model accepts a request field. Models without a schema are assumed to
+// accept every field, matching the full-capability fallback in BuildRequestPayloadWithOptions.
+func modelAllowsRequestField(modelIdentifier string, fieldName string) bool {
+	payloadSchema := ResolveModelPayloadSchema(modelIdentifier)
+	if len(payloadSchema.AllowedRequestFields) == 0 {
+		return true
+	}
+	return slices.Contains(payloadSchema.AllowedRequestFields, fieldName)
+}
+
 // ResolveModelPayloadSchema returns the schema for a model or an empty schema when unknown.
 func ResolveModelPayloadSchema(modelIdentifier string) ModelPayloadSchema {
 	normalized := strings.ToLower(strings.TrimSpace(modelIdentifier))
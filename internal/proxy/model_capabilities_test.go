@@ -25,11 +25,11 @@ func TestResolveModelPayloadSchema(testFramework *testing.T) {
 		modelIdentifier string
 		expectFields    []string
 	}{
-		{proxy.ModelNameGPT4oMini, []string{"model", "input", "max_output_tokens", "temperature"}},
-		{proxy.ModelNameGPT4o, []string{"model", "input", "max_output_tokens", "temperature", "tools", "tool_choice"}},
-		{proxy.ModelNameGPT41, []string{"model", "input", "max_output_tokens", "temperature", "tools", "tool_choice"}},
-		{proxy.ModelNameGPT5Mini, []string{"model", "input", "max_output_tokens"}},
-		{proxy.ModelNameGPT5, []string{"model", "input", "max_output_tokens", "tools", "tool_choice", "reasoning"}},
+		{proxy.ModelNameGPT4oMini, []string{"model", "input", "max_output_tokens", "temperature", "metadata", "text"}},
+		{proxy.ModelNameGPT4o, []string{"model", "input", "max_output_tokens", "temperature", "tools", "tool_choice", "metadata", "text", "seed"}},
+		{proxy.ModelNameGPT41, []string{"model", "input", "max_output_tokens", "temperature", "tools", "tool_choice", "metadata", "text", "seed"}},
+		{proxy.ModelNameGPT5Mini, []string{"model", "input", "max_output_tokens", "metadata", "text"}},
+		{proxy.ModelNameGPT5, []string{"model", "input", "max_output_tokens", "tools", "tool_choice", "reasoning", "metadata", "text"}},
 	}
 	for _, testCase := range testCases {
 		payloadSchema := proxy.ResolveModelPayloadSchema(testCase.modelIdentifier)
@@ -0,0 +1,91 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/temirov/llm-proxy/internal/constants"
+	"go.uber.org/zap"
+)
+
+// fallbackServerErrorRetries caps the retries of an upstream server error for every model but the last one tried,
+// so a failing model hands over to the next one instead of consuming the whole request budget.
+const fallbackServerErrorRetries = 1
+
+// serverErrorRetryLimitContextKey keys the retry limit carried by the context of an upstream request.
+type serverErrorRetryLimitContextKey struct{}
+
+// withServerErrorRetryLimit returns parent carrying retryLimit, so that the upstream requests built from it retry
+// server and rate limit errors at most retryLimit times. A retryLimit of zero keeps the usual retry policy.
+func withServerErrorRetryLimit(parent context.Context, retryLimit int) context.Context {
+	if retryLimit <= 0 {
+		return parent
+	}
+	return context.WithValue(parent, serverErrorRetryLimitContextKey{}, retryLimit)
+}
+
+// serverErrorRetryLimit returns the retry limit carried by requestContext; zero means none was set.
+func serverErrorRetryLimit(requestContext context.Context) int {
+	retryLimit, _ := requestContext.Value(serverErrorRetryLimitContextKey{}).(int)
+	return retryLimit
+}
+
+// isFallbackEligible reports whether requestError is an upstream failure that another model may not share: a
+// transport error, a server error or an exhausted rate limit. Other upstream responses describe a problem with the
+// request itself and are returned to the client as they are, as are unknown models, exhausted deadlines, cancelled
+// requests and an open circuit.
+func isFallbackEligible(requestError error) bool {
+	if errors.Is(requestError, ErrUpstreamRateLimited) {
+		return true
+	}
+	var statusError *upstreamStatusError
+	if errors.As(requestError, &statusError) {
+		return statusError.statusCode >= http.StatusInternalServerError
+	}
+	return !errors.Is(requestError, ErrUnknownModel) &&
+		!errors.Is(requestError, context.DeadlineExceeded) &&
+		!errors.Is(requestError, context.Canceled) &&
+		!errors.Is(requestError, ErrUpstreamCircuitOpen)
+}
+
+// completeWithFallbackModels calls complete with primaryModel and, while it fails with an upstream error, with each
+// of fallbackModels in order. Every attempt but the last one possible passes fallbackServerErrorRetries as the retry
+// limit; the last one passes zero and keeps the usual retry policy. The latency of failed attempts is added to the
+// reply. The last error is returned when every model fails. A successful reply records the model that produced it.
+func completeWithFallbackModels(complete func(modelIdentifier string, retryLimit int) (upstreamResponse, error), primaryModel string, fallbackModels []string, structuredLogger *zap.SugaredLogger) (upstreamResponse, error) {
+	attemptModels := []string{primaryModel}
+	for _, fallbackModel := range fallbackModels {
+		if fallbackModel != primaryModel {
+			attemptModels = append(attemptModels, fallbackModel)
+		}
+	}
+	var upstreamReply upstreamResponse
+	var requestError error
+	failedLatencyMillis := int64(0)
+	for attemptIndex, attemptModel := range attemptModels {
+		if attemptIndex > 0 {
+			if !isFallbackEligible(requestError) {
+				break
+			}
+			structuredLogger.Warnw(
+				logEventFallbackModel,
+				logFieldModel, attemptModel,
+				logFieldFailedModel, attemptModels[attemptIndex-1],
+				constants.LogFieldError, requestError,
+			)
+			failedLatencyMillis = upstreamReply.latencyMillis
+		}
+		retryLimit := 0
+		if attemptIndex < len(attemptModels)-1 {
+			retryLimit = fallbackServerErrorRetries
+		}
+		upstreamReply, requestError = complete(attemptModel, retryLimit)
+		upstreamReply.latencyMillis += failedLatencyMillis
+		upstreamReply.model = attemptModel
+		if requestError == nil {
+			break
+		}
+	}
+	return upstreamReply, requestError
+}
@@ -0,0 +1,103 @@
+package proxy
+
+import "slices"
+
+// ModelPool dedicates a task queue and its own workers to a class of models, so that slow models cannot hold up
+// the workers serving fast ones.
+type ModelPool struct {
+	// Models lists the model identifiers served by the pool. An entry naming a model alias stands for the model the
+	// alias resolves to.
+	Models []string
+	// WorkerCount is the number of workers serving the pool.
+	WorkerCount int
+	// QueueSize is the capacity of the pool's queue; zero selects Configuration.QueueSize.
+	QueueSize int
+}
+
+// modelQueues routes each task to the queue of the pool serving its model. Models outside every pool share the
+// default queue.
+type modelQueues struct {
+	defaultQueue chan requestTask
+	poolQueues   map[string]chan requestTask
+}
+
+// newModelQueues creates the default queue with defaultQueueSize slots and one queue per configured pool. Pool
+// entries naming an alias in modelAliases are routed by the model the alias resolves to.
+func newModelQueues(defaultQueueSize int, modelPools map[string]ModelPool, modelAliases map[string]string) *modelQueues {
+	queues := &modelQueues{
+		defaultQueue: make(chan requestTask, defaultQueueSize),
+		poolQueues:   make(map[string]chan requestTask),
+	}
+	for _, modelPool := range modelPools {
+		poolQueueSize := modelPool.QueueSize
+		if poolQueueSize <= 0 {
+			poolQueueSize = defaultQueueSize
+		}
+		poolQueue := make(chan requestTask, poolQueueSize)
+		for _, modelIdentifier := range modelPool.Models {
+			queues.poolQueues[resolveModelAlias(modelIdentifier, modelAliases)] = poolQueue
+		}
+	}
+	return queues
+}
+
+// queueFor returns the queue serving modelIdentifier.
+func (queues *modelQueues) queueFor(modelIdentifier string) chan requestTask {
+	if poolQueue, pooled := queues.poolQueues[modelIdentifier]; pooled {
+		return poolQueue
+	}
+	return queues.defaultQueue
+}
+
+// startWorkers starts defaultWorkerCount workers on the default queue and the configured workers of every pool,
+// all stopped together by the returned pool's shutdown.
+func (queues *modelQueues) startWorkers(defaultWorkerCount int, modelPools map[string]ModelPool, modelAliases map[string]string, processTask func(requestTask)) *workerPool {
+	workers := newWorkerPool(defaultWorkerCount, queues.defaultQueue, processTask)
+	for _, modelPool := range modelPools {
+		if len(modelPool.Models) == 0 {
+			continue
+		}
+		workers.addWorkers(modelPool.WorkerCount, queues.queueFor(resolveModelAlias(modelPool.Models[0], modelAliases)), processTask)
+	}
+	return workers
+}
+
+// validateModelPools reports ErrInvalidModelPools when a pool has no models or workers, a negative queue size, or
+// shares a model with another pool once aliases are resolved through modelAliases.
+func validateModelPools(modelPools map[string]ModelPool, modelAliases map[string]string) error {
+	pooledModels := make(map[string]bool)
+	for _, modelPool := range modelPools {
+		if len(modelPool.Models) == 0 || modelPool.WorkerCount <= 0 || modelPool.QueueSize < 0 {
+			return ErrInvalidModelPools
+		}
+		for _, modelIdentifier := range modelPool.Models {
+			resolvedModel := resolveModelAlias(modelIdentifier, modelAliases)
+			if pooledModels[resolvedModel] {
+				return ErrInvalidModelPools
+			}
+			pooledModels[resolvedModel] = true
+		}
+	}
+	return nil
+}
+
+// distinctQueues returns the default queue followed by each pool queue once.
+func (queues *modelQueues) distinctQueues() []chan requestTask {
+	distinct := []chan requestTask{queues.defaultQueue}
+	for _, poolQueue := range queues.poolQueues {
+		if !slices.Contains(distinct, poolQueue) {
+			distinct = append(distinct, poolQueue)
+		}
+	}
+	return distinct
+}
+
+// depth returns the number of tasks waiting across all queues and their combined capacity.
+func (queues *modelQueues) depth() (int, int) {
+	waitingTasks, totalCapacity := 0, 0
+	for _, taskQueue := range queues.distinctQueues() {
+		waitingTasks += len(taskQueue)
+		totalCapacity += cap(taskQueue)
+	}
+	return waitingTasks, totalCapacity
+}
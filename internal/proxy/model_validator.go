@@ -3,6 +3,8 @@ package proxy
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 )
 
 // errUnknownModelFormat specifies the format string for wrapping an unknown model error.
@@ -12,17 +14,29 @@ const errUnknownModelFormat = "%w: %s"
 var ErrUnknownModel = errors.New(errorUnknownModel)
 
 // modelValidator validates model identifiers using the static payload schema table.
-type modelValidator struct{}
+// Verification never contacts OpenAI, so unknown models are rejected without any models-list refresh.
+type modelValidator struct {
+	// skipVerification accepts every model identifier, forwarding unknown models to the upstream.
+	skipVerification bool
+}
 
-// newModelValidator creates a modelValidator.
-func newModelValidator() (*modelValidator, error) {
-	return &modelValidator{}, nil
+// newModelValidator creates a modelValidator. When skipVerification is set, Verify accepts every identifier.
+func newModelValidator(skipVerification bool) (*modelValidator, error) {
+	return &modelValidator{skipVerification: skipVerification}, nil
 }
 
 // Verify checks whether the provided model identifier is known.
 func (validator *modelValidator) Verify(modelIdentifier string) error {
+	if validator.skipVerification {
+		return nil
+	}
 	if _, known := modelPayloadSchemas[modelIdentifier]; !known {
 		return fmt.Errorf(errUnknownModelFormat, ErrUnknownModel, modelIdentifier)
 	}
 	return nil
 }
+
+// models returns the recognized model identifiers in lexical order.
+func (validator *modelValidator) models() []string {
+	return slices.Sorted(maps.Keys(modelPayloadSchemas))
+}
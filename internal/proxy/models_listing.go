@@ -0,0 +1,39 @@
+package proxy
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// modelsPath serves the recognized model identifiers in the OpenAI list shape.
+	modelsPath = "/v1/models"
+	// modelListObject is the object type of the models list document.
+	modelListObject = "list"
+	// modelObject is the object type of each listed model.
+	modelObject = "model"
+)
+
+// modelListing is the JSON document served by the models endpoint.
+type modelListing struct {
+	Object string           `json:"object"`
+	Data   []modelListEntry `json:"data"`
+}
+
+// modelListEntry describes one recognized model.
+type modelListEntry struct {
+	ID     string `json:"id"`
+	Object string `json:"object"`
+}
+
+// modelsHandler lists the models accepted by validator.
+func modelsHandler(validator *modelValidator) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		listing := modelListing{Object: modelListObject, Data: []modelListEntry{}}
+		for _, modelIdentifier := range validator.models() {
+			listing.Data = append(listing.Data, modelListEntry{ID: modelIdentifier, Object: modelObject})
+		}
+		ginContext.JSON(http.StatusOK, listing)
+	}
+}
@@ -8,12 +8,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/temirov/llm-proxy/internal/constants"
 	"github.com/temirov/llm-proxy/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -35,24 +39,101 @@ type OpenAIClient struct {
 	requestTimeout      time.Duration
 	maxOutputTokens     int
 	upstreamPollTimeout time.Duration
+	// pollInterval is the wait between polls of an incomplete response.
+	pollInterval time.Duration
+	// promptPrefix and promptSuffix wrap the combined prompt of every initial upstream request.
+	promptPrefix string
+	promptSuffix string
+	// modelOutputTokenCeilings caps the output token budget sent upstream per model.
+	modelOutputTokenCeilings map[string]int
+	// logRedactedFields lists JSON paths whose values are masked before upstream bodies are logged.
+	logRedactedFields []string
+	// retryOnLengthTruncation re-issues a request once with a larger budget when the answer hits the output token limit.
+	retryOnLengthTruncation bool
+	// retryOnParseFailure re-issues a request once when the initial response body is not valid JSON.
+	retryOnParseFailure bool
+	// synthesisBudgetFraction limits each synthesis poll phase to this share of the request budget left; zero disables the limit.
+	synthesisBudgetFraction float64
+	// extractionStrategy selects whether output_text or the assistant message is preferred when extracting text.
+	extractionStrategy string
+	// organization is sent in the OpenAI-Organization header when set.
+	organization string
+	// project is sent in the OpenAI-Project header when set.
+	project string
+	// azureAPIVersion, when set, authenticates with the Azure api-key header and adds it as the api-version query
+	// parameter of every upstream request.
+	azureAPIVersion string
+	// backoffSettings tunes the exponential backoff between retries of an upstream request.
+	backoffSettings utils.BackoffSettings
+	// stuckSessionPollThreshold escalates a continued session to a synthesis continuation after this many polls
+	// without progress; zero polls until the deadline.
+	stuckSessionPollThreshold int
+	// circuitBreaker stops upstream calls after sustained failures; nil disables it.
+	circuitBreaker *circuitBreaker
+	// tracer starts the spans around upstream calls; it records nothing unless a tracer provider is configured.
+	tracer trace.Tracer
 }
 
 // NewOpenAIClient constructs an OpenAIClient initialized with the supplied components.
-func NewOpenAIClient(httpClient HTTPDoer, endpoints *Endpoints, requestTimeout time.Duration, maxTokens int, pollTimeout time.Duration) *OpenAIClient {
+func NewOpenAIClient(httpClient HTTPDoer, endpoints *Endpoints, requestTimeout time.Duration, maxTokens int, pollTimeout time.Duration, pollInterval time.Duration) *OpenAIClient {
 	return &OpenAIClient{
 		httpClient:          httpClient,
 		endpoints:           endpoints,
 		requestTimeout:      requestTimeout,
 		maxOutputTokens:     maxTokens,
 		upstreamPollTimeout: pollTimeout,
+		pollInterval:        pollInterval,
+		tracer:              newTracer(nil),
 	}
 }
 
 const (
 	synthesisInstructionPrimary = "Now synthesize the final answer with concise citations."
 	synthesisInstructionRetry   = "Produce the final answer now as plain text with concise citations. Do not call tools. Do not include hidden reasoning."
+
+	// synthesisOutputTokenFloor is the minimum output budget granted to the first synthesis pass.
+	synthesisOutputTokenFloor = 1536
+	// synthesisRetryOutputTokenFloor is the minimum output budget granted to the stricter synthesis retry.
+	synthesisRetryOutputTokenFloor = 2048
+	// truncationRetryBudgetMultiplier scales the output budget of a request retried after length truncation.
+	truncationRetryBudgetMultiplier = 2
+	// sessionProgressSeparator joins the status and output item count that summarize a non-terminal response.
+	sessionProgressSeparator = ":"
 )
 
+// effectiveMaxOutputTokens returns the per-request output token limit when one is supplied and the configured limit otherwise.
+func (client *OpenAIClient) effectiveMaxOutputTokens(requestedMaxOutputTokens int) int {
+	if requestedMaxOutputTokens > 0 {
+		return requestedMaxOutputTokens
+	}
+	return client.maxOutputTokens
+}
+
+// clampedMaxOutputTokens returns maxOutputTokens lowered to the ceiling configured for modelIdentifier, logging when
+// the budget had to be clamped.
+func (client *OpenAIClient) clampedMaxOutputTokens(modelIdentifier string, maxOutputTokens int, structuredLogger *zap.SugaredLogger) int {
+	outputTokenCeiling, hasCeiling := client.modelOutputTokenCeilings[modelIdentifier]
+	if !hasCeiling || maxOutputTokens <= outputTokenCeiling {
+		return maxOutputTokens
+	}
+	structuredLogger.Infow(
+		logEventMaxOutputTokensClamped,
+		logFieldModel, modelIdentifier,
+		logFieldRequestedMaxOutputTokens, maxOutputTokens,
+		logFieldMaxOutputTokens, outputTokenCeiling,
+	)
+	return outputTokenCeiling
+}
+
+// requestPayload builds the initial upstream request body for the prompt, wrapped in the configured prompt prefix
+// and suffix, with the output token budget resolved and clamped exactly as it is sent upstream. It also returns that
+// budget, so callers reuse it instead of clamping again.
+func (client *OpenAIClient) requestPayload(modelIdentifier string, userPrompt string, systemPrompt string, options RequestPayloadOptions, structuredLogger *zap.SugaredLogger) (any, int) {
+	options.MaxOutputTokens = client.clampedMaxOutputTokens(modelIdentifier, client.effectiveMaxOutputTokens(options.MaxOutputTokens), structuredLogger)
+	input := client.promptPrefix + combinePrompts(systemPrompt, userPrompt) + client.promptSuffix
+	return BuildRequestPayloadWithOptions(modelIdentifier, input, options), options.MaxOutputTokens
+}
+
 // hasFinalMessage checks if the response payload contains the terminal assistant message.
 func hasFinalMessage(rawPayload []byte) bool {
 	var envelope struct {
@@ -80,45 +161,52 @@ func hasFinalMessage(rawPayload []byte) bool {
 	return false
 }
 
-// openAIRequest sends a prompt to the OpenAI responses API and returns the resulting text.
-func (client *OpenAIClient) openAIRequest(openAIKey string, modelIdentifier string, userPrompt string, systemPrompt string, webSearchEnabled bool, structuredLogger *zap.SugaredLogger) (string, error) {
-	// The Responses API expects a single string input. We'll prepend the system prompt to the user prompt.
+// combinePrompts builds the single string input expected by the Responses API by prepending the system prompt to the user prompt.
+func combinePrompts(systemPrompt string, userPrompt string) string {
 	var combinedPrompt strings.Builder
 	if !utils.IsBlank(systemPrompt) {
 		combinedPrompt.WriteString(systemPrompt)
 		combinedPrompt.WriteString("\n\n")
 	}
 	combinedPrompt.WriteString(userPrompt)
+	return combinedPrompt.String()
+}
 
-	payload := BuildRequestPayload(modelIdentifier, combinedPrompt.String(), webSearchEnabled, client.maxOutputTokens)
+// openAIRequest sends the prompt to the responses API and waits for the final answer, driving continuation,
+// synthesis and polling as needed. options.MaxOutputTokens overrides the configured limit when positive. Upstream
+// spans are started as children of the span carried by traceContext.
+func (client *OpenAIClient) openAIRequest(traceContext context.Context, openAIKey string, modelIdentifier string, userPrompt string, systemPrompt string, options RequestPayloadOptions, structuredLogger *zap.SugaredLogger) (upstreamResponse, error) {
+	requestStart := time.Now()
+	payload, maxOutputTokens := client.requestPayload(modelIdentifier, userPrompt, systemPrompt, options, structuredLogger)
 	payloadBytes, marshalError := json.Marshal(payload)
 	if marshalError != nil {
 		structuredLogger.Errorw(logEventMarshalRequestPayload, constants.LogFieldError, marshalError)
-		return constants.EmptyString, marshalError
+		return upstreamResponse{}, marshalError
 	}
 
-	requestContext, cancelRequest := context.WithTimeout(context.Background(), client.requestTimeout)
+	requestContext, cancelRequest := context.WithTimeout(traceContext, client.requestTimeout)
 	defer cancelRequest()
-	httpRequest, buildError := buildAuthorizedJSONRequest(requestContext, http.MethodPost, client.endpoints.GetResponsesURL(), openAIKey, bytes.NewReader(payloadBytes))
+	httpRequest, buildError := client.buildAuthorizedJSONRequest(requestContext, http.MethodPost, client.endpoints.ResponsesURLForModel(modelIdentifier), openAIKey, bytes.NewReader(payloadBytes))
 	if buildError != nil {
 		structuredLogger.Errorw(logEventBuildHTTPRequest, constants.LogFieldError, buildError)
-		return constants.EmptyString, buildError
+		return upstreamResponse{}, buildError
 	}
 
 	statusCode, responseBytes, latencyMillis, requestError := client.performResponsesRequest(httpRequest, structuredLogger, logEventOpenAIRequestError)
 	if requestError != nil {
-		if errors.Is(requestError, context.DeadlineExceeded) {
-			return constants.EmptyString, requestError
+		if errors.Is(requestError, context.DeadlineExceeded) || errors.Is(requestError, ErrUpstreamCircuitOpen) || errors.Is(requestError, ErrUpstreamRateLimited) {
+			return upstreamResponse{}, withUpstreamStatus(requestError, statusCode, responseBytes)
 		}
-		return constants.EmptyString, errors.New(errorOpenAIRequest)
+		return upstreamResponse{}, withUpstreamStatus(errors.New(errorOpenAIRequest), statusCode, responseBytes)
 	}
 
-	structuredLogger.Debugw(logEventOpenAIInitialResponseBody, logFieldResponseBody, string(responseBytes))
+	structuredLogger.Debugw(logEventOpenAIInitialResponseBody, logFieldResponseBody, string(redactJSONFields(responseBytes, client.logRedactedFields)))
 
 	var decodedObject map[string]any
-	_ = json.Unmarshal(responseBytes, &decodedObject)
+	decodeError := json.Unmarshal(responseBytes, &decodedObject)
 
-	outputText := extractTextFromAny(responseBytes)
+	outputText, fallbackUsed := extractTextFromAny(responseBytes, client.extractionStrategy)
+	finishReason := extractFinishReason(decodedObject)
 	responseIdentifier := utils.GetString(decodedObject, jsonFieldID)
 	apiStatus := utils.GetString(decodedObject, jsonFieldStatus)
 
@@ -127,21 +215,37 @@ func (client *OpenAIClient) openAIRequest(openAIKey string, modelIdentifier stri
 		logFieldHTTPStatus, statusCode,
 		logFieldAPIStatus, apiStatus,
 		constants.LogFieldLatencyMilliseconds, latencyMillis,
-		logFieldResponseText, outputText,
+		logFieldResponseText, redactLoggedText(outputText, client.logRedactedFields),
 	)
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
 		structuredLogger.Desugar().Error(
 			errorOpenAIAPI,
 			zap.Int(logFieldStatus, statusCode),
-			zap.ByteString(logFieldResponseBody, responseBytes),
+			zap.ByteString(logFieldResponseBody, redactJSONFields(responseBytes, client.logRedactedFields)),
 		)
-		return constants.EmptyString, errors.New(errorOpenAIAPI)
+		return upstreamResponse{}, withUpstreamStatus(errors.New(errorOpenAIAPI), statusCode, responseBytes)
+	}
+	if embeddedError := embeddedUpstreamError(decodedObject); embeddedError != nil {
+		structuredLogger.Desugar().Error(
+			errorOpenAIAPI,
+			zap.Int(logFieldStatus, statusCode),
+			zap.ByteString(logFieldResponseBody, redactJSONFields(responseBytes, client.logRedactedFields)),
+		)
+		return upstreamResponse{}, embeddedError
+	}
+	if decodeError != nil {
+		structuredLogger.Warnw(logEventParseResponseFailed, constants.LogFieldError, decodeError)
+		return upstreamResponse{}, ErrMalformedUpstreamResponse
 	}
 
 	isTerminalStatus := false
 	switch apiStatus {
-	case statusCompleted, statusSucceeded, statusDone, statusCancelled, statusFailed, statusErrored:
+	case statusCompleted, statusSucceeded, statusDone, statusIncomplete, statusCancelled, statusFailed, statusErrored:
+		isTerminalStatus = true
+	}
+	// Chat-completions bodies carry no status and are always final.
+	if _, hasChoices := decodedObject[jsonFieldChoices]; hasChoices && apiStatus == constants.EmptyString {
 		isTerminalStatus = true
 	}
 
@@ -160,123 +264,202 @@ func (client *OpenAIClient) openAIRequest(openAIKey string, modelIdentifier stri
 		//  - Non-terminal: ask upstream to keep going via POST /{id}/continue, then poll the same id
 		//  - Forced synthesis: create a new response (previous_response_id, tool_choice:"none"), then poll the new id
 		targetResponseID := responseIdentifier
+		cumulativeLatencyMillis := latencyMillis
 
 		if forcedSynthesis {
-			newID, synthErr := client.startSynthesisContinuation(openAIKey, responseIdentifier, modelIdentifier, structuredLogger /*retryOrdinal=*/, 0)
+			newID, synthesisLatencyMillis, synthErr := client.startSynthesisContinuation(traceContext, openAIKey, responseIdentifier, modelIdentifier, maxOutputTokens, structuredLogger /*retryOrdinal=*/, 0)
+			cumulativeLatencyMillis += synthesisLatencyMillis
 			if synthErr != nil {
 				structuredLogger.Errorw(
 					logEventOpenAIContinueError,
 					logFieldID, responseIdentifier,
 					constants.LogFieldError, synthErr,
 				)
-				return constants.EmptyString, errors.New(errorOpenAIAPI)
+				return upstreamResponse{}, errors.New(errorOpenAIAPI)
 			}
 			targetResponseID = newID
 		} else {
-			if continueError := client.continueResponse(openAIKey, responseIdentifier, structuredLogger); continueError != nil {
+			continueLatencyMillis, continueError := client.continueResponse(traceContext, openAIKey, responseIdentifier, structuredLogger)
+			cumulativeLatencyMillis += continueLatencyMillis
+			if continueError != nil {
 				structuredLogger.Errorw(
 					logEventOpenAIContinueError,
 					logFieldID, responseIdentifier,
 					constants.LogFieldError, continueError,
 				)
-				return constants.EmptyString, errors.New(errorOpenAIAPI)
+				return upstreamResponse{}, errors.New(errorOpenAIAPI)
 			}
 		}
 
-		finalText, pollError := client.pollResponseUntilDone(openAIKey, targetResponseID, structuredLogger)
+		stuckPollThreshold := client.stuckSessionPollThreshold
+		if forcedSynthesis {
+			stuckPollThreshold = 0
+		}
+		finalResponse, pollError := client.pollResponseUntilDone(traceContext, openAIKey, targetResponseID, client.pollDeadline(requestStart), stuckPollThreshold, structuredLogger)
+		if errors.Is(pollError, errSessionStuck) {
+			// The continued session stopped advancing; ask for a synthesis from what it has produced so far.
+			structuredLogger.Warnw(logEventSessionStuck, logFieldID, targetResponseID, logFieldPollCount, stuckPollThreshold)
+			cumulativeLatencyMillis += finalResponse.latencyMillis
+			newID, synthesisLatencyMillis, synthErr := client.startSynthesisContinuation(traceContext, openAIKey, targetResponseID, modelIdentifier, maxOutputTokens, structuredLogger /*retryOrdinal=*/, 0)
+			cumulativeLatencyMillis += synthesisLatencyMillis
+			if synthErr != nil {
+				structuredLogger.Errorw(
+					logEventOpenAIContinueError,
+					logFieldID, targetResponseID,
+					constants.LogFieldError, synthErr,
+				)
+				return upstreamResponse{}, errors.New(errorOpenAIAPI)
+			}
+			forcedSynthesis = true
+			targetResponseID = newID
+			finalResponse, pollError = client.pollResponseUntilDone(traceContext, openAIKey, targetResponseID, client.pollDeadline(requestStart), 0, structuredLogger)
+		}
 		if pollError != nil {
 			structuredLogger.Errorw(
 				logEventOpenAIPollError,
 				logFieldID, targetResponseID,
 				constants.LogFieldError, pollError,
 			)
-			return constants.EmptyString, errors.New(errorOpenAIAPI)
+			return upstreamResponse{}, pollFailure(pollError)
 		}
-		if !utils.IsBlank(finalText) {
-			return finalText, nil
+		cumulativeLatencyMillis += finalResponse.latencyMillis
+		if !utils.IsBlank(finalResponse.text) {
+			finalResponse.latencyMillis = cumulativeLatencyMillis
+			finalResponse.responseID = targetResponseID
+			return finalResponse, nil
 		}
 
 		// --- Fallback: one more synthesis continuation if still no text ---
 		if forcedSynthesis {
 			structuredLogger.Debugw(logEventRetryingSynthesis)
-			newID, synthErr := client.startSynthesisContinuation(openAIKey, targetResponseID, modelIdentifier, structuredLogger /*retryOrdinal=*/, 1)
+			newID, synthesisLatencyMillis, synthErr := client.startSynthesisContinuation(traceContext, openAIKey, targetResponseID, modelIdentifier, maxOutputTokens, structuredLogger /*retryOrdinal=*/, 1)
+			cumulativeLatencyMillis += synthesisLatencyMillis
 			if synthErr != nil {
 				structuredLogger.Errorw(
 					logEventOpenAIContinueError,
 					logFieldID, targetResponseID,
 					constants.LogFieldError, synthErr,
 				)
-				return constants.EmptyString, errors.New(errorOpenAIAPI)
+				return upstreamResponse{}, errors.New(errorOpenAIAPI)
 			}
 			targetResponseID = newID
 
-			finalText2, pollError2 := client.pollResponseUntilDone(openAIKey, targetResponseID, structuredLogger)
+			finalResponse2, pollError2 := client.pollResponseUntilDone(traceContext, openAIKey, targetResponseID, client.pollDeadline(requestStart), 0, structuredLogger)
 			if pollError2 != nil {
 				structuredLogger.Errorw(
 					logEventOpenAIPollError,
 					logFieldID, targetResponseID,
 					constants.LogFieldError, pollError2,
 				)
-				return constants.EmptyString, errors.New(errorOpenAIAPI)
+				return upstreamResponse{}, pollFailure(pollError2)
 			}
-			if !utils.IsBlank(finalText2) {
-				return finalText2, nil
+			if !utils.IsBlank(finalResponse2.text) {
+				finalResponse2.latencyMillis += cumulativeLatencyMillis
+				finalResponse2.responseID = targetResponseID
+				return finalResponse2, nil
 			}
 		}
 
-		return constants.EmptyString, errors.New(errorOpenAIAPINoText)
+		return upstreamResponse{}, errors.New(errorOpenAIAPINoText)
 	}
 
 	// If the initial response is terminal but we couldn't extract text, it's an error.
 	if utils.IsBlank(outputText) {
-		return constants.EmptyString, errors.New(errorOpenAIAPI)
+		return upstreamResponse{}, errors.New(errorOpenAIAPI)
 	}
-	return outputText, nil
+	return upstreamResponse{text: outputText, finishReason: finishReason, latencyMillis: latencyMillis, fallbackUsed: fallbackUsed, usage: extractTokenUsage(decodedObject), responseID: responseIdentifier}, nil
+}
+
+// completeRequest performs openAIRequest. When retryOnParseFailure is set and the initial response body is not valid
+// JSON, the request is re-issued once. When retryOnLengthTruncation is set and the answer stopped at the output
+// token limit, it is re-issued once with a larger budget bounded by the model's output token ceiling and by its
+// configured ceiling, if any; the truncated answer is returned when the budget cannot grow or that retry fails.
+func (client *OpenAIClient) completeRequest(traceContext context.Context, openAIKey string, modelIdentifier string, userPrompt string, systemPrompt string, options RequestPayloadOptions, structuredLogger *zap.SugaredLogger) (upstreamResponse, error) {
+	firstResponse, firstError := client.openAIRequest(traceContext, openAIKey, modelIdentifier, userPrompt, systemPrompt, options, structuredLogger)
+	if client.retryOnParseFailure && errors.Is(firstError, ErrMalformedUpstreamResponse) {
+		structuredLogger.Infow(logEventRetryingMalformedResponse, logFieldModel, modelIdentifier)
+		firstResponse, firstError = client.openAIRequest(traceContext, openAIKey, modelIdentifier, userPrompt, systemPrompt, options, structuredLogger)
+	}
+	if firstError != nil || !client.retryOnLengthTruncation || firstResponse.finishReason != finishReasonLength {
+		return firstResponse, firstError
+	}
+	outputTokenCeiling := resolveOutputTokenCeiling(modelIdentifier)
+	if configuredCeiling, hasCeiling := client.modelOutputTokenCeilings[modelIdentifier]; hasCeiling {
+		outputTokenCeiling = min(outputTokenCeiling, configuredCeiling)
+	}
+	currentBudget := min(client.effectiveMaxOutputTokens(options.MaxOutputTokens), outputTokenCeiling)
+	retryBudget := min(currentBudget*truncationRetryBudgetMultiplier, outputTokenCeiling)
+	if retryBudget <= currentBudget {
+		return firstResponse, nil
+	}
+	structuredLogger.Infow(logEventRetryingTruncatedResponse, logFieldModel, modelIdentifier, logFieldMaxOutputTokens, retryBudget)
+	options.MaxOutputTokens = retryBudget
+	retryResponse, retryError := client.openAIRequest(traceContext, openAIKey, modelIdentifier, userPrompt, systemPrompt, options, structuredLogger)
+	if retryError != nil {
+		structuredLogger.Warnw(logEventOpenAIRequestError, constants.LogFieldError, retryError)
+		return firstResponse, nil
+	}
+	retryResponse.latencyMillis += firstResponse.latencyMillis
+	return retryResponse, nil
 }
 
 // continueResponse signals to the API that a response session should proceed (legacy non-terminal case).
-func (client *OpenAIClient) continueResponse(openAIKey string, responseIdentifier string, structuredLogger *zap.SugaredLogger) error {
+// It returns the upstream latency of the call.
+func (client *OpenAIClient) continueResponse(traceContext context.Context, openAIKey string, responseIdentifier string, structuredLogger *zap.SugaredLogger) (int64, error) {
 	resourceURL := client.endpoints.GetResponsesURL() + "/" + responseIdentifier + "/continue"
-	requestContext, cancel := context.WithTimeout(context.Background(), client.requestTimeout)
+	requestContext, cancel := context.WithTimeout(traceContext, client.requestTimeout)
 	defer cancel()
 
-	httpRequest, buildError := buildAuthorizedJSONRequest(requestContext, http.MethodPost, resourceURL, openAIKey, nil)
+	httpRequest, buildError := client.buildAuthorizedJSONRequest(requestContext, http.MethodPost, resourceURL, openAIKey, nil)
 	if buildError != nil {
-		return buildError
+		return 0, buildError
 	}
 
-	statusCode, responseBytes, _, requestError := client.performResponsesRequest(httpRequest, structuredLogger, logEventOpenAIContinueError)
+	statusCode, responseBytes, latencyMillis, requestError := client.performResponsesRequest(httpRequest, structuredLogger, logEventOpenAIContinueError)
 	if requestError != nil {
-		return requestError
+		return latencyMillis, requestError
 	}
 
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
 		structuredLogger.Desugar().Error(
 			errorOpenAIContinue,
 			zap.Int(logFieldStatus, statusCode),
-			zap.ByteString(logFieldResponseBody, responseBytes),
+			zap.ByteString(logFieldResponseBody, redactJSONFields(responseBytes, client.logRedactedFields)),
 			zap.String(logFieldID, responseIdentifier),
 		)
-		return errors.New(errorOpenAIContinue)
+		return latencyMillis, errors.New(errorOpenAIContinue)
 	}
-	return nil
+	return latencyMillis, nil
 }
 
 // startSynthesisContinuation begins a synthesis-only pass by POSTing /v1/responses with
 // previous_response_id and tool_choice set to "none". It allocates enough output tokens,
 // limits reasoning effort to minimal, and includes a low-verbosity text format hint.
+// maxOutputTokens is the request's output ceiling; synthesis never receives less than it.
 // When retryOrdinal is 1 the instruction is strengthened and the token limit is increased.
-// It returns the identifier of the new response.
+// It returns the identifier of the new response and the upstream latency of the call.
 //
 // retryOrdinal==0 : first synthesis pass; retryOrdinal==1 : stricter retry
-func (client *OpenAIClient) startSynthesisContinuation(openAIKey string, previousResponseID string, modelIdentifier string, structuredLogger *zap.SugaredLogger, retryOrdinal int) (string, error) {
-	outputTokenLimit := client.maxOutputTokens
-	if outputTokenLimit < 1536 {
-		outputTokenLimit = 1536
+func (client *OpenAIClient) startSynthesisContinuation(traceContext context.Context, openAIKey string, previousResponseID string, modelIdentifier string, maxOutputTokens int, structuredLogger *zap.SugaredLogger, retryOrdinal int) (string, int64, error) {
+	spanContext, synthesisSpan := client.tracer.Start(traceContext, spanNameSynthesis, trace.WithAttributes(
+		attribute.String(spanAttributeModel, modelIdentifier),
+		attribute.String(spanAttributeResponseID, previousResponseID),
+	))
+	newID, latencyMillis, synthesisError := client.requestSynthesisContinuation(spanContext, openAIKey, previousResponseID, modelIdentifier, maxOutputTokens, structuredLogger, retryOrdinal)
+	synthesisSpan.SetAttributes(attribute.Int64(spanAttributeLatencyMilliseconds, latencyMillis))
+	endSpan(synthesisSpan, synthesisError)
+	return newID, latencyMillis, synthesisError
+}
+
+// requestSynthesisContinuation performs the upstream call of startSynthesisContinuation.
+func (client *OpenAIClient) requestSynthesisContinuation(traceContext context.Context, openAIKey string, previousResponseID string, modelIdentifier string, maxOutputTokens int, structuredLogger *zap.SugaredLogger, retryOrdinal int) (string, int64, error) {
+	outputTokenLimit := maxOutputTokens
+	if outputTokenLimit < synthesisOutputTokenFloor {
+		outputTokenLimit = synthesisOutputTokenFloor
 	}
 	if retryOrdinal == 1 {
-		if outputTokenLimit < 2048 {
-			outputTokenLimit = 2048
+		if outputTokenLimit < synthesisRetryOutputTokenFloor {
+			outputTokenLimit = synthesisRetryOutputTokenFloor
 		}
 	}
 
@@ -302,91 +485,211 @@ func (client *OpenAIClient) startSynthesisContinuation(openAIKey string, previou
 	payloadBytes, marshalError := json.Marshal(payload)
 	if marshalError != nil {
 		structuredLogger.Errorw(logEventMarshalRequestPayload, constants.LogFieldError, marshalError)
-		return constants.EmptyString, marshalError
+		return constants.EmptyString, 0, marshalError
 	}
 
-	requestContext, cancelRequest := context.WithTimeout(context.Background(), client.requestTimeout)
+	requestContext, cancelRequest := context.WithTimeout(traceContext, client.requestTimeout)
 	defer cancelRequest()
-	request, buildError := buildAuthorizedJSONRequest(requestContext, http.MethodPost, client.endpoints.GetResponsesURL(), openAIKey, bytes.NewReader(payloadBytes))
+	request, buildError := client.buildAuthorizedJSONRequest(requestContext, http.MethodPost, client.endpoints.ResponsesURLForModel(modelIdentifier), openAIKey, bytes.NewReader(payloadBytes))
 	if buildError != nil {
-		return constants.EmptyString, buildError
+		return constants.EmptyString, 0, buildError
 	}
 
-	statusCode, responseBytes, _, requestError := client.performResponsesRequest(request, structuredLogger, logEventOpenAIRequestError)
+	statusCode, responseBytes, latencyMillis, requestError := client.performResponsesRequest(request, structuredLogger, logEventOpenAIRequestError)
 	if requestError != nil {
-		return constants.EmptyString, requestError
+		return constants.EmptyString, latencyMillis, requestError
 	}
 	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
-		return constants.EmptyString, errors.New(errorOpenAIAPI)
+		return constants.EmptyString, latencyMillis, errors.New(errorOpenAIAPI)
 	}
 
 	var decodedResponse map[string]any
 	if json.Unmarshal(responseBytes, &decodedResponse) != nil {
-		return constants.EmptyString, errors.New(errorOpenAIAPI)
+		return constants.EmptyString, latencyMillis, errors.New(errorOpenAIAPI)
 	}
 	newID := utils.GetString(decodedResponse, jsonFieldID)
 	if utils.IsBlank(newID) {
-		return constants.EmptyString, errors.New(errorOpenAIAPI)
+		return constants.EmptyString, latencyMillis, errors.New(errorOpenAIAPI)
 	}
-	return newID, nil
+	return newID, latencyMillis, nil
 }
 
-// pollResponseUntilDone repeatedly fetches a response until it is complete or the poll timeout elapses.
-func (client *OpenAIClient) pollResponseUntilDone(openAIKey string, responseIdentifier string, structuredLogger *zap.SugaredLogger) (string, error) {
-	deadlineInstant := time.Now().Add(client.upstreamPollTimeout)
+// pollDeadline returns when a poll phase starting now must end. The upstream poll timeout always applies. When
+// synthesisBudgetFraction is set, the phase is also limited to that fraction of the request budget remaining since
+// requestStart, so a slow initial response cannot leave later phases without time and a slow poll cannot starve them.
+func (client *OpenAIClient) pollDeadline(requestStart time.Time) time.Time {
+	timeoutDeadline := time.Now().Add(client.upstreamPollTimeout)
+	if client.synthesisBudgetFraction <= 0 {
+		return timeoutDeadline
+	}
+	remainingBudget := time.Until(requestStart.Add(client.requestTimeout))
+	budgetDeadline := time.Now().Add(time.Duration(float64(remainingBudget) * client.synthesisBudgetFraction))
+	if budgetDeadline.Before(timeoutDeadline) {
+		return budgetDeadline
+	}
+	return timeoutDeadline
+}
+
+// pollResponseUntilDone repeatedly fetches a response until it is complete or deadlineInstant passes.
+// The returned response carries the summed upstream latency of all fetches. When stuckPollThreshold is positive and
+// that many consecutive polls report the same status and output item count, it gives up with errSessionStuck.
+func (client *OpenAIClient) pollResponseUntilDone(traceContext context.Context, openAIKey string, responseIdentifier string, deadlineInstant time.Time, stuckPollThreshold int, structuredLogger *zap.SugaredLogger) (upstreamResponse, error) {
+	spanContext, pollSpan := client.tracer.Start(traceContext, spanNamePoll, trace.WithAttributes(attribute.String(spanAttributeResponseID, responseIdentifier)))
+	polledResponse, pollError := client.pollUntilDone(spanContext, openAIKey, responseIdentifier, deadlineInstant, stuckPollThreshold, structuredLogger)
+	pollSpan.SetAttributes(attribute.Int64(spanAttributeLatencyMilliseconds, polledResponse.latencyMillis))
+	endSpan(pollSpan, pollError)
+	return polledResponse, pollError
+}
+
+// pollUntilDone performs the polling loop of pollResponseUntilDone.
+func (client *OpenAIClient) pollUntilDone(traceContext context.Context, openAIKey string, responseIdentifier string, deadlineInstant time.Time, stuckPollThreshold int, structuredLogger *zap.SugaredLogger) (upstreamResponse, error) {
+	var pollLatencyMillis int64
+	lastProgress := constants.EmptyString
+	unchangedPolls := 0
 	for {
 		if time.Now().After(deadlineInstant) {
-			return constants.EmptyString, ErrUpstreamIncomplete
+			return upstreamResponse{}, ErrUpstreamIncomplete
 		}
-		textCandidate, responseComplete, fetchError := client.fetchResponseByID(deadlineInstant, openAIKey, responseIdentifier, structuredLogger)
+		responseCandidate, responseComplete, fetchError := client.fetchResponseByID(traceContext, deadlineInstant, openAIKey, responseIdentifier, structuredLogger)
 		if fetchError != nil {
-			return constants.EmptyString, fetchError
+			return upstreamResponse{}, fetchError
 		}
-		if responseComplete && !utils.IsBlank(textCandidate) {
-			return textCandidate, nil
+		pollLatencyMillis += responseCandidate.latencyMillis
+		if responseComplete && !utils.IsBlank(responseCandidate.text) {
+			responseCandidate.latencyMillis = pollLatencyMillis
+			return responseCandidate, nil
 		}
 		if responseComplete {
-			return constants.EmptyString, errors.New(errorOpenAIAPINoText)
+			return upstreamResponse{}, errors.New(errorOpenAIAPINoText)
+		}
+		if responseCandidate.sessionProgress == lastProgress {
+			unchangedPolls++
+		} else {
+			lastProgress = responseCandidate.sessionProgress
+			unchangedPolls = 1
+		}
+		if stuckPollThreshold > 0 && unchangedPolls >= stuckPollThreshold {
+			return upstreamResponse{latencyMillis: pollLatencyMillis}, errSessionStuck
 		}
-		time.Sleep(500 * time.Millisecond)
+		time.Sleep(client.pollInterval)
 	}
 }
 
 // fetchResponseByID retrieves a response by identifier and reports whether the response is complete.
-func (client *OpenAIClient) fetchResponseByID(deadline time.Time, openAIKey string, responseIdentifier string, structuredLogger *zap.SugaredLogger) (string, bool, error) {
+func (client *OpenAIClient) fetchResponseByID(traceContext context.Context, deadline time.Time, openAIKey string, responseIdentifier string, structuredLogger *zap.SugaredLogger) (upstreamResponse, bool, error) {
 	resourceURL := client.endpoints.GetResponsesURL() + "/" + responseIdentifier
-	requestContext, cancel := context.WithDeadline(context.Background(), deadline)
+	requestContext, cancel := context.WithDeadline(traceContext, deadline)
 	defer cancel()
 
-	httpRequest, buildError := buildAuthorizedJSONRequest(requestContext, http.MethodGet, resourceURL, openAIKey, nil)
+	httpRequest, buildError := client.buildAuthorizedJSONRequest(requestContext, http.MethodGet, resourceURL, openAIKey, nil)
 	if buildError != nil {
-		return constants.EmptyString, false, buildError
+		return upstreamResponse{}, false, buildError
 	}
 
-	_, responseBytes, _, requestError := client.performResponsesRequest(httpRequest, structuredLogger, logEventOpenAIPollError)
+	_, responseBytes, latencyMillis, requestError := client.performResponsesRequest(httpRequest, structuredLogger, logEventOpenAIPollError)
 	if requestError != nil {
-		return constants.EmptyString, false, requestError
+		return upstreamResponse{}, false, requestError
 	}
 
 	structuredLogger.Debugw(
 		logEventOpenAIPollResponseBody,
 		logFieldID, responseIdentifier,
-		logFieldResponseBody, string(responseBytes),
+		logFieldResponseBody, string(redactJSONFields(responseBytes, client.logRedactedFields)),
 	)
 
 	var decodedObject map[string]any
 	_ = json.Unmarshal(responseBytes, &decodedObject)
+	if embeddedError := embeddedUpstreamError(decodedObject); embeddedError != nil {
+		return upstreamResponse{}, true, embeddedError
+	}
 	responseStatus := strings.ToLower(utils.GetString(decodedObject, jsonFieldStatus))
-	outputText := extractTextFromAny(responseBytes)
+	outputText, fallbackUsed := extractTextFromAny(responseBytes, client.extractionStrategy)
 
 	switch responseStatus {
-	case statusCompleted, statusSucceeded, statusDone:
-		return outputText, true, nil
+	case statusCompleted, statusSucceeded, statusDone, statusIncomplete:
+		return upstreamResponse{text: outputText, finishReason: extractFinishReason(decodedObject), latencyMillis: latencyMillis, fallbackUsed: fallbackUsed, usage: extractTokenUsage(decodedObject)}, true, nil
 	case statusCancelled, statusFailed, statusErrored:
-		return constants.EmptyString, true, errors.New(errorOpenAIFailedStatus)
+		return upstreamResponse{}, true, errors.New(errorOpenAIFailedStatus)
 	default:
-		return constants.EmptyString, false, nil
+		outputItems, _ := decodedObject[jsonFieldOutput].([]any)
+		sessionProgress := responseStatus + sessionProgressSeparator + strconv.Itoa(len(outputItems))
+		return upstreamResponse{latencyMillis: latencyMillis, sessionProgress: sessionProgress}, false, nil
+	}
+}
+
+// embeddedUpstreamError reports an error object returned inside a successful HTTP response, which some
+// OpenAI-compatible servers send instead of a non-2xx status. A null or absent error field yields nil.
+// The returned error wraps ErrUpstreamErrorObject and carries the upstream message when one is present.
+func embeddedUpstreamError(decodedObject map[string]any) error {
+	errorObject, isObject := decodedObject[jsonFieldError].(map[string]any)
+	if !isObject {
+		return nil
+	}
+	upstreamMessage := utils.GetString(errorObject, jsonFieldMessage)
+	if utils.IsBlank(upstreamMessage) {
+		upstreamMessage = utils.GetString(errorObject, jsonFieldCode)
+	}
+	if utils.IsBlank(upstreamMessage) {
+		return ErrUpstreamErrorObject
+	}
+	return fmt.Errorf(errorWrapWithDetailFormat, ErrUpstreamErrorObject, upstreamMessage)
+}
+
+// pollFailure converts a polling error into the error reported to the client, keeping upstream error details and
+// the incomplete response sentinel.
+func pollFailure(pollError error) error {
+	if errors.Is(pollError, ErrUpstreamErrorObject) || errors.Is(pollError, ErrUpstreamIncomplete) {
+		return pollError
+	}
+	return errors.New(errorOpenAIAPI)
+}
+
+// upstreamResponse holds the text extracted from a finished upstream response together with the reason generation
+// stopped and the cumulative latency of the upstream calls that produced it.
+type upstreamResponse struct {
+	text          string
+	finishReason  string
+	latencyMillis int64
+	// fallbackUsed reports that text was synthesized from the last web search because the model gave no answer.
+	fallbackUsed bool
+	// usage holds the token counts reported by the upstream, or nil when the response carried none.
+	usage *tokenUsage
+	// model identifies the model that produced the reply; blank when the caller did not record it.
+	model string
+	// responseID identifies the upstream response that produced text.
+	responseID string
+	// sessionProgress summarizes the status and output item count of a non-terminal response, so that polls of a
+	// session that stopped advancing can be recognized.
+	sessionProgress string
+}
+
+// extractFinishReason reports why generation stopped. Responses API payloads signal truncation through
+// incomplete_details.reason, which is normalized to the chat-completions vocabulary (length, content_filter);
+// a completed response yields stop. Chat-completions payloads report choices[0].finish_reason directly.
+func extractFinishReason(decodedObject map[string]any) string {
+	if incompleteDetails, isObject := decodedObject[jsonFieldIncompleteDetails].(map[string]any); isObject {
+		incompleteReason := utils.GetString(incompleteDetails, jsonFieldReason)
+		if incompleteReason == incompleteReasonMaxOutputTokens {
+			return finishReasonLength
+		}
+		if !utils.IsBlank(incompleteReason) {
+			return incompleteReason
+		}
 	}
+	if choices, isList := decodedObject[jsonFieldChoices].([]any); isList && len(choices) > 0 {
+		if firstChoice, isObject := choices[0].(map[string]any); isObject {
+			if choiceReason := utils.GetString(firstChoice, jsonFieldFinishReason); !utils.IsBlank(choiceReason) {
+				return choiceReason
+			}
+		}
+	}
+	switch strings.ToLower(utils.GetString(decodedObject, jsonFieldStatus)) {
+	case statusCompleted, statusSucceeded, statusDone:
+		return finishReasonStop
+	case statusIncomplete:
+		return finishReasonLength
+	}
+	return constants.EmptyString
 }
 
 // --- Final, Corrected Response Parser ---
@@ -422,39 +725,77 @@ func joinParts(parts []contentPart) string {
 	return builder.String()
 }
 
-// extractTextFromAny parses the final response from OpenAI.
-func extractTextFromAny(rawPayload []byte) string {
+// extractAssistantMessageText returns the joined text of the first assistant message in the output array.
+func extractAssistantMessageText(outputItems []json.RawMessage) string {
+	for _, rawItem := range outputItems {
+		var header struct {
+			Type string `json:"type"`
+			Role string `json:"role"`
+		}
+		if json.Unmarshal(rawItem, &header) == nil && header.Type == responseTypeMessage && header.Role == responseRoleAssistant {
+			var msgItem outputItem
+			if json.Unmarshal(rawItem, &msgItem) == nil {
+				return joinParts(msgItem.Content)
+			}
+		}
+	}
+	return constants.EmptyString
+}
+
+// extractChatCompletionText returns the message content of the first chat-completions choice. The content may be a
+// plain string or an array of text parts.
+func extractChatCompletionText(choices []json.RawMessage) string {
+	if len(choices) == 0 {
+		return constants.EmptyString
+	}
+	var firstChoice struct {
+		Message struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"message"`
+	}
+	if json.Unmarshal(choices[0], &firstChoice) != nil {
+		return constants.EmptyString
+	}
+	var contentText string
+	if json.Unmarshal(firstChoice.Message.Content, &contentText) == nil {
+		return contentText
+	}
+	var contentParts []contentPart
+	if json.Unmarshal(firstChoice.Message.Content, &contentParts) == nil {
+		return joinParts(contentParts)
+	}
+	return constants.EmptyString
+}
+
+// extractTextFromAny parses the final response from OpenAI. The strategy decides whether `output_text` or the
+// assistant message is tried first; ExtractionStrategyOutputTextFirst is used for any other value.
+// The boolean result reports that the text is the synthesized web search fallback rather than a model answer.
+func extractTextFromAny(rawPayload []byte, strategy string) (string, bool) {
 	var envelope struct {
 		OutputText string            `json:"output_text"`
 		Output     []json.RawMessage `json:"output"` // Use json.RawMessage for resilience
+		Choices    []json.RawMessage `json:"choices"`
 	}
 
 	if json.Unmarshal(rawPayload, &envelope) != nil {
-		return constants.EmptyString
+		return constants.EmptyString, false
 	}
 
-	// 1. Prioritize `output_text` as the most reliable source.
-	if !utils.IsBlank(envelope.OutputText) {
-		return envelope.OutputText
+	// 1. Prefer the source selected by the strategy, falling back to the other one.
+	preferredSources := []func() string{
+		func() string { return envelope.OutputText },
+		func() string { return extractAssistantMessageText(envelope.Output) },
 	}
-
-	// 2. If `output_text` is missing, parse the `output` array for the assistant's message.
-	if len(envelope.Output) > 0 {
-		for _, rawItem := range envelope.Output {
-			var header struct {
-				Type string `json:"type"`
-				Role string `json:"role"`
-			}
-			if json.Unmarshal(rawItem, &header) == nil && header.Type == responseTypeMessage && header.Role == responseRoleAssistant {
-				var msgItem outputItem
-				if json.Unmarshal(rawItem, &msgItem) == nil {
-					return joinParts(msgItem.Content)
-				}
-			}
+	if strategy == ExtractionStrategyMessageFirst {
+		slices.Reverse(preferredSources)
+	}
+	for _, source := range preferredSources {
+		if sourceText := source(); !utils.IsBlank(sourceText) {
+			return sourceText, false
 		}
 	}
 
-	// 3. If no message was found, create a fallback from the last tool call.
+	// 2. If no message was found, create a fallback from the last tool call.
 	if len(envelope.Output) > 0 {
 		lastQuery := constants.EmptyString
 		for outputIndex := len(envelope.Output) - 1; outputIndex >= 0; outputIndex-- {
@@ -473,42 +814,106 @@ func extractTextFromAny(rawPayload []byte) string {
 			}
 		}
 		if !utils.IsBlank(lastQuery) {
-			return fmt.Sprintf(fallbackFinalAnswerFormat, lastQuery)
+			return fmt.Sprintf(fallbackFinalAnswerFormat, lastQuery), true
 		}
 	}
 
-	return constants.EmptyString
+	// 3. Without any responses-shaped output, accept a chat-completions body from an OpenAI-compatible server.
+	return extractChatCompletionText(envelope.Choices), false
 }
 
 // --- HTTP and Helper Functions ---
+
+// performResponsesRequest sends httpRequest with retries inside a span that is a child of the span carried by the
+// request context, recording the final status code and latency.
 func (client *OpenAIClient) performResponsesRequest(httpRequest *http.Request, structuredLogger *zap.SugaredLogger, logEvent string) (int, []byte, int64, error) {
+	_, requestSpan := client.tracer.Start(httpRequest.Context(), spanNameUpstreamRequest, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String(spanAttributeHTTPMethod, httpRequest.Method),
+	))
+	statusCode, responseBytes, latencyMillis, requestError := client.performRetriedRequest(httpRequest, structuredLogger, logEvent)
+	requestSpan.SetAttributes(
+		attribute.Int(spanAttributeHTTPStatusCode, statusCode),
+		attribute.Int64(spanAttributeLatencyMilliseconds, latencyMillis),
+	)
+	endSpan(requestSpan, requestError)
+	return statusCode, responseBytes, latencyMillis, requestError
+}
+
+// performRetriedRequest sends httpRequest, retrying transport failures, server errors and rate limits with
+// exponential backoff, and records the outcome in the circuit breaker.
+func (client *OpenAIClient) performRetriedRequest(httpRequest *http.Request, structuredLogger *zap.SugaredLogger, logEvent string) (int, []byte, int64, error) {
+	allowed, probe := client.circuitBreaker.allow(time.Now())
+	if !allowed {
+		return 0, nil, 0, ErrUpstreamCircuitOpen
+	}
 	var statusCode int
 	var responseBytes []byte
 	var latencyMillis int64
+	var retryAfter string
+	sendRequest := func(request *http.Request) (*http.Response, error) {
+		response, sendError := client.httpClient.Do(request)
+		if sendError == nil {
+			retryAfter = response.Header.Get(headerRetryAfter)
+		}
+		return response, sendError
+	}
 	operation := func() error {
 		var transportError error
-		statusCode, responseBytes, latencyMillis, transportError = utils.PerformHTTPRequest(client.httpClient.Do, httpRequest, structuredLogger, logEvent)
+		statusCode, responseBytes, latencyMillis, transportError = utils.PerformHTTPRequest(sendRequest, httpRequest, client.backoffSettings, structuredLogger, logEvent)
 		if transportError != nil {
 			return transportError
 		}
 		// Retry on server errors (5xx) and rate limit errors (429).
-		if statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests {
+		if statusCode == http.StatusTooManyRequests {
+			return &upstreamRateLimitError{retryAfter: retryAfter}
+		}
+		if statusCode >= http.StatusInternalServerError {
 			return errors.New(errorOpenAIAPI)
 		}
 		return nil
 	}
-	retryStrategy := utils.AcquireExponentialBackoff()
+	retryStrategy := utils.AcquireExponentialBackoff(client.backoffSettings)
 	defer utils.ReleaseExponentialBackoff(retryStrategy)
-	retryError := backoff.Retry(operation, backoff.WithContext(retryStrategy, httpRequest.Context()))
+	var boundedStrategy backoff.BackOff = retryStrategy
+	if retryLimit := serverErrorRetryLimit(httpRequest.Context()); retryLimit > 0 {
+		boundedStrategy = backoff.WithMaxRetries(retryStrategy, uint64(retryLimit))
+	}
+	retryError := backoff.Retry(operation, backoff.WithContext(boundedStrategy, httpRequest.Context()))
+	switch {
+	case retryError == nil:
+		client.circuitBreaker.recordSuccess()
+	case errors.Is(retryError, context.Canceled):
+		if probe {
+			client.circuitBreaker.releaseProbe()
+		}
+	default:
+		client.circuitBreaker.recordFailure(time.Now(), probe)
+	}
 	return statusCode, responseBytes, latencyMillis, retryError
 }
 
-func buildAuthorizedJSONRequest(contextToUse context.Context, method string, resourceURL string, openAIKey string, body io.Reader) (*http.Request, error) {
+// buildAuthorizedJSONRequest creates an upstream request carrying the API key and, when configured, the organization
+// and project headers used to attribute usage on shared OpenAI accounts.
+func (client *OpenAIClient) buildAuthorizedJSONRequest(contextToUse context.Context, method string, resourceURL string, openAIKey string, body io.Reader) (*http.Request, error) {
 	httpReq, httpRequestError := http.NewRequestWithContext(contextToUse, method, resourceURL, body)
 	if httpRequestError != nil {
 		return nil, httpRequestError
 	}
-	httpReq.Header.Set(headerAuthorization, headerAuthorizationPrefix+openAIKey)
+	copyPassthroughHeaders(contextToUse, httpReq)
+	if utils.IsBlank(client.azureAPIVersion) {
+		httpReq.Header.Set(headerAuthorization, headerAuthorizationPrefix+openAIKey)
+	} else {
+		httpReq.Header.Set(headerAzureAPIKey, openAIKey)
+		queryValues := httpReq.URL.Query()
+		queryValues.Set(queryParameterAzureAPIVersion, client.azureAPIVersion)
+		httpReq.URL.RawQuery = queryValues.Encode()
+	}
+	if !utils.IsBlank(client.organization) {
+		httpReq.Header.Set(headerOpenAIOrganization, client.organization)
+	}
+	if !utils.IsBlank(client.project) {
+		httpReq.Header.Set(headerOpenAIProject, client.project)
+	}
 	if body != nil {
 		httpReq.Header.Set(headerContentType, mimeApplicationJSON)
 	}
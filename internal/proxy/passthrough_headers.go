@@ -0,0 +1,55 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// passthroughHeadersContextKey stores the inbound headers copied onto the upstream requests of a task.
+type passthroughHeadersContextKey struct{}
+
+// validatePassthroughHeaders rejects an allowlist naming the Authorization header, which always carries the
+// OpenAI key and must never be taken from the client.
+func validatePassthroughHeaders(headerNames []string) error {
+	for _, headerName := range headerNames {
+		if strings.EqualFold(strings.TrimSpace(headerName), headerAuthorization) {
+			return ErrInvalidPassthroughHeaders
+		}
+	}
+	return nil
+}
+
+// passthroughHeaders returns the values of the allowlisted headerNames present on inboundRequest, or nil when none
+// is present.
+func passthroughHeaders(inboundRequest *http.Request, headerNames []string) http.Header {
+	var copiedHeaders http.Header
+	for _, headerName := range headerNames {
+		canonicalName := http.CanonicalHeaderKey(strings.TrimSpace(headerName))
+		headerValues := inboundRequest.Header.Values(canonicalName)
+		if len(headerValues) == 0 || canonicalName == headerAuthorization {
+			continue
+		}
+		if copiedHeaders == nil {
+			copiedHeaders = make(http.Header)
+		}
+		copiedHeaders[canonicalName] = append([]string(nil), headerValues...)
+	}
+	return copiedHeaders
+}
+
+// withPassthroughHeaders returns parent carrying headers, so that every upstream request built from it copies them.
+func withPassthroughHeaders(parent context.Context, headers http.Header) context.Context {
+	if len(headers) == 0 {
+		return parent
+	}
+	return context.WithValue(parent, passthroughHeadersContextKey{}, headers)
+}
+
+// copyPassthroughHeaders sets the headers carried by requestContext on outboundRequest.
+func copyPassthroughHeaders(requestContext context.Context, outboundRequest *http.Request) {
+	headers, _ := requestContext.Value(passthroughHeadersContextKey{}).(http.Header)
+	for headerName, headerValues := range headers {
+		outboundRequest.Header[headerName] = append([]string(nil), headerValues...)
+	}
+}
@@ -0,0 +1,79 @@
+package proxy
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// pricingPath serves the operator-configured token prices of each model.
+	pricingPath = "/pricing"
+	// pricingUnitPerMillionTokens labels prices expressed per one million tokens.
+	pricingUnitPerMillionTokens = "per_million_tokens"
+	// tokensPerPricingUnit is the number of tokens a configured price covers.
+	tokensPerPricingUnit = 1_000_000
+	// headerEstimatedCost reports the estimated cost of a request in US dollars.
+	headerEstimatedCost = "X-Estimated-Cost-USD"
+	// estimatedCostPrecision is the number of decimal places reported in the estimated cost header.
+	estimatedCostPrecision = 6
+)
+
+// ModelPrice holds the operator-configured cost of one million input and output tokens of a model.
+type ModelPrice struct {
+	InputPerMillionTokens  float64
+	OutputPerMillionTokens float64
+}
+
+// pricingListing is the JSON document served by the pricing endpoint.
+type pricingListing struct {
+	Unit string         `json:"unit"`
+	Data []pricingEntry `json:"data"`
+}
+
+// pricingEntry describes the token prices of one model.
+type pricingEntry struct {
+	Model       string  `json:"model"`
+	InputPrice  float64 `json:"input"`
+	OutputPrice float64 `json:"output"`
+}
+
+// pricingHandler lists modelPricing sorted by model identifier. The prices are static operator data; clients combine
+// them with the X-Usage-* headers to estimate the cost of a request.
+func pricingHandler(modelPricing map[string]ModelPrice) gin.HandlerFunc {
+	listing := pricingListing{Unit: pricingUnitPerMillionTokens, Data: make([]pricingEntry, 0, len(modelPricing))}
+	for modelIdentifier, modelPrice := range modelPricing {
+		listing.Data = append(listing.Data, pricingEntry{
+			Model:       modelIdentifier,
+			InputPrice:  modelPrice.InputPerMillionTokens,
+			OutputPrice: modelPrice.OutputPerMillionTokens,
+		})
+	}
+	sort.Slice(listing.Data, func(leftIndex, rightIndex int) bool {
+		return listing.Data[leftIndex].Model < listing.Data[rightIndex].Model
+	})
+	return func(ginContext *gin.Context) {
+		ginContext.JSON(http.StatusOK, listing)
+	}
+}
+
+// estimateCost prices usage with the configured price of modelIdentifier. It reports false when the upstream sent no
+// usage or the model has no configured price.
+func estimateCost(usage *tokenUsage, modelIdentifier string, modelPricing map[string]ModelPrice) (float64, bool) {
+	modelPrice, priced := modelPricing[modelIdentifier]
+	if usage == nil || !priced {
+		return 0, false
+	}
+	inputCost := float64(usage.inputTokens) * modelPrice.InputPerMillionTokens / tokensPerPricingUnit
+	outputCost := float64(usage.outputTokens) * modelPrice.OutputPerMillionTokens / tokensPerPricingUnit
+	return inputCost + outputCost, true
+}
+
+// writeEstimatedCostHeader sets the X-Estimated-Cost-USD response header when the cost of the request can be estimated.
+func writeEstimatedCostHeader(ginContext *gin.Context, usage *tokenUsage, modelIdentifier string, modelPricing map[string]ModelPrice) {
+	if estimatedCost, estimated := estimateCost(usage, modelIdentifier, modelPricing); estimated {
+		ginContext.Header(headerEstimatedCost, strconv.FormatFloat(estimatedCost, 'f', estimatedCostPrecision, 64))
+	}
+}
@@ -0,0 +1,27 @@
+package proxy
+
+import (
+	"strings"
+
+	"github.com/temirov/llm-proxy/internal/constants"
+)
+
+// resolvePromptPrefix detects a configured directive such as "@fast:" at the start of userPrompt.
+// It returns the prompt with the directive removed, the mapped model identifier, and whether a directive matched.
+// When several directives match, the longest one wins. Unrecognized prefixes are left in the prompt.
+func resolvePromptPrefix(userPrompt string, prefixModels map[string]string) (string, string, bool) {
+	matchedPrefix := constants.EmptyString
+	for candidatePrefix := range prefixModels {
+		if candidatePrefix == constants.EmptyString || !strings.HasPrefix(userPrompt, candidatePrefix) {
+			continue
+		}
+		if len(candidatePrefix) > len(matchedPrefix) {
+			matchedPrefix = candidatePrefix
+		}
+	}
+	if matchedPrefix == constants.EmptyString {
+		return userPrompt, constants.EmptyString, false
+	}
+	strippedPrompt := strings.TrimSpace(strings.TrimPrefix(userPrompt, matchedPrefix))
+	return strippedPrompt, prefixModels[matchedPrefix], true
+}
@@ -0,0 +1,173 @@
+package proxy
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+)
+
+const (
+	// askFilePath accepts a multipart upload whose file becomes the prompt.
+	askFilePath = "/ask-file"
+	// formFieldPromptFile names the multipart file field holding the prompt.
+	formFieldPromptFile = "file"
+	// multipartOverheadBytes bounds the form fields and part headers accepted alongside the uploaded prompt.
+	multipartOverheadBytes = 1 << 20
+	// contextKeyUploadedPrompt carries the uploaded prompt from uploadedPromptMiddleware to requestPrompt.
+	contextKeyUploadedPrompt = "uploaded_prompt"
+	// contextKeyUploadForm carries the partly read upload from uploadedKeyMiddleware to uploadedPromptMiddleware.
+	contextKeyUploadForm = "upload_form"
+)
+
+// errUploadFieldsTooLarge reports form fields exceeding multipartOverheadBytes.
+var errUploadFieldsTooLarge = errors.New(errorInvalidRequestBody)
+
+// uploadForm is a multipart upload read part by part. pendingPart is the first file part, which is only read once
+// the request is authorized; fieldValues collects the form fields read so far.
+type uploadForm struct {
+	reader              *multipart.Reader
+	pendingPart         *multipart.Part
+	fieldValues         url.Values
+	remainingFieldBytes int64
+}
+
+// readFields reads form fields into fieldValues until the first file part, which is kept as pendingPart. It returns
+// io.EOF once the upload has no more parts.
+func (form *uploadForm) readFields() error {
+	for {
+		nextPart, partError := form.reader.NextPart()
+		if partError != nil {
+			return partError
+		}
+		if nextPart.FileName() != constants.EmptyString || nextPart.FormName() == formFieldPromptFile {
+			form.pendingPart = nextPart
+			return nil
+		}
+		fieldBytes, readError := io.ReadAll(io.LimitReader(nextPart, form.remainingFieldBytes+1))
+		if readError != nil {
+			return readError
+		}
+		if int64(len(fieldBytes)) > form.remainingFieldBytes {
+			return errUploadFieldsTooLarge
+		}
+		form.remainingFieldBytes -= int64(len(fieldBytes))
+		form.fieldValues.Add(nextPart.FormName(), string(fieldBytes))
+	}
+}
+
+// uploadedKeyMiddleware starts reading a multipart/form-data upload for the ask-file endpoint ahead of
+// authentication. When neither the Authorization header nor the key query parameter carries a client key, the form
+// fields preceding the first file part are read and a key field among them is copied to the query string, so a
+// client sending the key as a form field must place it before the file. No file content is read before the client
+// is authorized.
+func uploadedKeyMiddleware(maxPromptBytes int) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		if ginContext.ContentType() != gin.MIMEMultipartPOSTForm {
+			ginContext.String(http.StatusUnsupportedMediaType, errorUnsupportedUploadMediaType)
+			ginContext.Abort()
+			return
+		}
+		bodyLimit := int64(maxPromptBytes) + multipartOverheadBytes
+		ginContext.Request.Body = http.MaxBytesReader(ginContext.Writer, ginContext.Request.Body, bodyLimit)
+		multipartReader, readerError := ginContext.Request.MultipartReader()
+		if readerError != nil {
+			ginContext.String(http.StatusBadRequest, errorInvalidRequestBody)
+			ginContext.Abort()
+			return
+		}
+		form := &uploadForm{reader: multipartReader, fieldValues: make(url.Values), remainingFieldBytes: multipartOverheadBytes}
+		if presentedClientKey(ginContext) == constants.EmptyString {
+			if fieldsError := form.readFields(); fieldsError != nil && !errors.Is(fieldsError, io.EOF) {
+				abortUpload(ginContext, fieldsError)
+				return
+			}
+			if formKey := form.fieldValues.Get(queryParameterKey); formKey != constants.EmptyString {
+				mergedQuery := ginContext.Request.URL.Query()
+				mergedQuery.Set(queryParameterKey, formKey)
+				ginContext.Request.URL.RawQuery = mergedQuery.Encode()
+			}
+		}
+		ginContext.Set(contextKeyUploadForm, form)
+		ginContext.Next()
+	}
+}
+
+// uploadedPromptMiddleware finishes reading the upload started by uploadedKeyMiddleware once the client is
+// authorized. The file field becomes the prompt, and is rejected with 413 when it exceeds maxPromptBytes. The
+// remaining form fields are merged into the query string, replacing query parameters of the same name, so that
+// chatHandler reads them like the parameters of a regular request.
+func uploadedPromptMiddleware(maxPromptBytes int) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		form := ginContext.MustGet(contextKeyUploadForm).(*uploadForm)
+		var promptBytes []byte
+		for promptBytes == nil {
+			if form.pendingPart == nil {
+				if fieldsError := form.readFields(); errors.Is(fieldsError, io.EOF) {
+					ginContext.String(http.StatusBadRequest, errorMissingPromptFile)
+					ginContext.Abort()
+					return
+				} else if fieldsError != nil {
+					abortUpload(ginContext, fieldsError)
+					return
+				}
+			}
+			filePart := form.pendingPart
+			form.pendingPart = nil
+			if filePart.FormName() != formFieldPromptFile {
+				if _, discardError := io.Copy(io.Discard, filePart); discardError != nil {
+					abortUpload(ginContext, discardError)
+					return
+				}
+				continue
+			}
+			var readError error
+			promptBytes, readError = io.ReadAll(io.LimitReader(filePart, int64(maxPromptBytes)+1))
+			if readError != nil {
+				abortUpload(ginContext, readError)
+				return
+			}
+			if len(promptBytes) > maxPromptBytes {
+				ginContext.String(http.StatusRequestEntityTooLarge, errorPromptTooLarge)
+				ginContext.Abort()
+				return
+			}
+		}
+		for {
+			fieldsError := form.readFields()
+			if errors.Is(fieldsError, io.EOF) {
+				break
+			}
+			if fieldsError == nil {
+				_, fieldsError = io.Copy(io.Discard, form.pendingPart)
+				form.pendingPart = nil
+			}
+			if fieldsError != nil {
+				abortUpload(ginContext, fieldsError)
+				return
+			}
+		}
+		ginContext.Set(contextKeyUploadedPrompt, string(promptBytes))
+
+		mergedQuery := ginContext.Request.URL.Query()
+		for fieldName, fieldValues := range form.fieldValues {
+			mergedQuery[fieldName] = fieldValues
+		}
+		ginContext.Request.URL.RawQuery = mergedQuery.Encode()
+		ginContext.Next()
+	}
+}
+
+// abortUpload rejects an upload that could not be read: 413 when it exceeds the body limit and 400 otherwise.
+func abortUpload(ginContext *gin.Context, uploadError error) {
+	uploadStatus := unreadableBodyStatus(uploadError)
+	if errors.Is(uploadError, errUploadFieldsTooLarge) {
+		uploadStatus = http.StatusRequestEntityTooLarge
+	}
+	ginContext.String(uploadStatus, errorInvalidRequestBody)
+	ginContext.Abort()
+}
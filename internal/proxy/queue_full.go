@@ -0,0 +1,71 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/temirov/llm-proxy/internal/constants"
+	"gopkg.in/yaml.v3"
+)
+
+// queueFullTextFormat renders a queue-full report as plain text.
+const queueFullTextFormat = "%s; queue_length=%d queue_capacity=%d retry_after_seconds=%d"
+
+// queueFullCSVHeader names the columns of a queue-full report rendered as CSV.
+var queueFullCSVHeader = []string{"error", "queue_length", "queue_capacity", "retry_after_seconds"}
+
+// queueFullReport describes the saturation of the request queue when a request is rejected because it is full.
+type queueFullReport struct {
+	XMLName           xml.Name `json:"-" yaml:"-" xml:"queue_full"`
+	Error             string   `json:"error" yaml:"error" xml:"error"`
+	QueueLength       int      `json:"queue_length" yaml:"queue_length" xml:"queue_length"`
+	QueueCapacity     int      `json:"queue_capacity" yaml:"queue_capacity" xml:"queue_capacity"`
+	RetryAfterSeconds int      `json:"retry_after_seconds" yaml:"retry_after_seconds" xml:"retry_after_seconds"`
+}
+
+// newQueueFullReport captures the current length and capacity of taskQueue together with the suggested retry wait.
+func newQueueFullReport(taskQueue chan requestTask, retryAfter time.Duration) queueFullReport {
+	retryAfterWholeSeconds, _ := strconv.Atoi(retryAfterSeconds(retryAfter))
+	return queueFullReport{
+		Error:             errorQueueFull,
+		QueueLength:       len(taskQueue),
+		QueueCapacity:     cap(taskQueue),
+		RetryAfterSeconds: retryAfterWholeSeconds,
+	}
+}
+
+// encode renders the report in the response format selected by preferred and returns the body and content type.
+// Plain text requests and encoding failures get a single line of text.
+func (report queueFullReport) encode(preferred string) (string, string) {
+	var encodedBody []byte
+	var encodeError error
+	var contentType string
+	switch responseFormatOf(preferred) {
+	case ResponseFormatJSON:
+		encodedBody, encodeError = json.Marshal(report)
+		contentType = mimeApplicationJSON
+	case ResponseFormatXML:
+		encodedBody, encodeError = xml.Marshal(report)
+		contentType = mimeApplicationXML
+	case ResponseFormatYAML:
+		encodedBody, encodeError = yaml.Marshal(report)
+		contentType = mimeApplicationYAML
+	case ResponseFormatCSV:
+		var csvBuffer bytes.Buffer
+		csvWriter := csv.NewWriter(&csvBuffer)
+		_ = csvWriter.Write(queueFullCSVHeader)
+		_ = csvWriter.Write([]string{report.Error, strconv.Itoa(report.QueueLength), strconv.Itoa(report.QueueCapacity), strconv.Itoa(report.RetryAfterSeconds)})
+		csvWriter.Flush()
+		encodedBody, encodeError = csvBuffer.Bytes(), csvWriter.Error()
+		contentType = mimeTextCSV
+	}
+	if contentType == constants.EmptyString || encodeError != nil {
+		return fmt.Sprintf(queueFullTextFormat, report.Error, report.QueueLength, report.QueueCapacity, report.RetryAfterSeconds), mimeTextPlain
+	}
+	return string(encodedBody), contentType
+}
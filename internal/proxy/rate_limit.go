@@ -0,0 +1,132 @@
+package proxy
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimiterCleanupInterval is how often idle rate limiter buckets are discarded.
+const rateLimiterCleanupInterval = time.Minute
+
+// tokenBucket holds the tokens available to one key and when they were last refilled.
+type tokenBucket struct {
+	availableTokens float64
+	lastRefill      time.Time
+}
+
+// keyedRateLimiter applies an independent token bucket to every key, refilling ratePerSecond tokens per second up
+// to burst. Buckets are created on first use and discarded by removeIdle once they have refilled completely.
+type keyedRateLimiter struct {
+	accessMutex   sync.Mutex
+	ratePerSecond float64
+	burst         float64
+	buckets       map[string]*tokenBucket
+}
+
+// newKeyedRateLimiter returns a limiter allowing ratePerSecond requests per second per key with bursts up to burst.
+func newKeyedRateLimiter(ratePerSecond float64, burst int) *keyedRateLimiter {
+	return &keyedRateLimiter{ratePerSecond: ratePerSecond, burst: float64(burst), buckets: make(map[string]*tokenBucket)}
+}
+
+// allow consumes a token for key. When none is available it reports false and how long until the next token.
+func (limiter *keyedRateLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	limiter.accessMutex.Lock()
+	defer limiter.accessMutex.Unlock()
+	bucket, known := limiter.buckets[key]
+	if !known {
+		bucket = &tokenBucket{availableTokens: limiter.burst, lastRefill: now}
+		limiter.buckets[key] = bucket
+	}
+	limiter.refill(bucket, now)
+	if bucket.availableTokens >= 1 {
+		bucket.availableTokens--
+		return true, 0
+	}
+	missingTokens := 1 - bucket.availableTokens
+	return false, time.Duration(missingTokens / limiter.ratePerSecond * float64(time.Second))
+}
+
+// refill adds the tokens earned since the bucket was last refilled, capped at the burst size.
+func (limiter *keyedRateLimiter) refill(bucket *tokenBucket, now time.Time) {
+	elapsedSeconds := now.Sub(bucket.lastRefill).Seconds()
+	if elapsedSeconds > 0 {
+		bucket.availableTokens = math.Min(limiter.burst, bucket.availableTokens+elapsedSeconds*limiter.ratePerSecond)
+		bucket.lastRefill = now
+	}
+}
+
+// removeIdle discards the buckets that have refilled completely, since a fresh bucket behaves identically.
+func (limiter *keyedRateLimiter) removeIdle(now time.Time) {
+	limiter.accessMutex.Lock()
+	defer limiter.accessMutex.Unlock()
+	for key, bucket := range limiter.buckets {
+		limiter.refill(bucket, now)
+		if bucket.availableTokens >= limiter.burst {
+			delete(limiter.buckets, key)
+		}
+	}
+}
+
+// startCleanup removes idle buckets every interval for the lifetime of the process.
+func (limiter *keyedRateLimiter) startCleanup(interval time.Duration) {
+	go func() {
+		cleanupTicker := time.NewTicker(interval)
+		defer cleanupTicker.Stop()
+		for tickTime := range cleanupTicker.C {
+			limiter.removeIdle(tickTime)
+		}
+	}()
+}
+
+// retryAfterSeconds converts a wait into the whole number of seconds reported in a Retry-After header.
+func retryAfterSeconds(wait time.Duration) string {
+	return strconv.Itoa(max(1, int(math.Ceil(wait.Seconds()))))
+}
+
+// rateLimitMiddleware rejects requests from a client address that has exhausted its token bucket with 429 and a
+// Retry-After header naming the seconds until the next token.
+func rateLimitMiddleware(limiter *keyedRateLimiter) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		allowed, wait := limiter.allow(ginContext.ClientIP(), time.Now())
+		if !allowed {
+			ginContext.Header(headerRetryAfter, retryAfterSeconds(wait))
+			ginContext.String(http.StatusTooManyRequests, errorRateLimited)
+			ginContext.Abort()
+			return
+		}
+		ginContext.Next()
+	}
+}
+
+// modelRateLimiter throttles requests per resolved model, each model having its own requests-per-minute budget.
+// Models without a configured limit are never throttled.
+type modelRateLimiter struct {
+	limiters map[string]*keyedRateLimiter
+}
+
+// newModelRateLimiter builds a limiter from requests-per-minute limits keyed by model; non-positive limits are skipped.
+// A model may spend its whole minute's budget at once, after which tokens return evenly over the minute.
+func newModelRateLimiter(requestsPerMinute map[string]int) *modelRateLimiter {
+	limiters := make(map[string]*keyedRateLimiter)
+	for modelIdentifier, modelLimit := range requestsPerMinute {
+		if modelLimit > 0 {
+			limiters[modelIdentifier] = newKeyedRateLimiter(float64(modelLimit)/time.Minute.Seconds(), modelLimit)
+		}
+	}
+	return &modelRateLimiter{limiters: limiters}
+}
+
+// allow consumes a request from the budget of modelIdentifier. When it is exhausted it reports false and how long until
+// the model accepts another request.
+func (limiter *modelRateLimiter) allow(modelIdentifier string, now time.Time) (bool, time.Duration) {
+	modelLimiter, limited := limiter.limiters[modelIdentifier]
+	if !limited {
+		return true, 0
+	}
+	return modelLimiter.allow(modelIdentifier, now)
+}
@@ -0,0 +1,89 @@
+package proxy
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/utils"
+)
+
+const (
+	// recentPath serves the summaries of the most recent requests.
+	recentPath = "/recent"
+	// jsonFieldRequests holds the request summaries returned by the recent endpoint.
+	jsonFieldRequests = "requests"
+)
+
+// recentRequestSummary describes one completed request without its prompt or response content.
+type recentRequestSummary struct {
+	Timestamp         string `json:"timestamp"`
+	Model             string `json:"model"`
+	Status            int    `json:"status"`
+	LatencyMillis     int64  `json:"latency_ms"`
+	ClientFingerprint string `json:"client_fingerprint"`
+}
+
+// recentRequestBuffer keeps the latest request summaries in a fixed-size ring, overwriting the oldest entry once full.
+type recentRequestBuffer struct {
+	accessMutex sync.Mutex
+	entries     []recentRequestSummary
+	nextIndex   int
+	filled      bool
+}
+
+// newRecentRequestBuffer returns a ring buffer holding up to capacity summaries.
+func newRecentRequestBuffer(capacity int) *recentRequestBuffer {
+	return &recentRequestBuffer{entries: make([]recentRequestSummary, capacity)}
+}
+
+// record stores summary, replacing the oldest entry when the buffer is full.
+func (buffer *recentRequestBuffer) record(summary recentRequestSummary) {
+	buffer.accessMutex.Lock()
+	defer buffer.accessMutex.Unlock()
+	buffer.entries[buffer.nextIndex] = summary
+	buffer.nextIndex = (buffer.nextIndex + 1) % len(buffer.entries)
+	if buffer.nextIndex == 0 {
+		buffer.filled = true
+	}
+}
+
+// snapshot returns the stored summaries, most recent first.
+func (buffer *recentRequestBuffer) snapshot() []recentRequestSummary {
+	buffer.accessMutex.Lock()
+	defer buffer.accessMutex.Unlock()
+	storedCount := buffer.nextIndex
+	if buffer.filled {
+		storedCount = len(buffer.entries)
+	}
+	summaries := make([]recentRequestSummary, 0, storedCount)
+	for offset := 1; offset <= storedCount; offset++ {
+		entryIndex := (buffer.nextIndex - offset + len(buffer.entries)) % len(buffer.entries)
+		summaries = append(summaries, buffer.entries[entryIndex])
+	}
+	return summaries
+}
+
+// recentRequestsMiddleware records a summary of every request it wraps once the handler has finished.
+// The model comes from the audit context published by chatHandler and is empty for requests rejected earlier.
+func recentRequestsMiddleware(buffer *recentRequestBuffer) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		requestStart := time.Now()
+		ginContext.Next()
+		buffer.record(recentRequestSummary{
+			Timestamp:         requestStart.UTC().Format(auditTimestampLayout),
+			Model:             ginContext.GetString(contextKeyAuditModel),
+			Status:            ginContext.Writer.Status(),
+			LatencyMillis:     time.Since(requestStart).Milliseconds(),
+			ClientFingerprint: utils.Fingerprint(ginContext.ClientIP()),
+		})
+	}
+}
+
+// recentRequestsHandler returns the buffered request summaries as JSON, most recent first.
+func recentRequestsHandler(buffer *recentRequestBuffer) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		ginContext.JSON(http.StatusOK, gin.H{jsonFieldRequests: buffer.snapshot()})
+	}
+}
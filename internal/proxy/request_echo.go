@@ -0,0 +1,41 @@
+package proxy
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/utils"
+)
+
+// requestEcho describes how the proxy interpreted a request. It is attached to JSON responses when the client
+// sets debug_echo and the proxy runs at the debug log level.
+type requestEcho struct {
+	Model                   string   `json:"model"`
+	WebSearch               bool     `json:"web_search"`
+	SystemPromptFingerprint string   `json:"system_prompt_fingerprint"`
+	Format                  string   `json:"format"`
+	Overrides               []string `json:"overrides"`
+}
+
+// newRequestEcho returns a populated requestEcho when echoing is requested and permitted, or nil otherwise.
+// appliedOverrides names the request parameters or directives that replaced configured defaults.
+func newRequestEcho(ginContext *gin.Context, logLevel string, modelIdentifier string, webSearchEnabled bool, systemPrompt string, format string, appliedOverrides []string) *requestEcho {
+	if strings.ToLower(logLevel) != LogLevelDebug {
+		return nil
+	}
+	echoRequested, parseError := strconv.ParseBool(strings.TrimSpace(ginContext.Query(queryParameterDebugEcho)))
+	if parseError != nil || !echoRequested {
+		return nil
+	}
+	if appliedOverrides == nil {
+		appliedOverrides = []string{}
+	}
+	return &requestEcho{
+		Model:                   modelIdentifier,
+		WebSearch:               webSearchEnabled,
+		SystemPromptFingerprint: utils.Fingerprint(systemPrompt),
+		Format:                  format,
+		Overrides:               appliedOverrides,
+	}
+}
@@ -0,0 +1,55 @@
+package proxy
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"go.uber.org/zap"
+)
+
+const (
+	// requestIDByteLength is the number of random bytes in a generated request id.
+	requestIDByteLength = 16
+	// contextKeyRequestID stores the correlation id of the request in the gin context.
+	contextKeyRequestID = "request_id"
+)
+
+// clientRequestIDPattern matches the client-supplied correlation ids the proxy accepts; other values are replaced so
+// that they cannot inject content into logs or response headers.
+var clientRequestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// newRequestID returns a random version 4 UUID used as the correlation id of a single proxied request.
+func newRequestID() string {
+	randomBytes := make([]byte, requestIDByteLength)
+	_, _ = rand.Read(randomBytes)
+	randomBytes[6] = randomBytes[6]&0x0f | 0x40
+	randomBytes[8] = randomBytes[8]&0x3f | 0x80
+	encoded := hex.EncodeToString(randomBytes)
+	return encoded[0:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:32]
+}
+
+// requestIDMiddleware assigns every request a correlation id, taken from the X-Request-ID header when the client
+// supplies a well-formed one and generated otherwise, stores it in the gin context and echoes it in the response.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		requestID := ginContext.GetHeader(headerRequestID)
+		if !clientRequestIDPattern.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+		ginContext.Set(contextKeyRequestID, requestID)
+		ginContext.Header(headerRequestID, requestID)
+		ginContext.Next()
+	}
+}
+
+// requestLogger returns structuredLogger annotated with the correlation id of requestID, or structuredLogger itself
+// when no id was assigned.
+func requestLogger(structuredLogger *zap.SugaredLogger, requestID string) *zap.SugaredLogger {
+	if requestID == constants.EmptyString {
+		return structuredLogger
+	}
+	return structuredLogger.With(logFieldRequestID, requestID)
+}
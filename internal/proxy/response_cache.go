@@ -0,0 +1,111 @@
+package proxy
+
+import (
+	"container/list"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/utils"
+)
+
+// responseCacheKeySeparator separates the request fields hashed into a cache key.
+const responseCacheKeySeparator = "\x00"
+
+// responseCacheEntry is one cached answer together with its key and expiry.
+type responseCacheEntry struct {
+	key       string
+	response  upstreamResponse
+	expiresAt time.Time
+}
+
+// responseCache is a least-recently-used cache of upstream answers whose entries expire after a fixed time to live.
+type responseCache struct {
+	accessMutex sync.Mutex
+	maxEntries  int
+	timeToLive  time.Duration
+	recency     *list.List
+	entries     map[string]*list.Element
+}
+
+// newResponseCache returns a cache holding up to maxEntries answers, each for at most timeToLive.
+func newResponseCache(maxEntries int, timeToLive time.Duration) *responseCache {
+	return &responseCache{
+		maxEntries: maxEntries,
+		timeToLive: timeToLive,
+		recency:    list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// responseCacheKey hashes the request fields that shape the upstream answer. The correlation id is left out because
+// it does not change the answer.
+func responseCacheKey(task requestTask) string {
+	temperature := constants.EmptyString
+	if task.temperature != nil {
+		temperature = strconv.FormatFloat(*task.temperature, 'g', -1, 64)
+	}
+	searchLocation := constants.EmptyString
+	if task.searchLocation != nil {
+		searchLocation = task.searchLocation.Country + searchLocationSeparator + task.searchLocation.City
+	}
+	seed := constants.EmptyString
+	if task.seed != nil {
+		seed = strconv.FormatInt(*task.seed, 10)
+	}
+	return utils.ContentHash(strings.Join([]string{
+		task.model,
+		task.systemPrompt,
+		task.prompt,
+		strconv.FormatBool(task.webSearchEnabled),
+		strings.Join(task.tools, toolListSeparator),
+		searchLocation,
+		task.searchContextSize,
+		strconv.Itoa(task.maxOutputTokens),
+		temperature,
+		task.reasoningEffort,
+		task.responseSchema,
+		seed,
+		task.previousResponseID,
+	}, responseCacheKeySeparator))
+}
+
+// get returns the cached answer for key when it exists and has not expired, marking it as recently used.
+func (cache *responseCache) get(key string, now time.Time) (upstreamResponse, bool) {
+	cache.accessMutex.Lock()
+	defer cache.accessMutex.Unlock()
+	element, found := cache.entries[key]
+	if !found {
+		return upstreamResponse{}, false
+	}
+	entry := element.Value.(*responseCacheEntry)
+	if now.After(entry.expiresAt) {
+		cache.recency.Remove(element)
+		delete(cache.entries, key)
+		return upstreamResponse{}, false
+	}
+	cache.recency.MoveToFront(element)
+	return entry.response, true
+}
+
+// put stores response under key, evicting the least recently used answer when the cache is full.
+func (cache *responseCache) put(key string, response upstreamResponse, now time.Time) {
+	cache.accessMutex.Lock()
+	defer cache.accessMutex.Unlock()
+	expiresAt := now.Add(cache.timeToLive)
+	if element, found := cache.entries[key]; found {
+		entry := element.Value.(*responseCacheEntry)
+		entry.response = response
+		entry.expiresAt = expiresAt
+		cache.recency.MoveToFront(element)
+		return
+	}
+	cache.entries[key] = cache.recency.PushFront(&responseCacheEntry{key: key, response: response, expiresAt: expiresAt})
+	for cache.recency.Len() > cache.maxEntries {
+		oldestElement := cache.recency.Back()
+		cache.recency.Remove(oldestElement)
+		delete(cache.entries, oldestElement.Value.(*responseCacheEntry).key)
+	}
+}
@@ -1,23 +1,54 @@
 package proxy
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/buildinfo"
 	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/utils"
 	"go.uber.org/zap"
 )
 
+// queueFullRetryAfter is the wait suggested to clients rejected because the request queue is full.
+const queueFullRetryAfter = 5 * time.Second
+
+// previousResponseIDPattern matches the upstream response ids a request may continue from.
+var previousResponseIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
 // result holds the outcome returned by a worker, including the text response
 // and any error encountered during the OpenAI request.
 type result struct {
-	text         string
+	text string
+	// finishReason reports why the model stopped generating, such as stop or length.
+	finishReason string
+	// candidates holds every candidate answer, in order, when more than one was requested; text then joins them.
+	candidates []string
+	// upstreamLatencyMillis is the cumulative latency of the upstream calls made for the request.
+	upstreamLatencyMillis int64
+	// queueWaitMillis is the time the task waited between being enqueued and a worker picking it up.
+	queueWaitMillis int64
+	// fallbackUsed reports that text is the synthesized web search fallback rather than a model answer.
+	fallbackUsed bool
+	// usage holds the token counts reported by the upstream, or nil when none were reported.
+	usage *tokenUsage
+	// servedModel identifies the model that produced text when it may differ from the requested model.
+	servedModel string
+	// responseID identifies the upstream response that produced text, so that a later turn can continue from it.
+	responseID   string
 	requestError error
 }
 
@@ -28,23 +59,125 @@ type requestTask struct {
 	systemPrompt     string
 	model            string
 	webSearchEnabled bool
-	reply            chan result
+	// tools lists further hosted tool types attached to the upstream request.
+	tools []string
+	// searchLocation narrows the results of the web_search tool when set.
+	searchLocation *UserLocation
+	// searchContextSize selects the search context of the web_search tool when set.
+	searchContextSize string
+	// maxOutputTokens overrides the configured output token limit when positive.
+	maxOutputTokens int
+	// temperature overrides the default sampling temperature when set.
+	temperature *float64
+	// reasoningEffort overrides the default reasoning effort when set.
+	reasoningEffort string
+	// responseSchema holds the JSON schema constraining the answer when structured output was requested.
+	responseSchema string
+	// seed requests deterministic sampling when set.
+	seed *int64
+	// candidateCount is the number of candidate answers requested; zero or one requests a single answer.
+	candidateCount int
+	// previousResponseID continues the conversation of an earlier upstream response when set.
+	previousResponseID string
+	// requestID correlates the log events of the request.
+	requestID string
+	// sendRequestID attaches requestID to the upstream metadata.
+	sendRequestID bool
+	// streamDeltas receives output text increments when the client requested streaming; nil otherwise.
+	// The worker closes it before replying.
+	streamDeltas chan string
+	// streamContext ends when the streaming client goes away.
+	streamContext context.Context
+	// traceContext carries the request span that upstream spans join; nil starts them as new traces.
+	traceContext context.Context
+	// passthroughHeaders holds the allowlisted inbound headers copied onto the upstream requests.
+	passthroughHeaders http.Header
+	// enqueuedAt records when the task entered the queue.
+	enqueuedAt time.Time
+	reply      chan result
+}
+
+// payloadOptions returns the upstream payload options requested by the task.
+func (task requestTask) payloadOptions() RequestPayloadOptions {
+	options := RequestPayloadOptions{
+		WebSearchEnabled:   task.webSearchEnabled,
+		Tools:              task.tools,
+		SearchLocation:     task.searchLocation,
+		SearchContextSize:  task.searchContextSize,
+		MaxOutputTokens:    task.maxOutputTokens,
+		Temperature:        task.temperature,
+		ReasoningEffort:    task.reasoningEffort,
+		Seed:               task.seed,
+		PreviousResponseID: task.previousResponseID,
+	}
+	if task.responseSchema != constants.EmptyString {
+		options.ResponseSchema = json.RawMessage(task.responseSchema)
+	}
+	if task.sendRequestID && task.requestID != constants.EmptyString {
+		options.Metadata = map[string]string{metadataKeyProxyRequestID: task.requestID}
+	}
+	return options
+}
+
+// upstreamTraceContext returns the context whose span parents the upstream spans of the task. It carries the
+// passthrough headers of the task.
+func (task requestTask) upstreamTraceContext() context.Context {
+	if task.traceContext == nil {
+		return withPassthroughHeaders(context.Background(), task.passthroughHeaders)
+	}
+	return withPassthroughHeaders(task.traceContext, task.passthroughHeaders)
 }
 
 // BuildRouter constructs the HTTP router used by the proxy. configuration supplies queue sizes, worker counts, timeout values, API credentials and other settings. structuredLogger records structured log messages during routing.
 func BuildRouter(configuration Configuration, structuredLogger *zap.SugaredLogger) (*gin.Engine, error) {
+	router, _, buildError := buildRouterWithWorkers(configuration, structuredLogger)
+	return router, buildError
+}
+
+// buildRouterWithWorkers constructs the HTTP router as BuildRouter does and also returns the worker pool serving its
+// task queue, so that a graceful shutdown can wait for queued work.
+func buildRouterWithWorkers(configuration Configuration, structuredLogger *zap.SugaredLogger) (*gin.Engine, *workerPool, error) {
 	if validationError := validateConfig(configuration); validationError != nil {
-		return nil, validationError
+		return nil, nil, validationError
 	}
 
 	configuration.ApplyTunables()
 	if configuration.Endpoints == nil {
 		configuration.Endpoints = NewEndpoints()
+		if configuration.Provider == ProviderAzure {
+			azureEndpoints, azureError := NewAzureEndpoints(configuration.OpenAIBaseURL)
+			if azureError != nil {
+				return nil, nil, azureError
+			}
+			configuration.Endpoints = azureEndpoints
+		} else if !utils.IsBlank(configuration.OpenAIBaseURL) {
+			baseURLEndpoints, baseURLError := NewEndpointsFromBaseURL(configuration.OpenAIBaseURL)
+			if baseURLError != nil {
+				return nil, nil, baseURLError
+			}
+			configuration.Endpoints = baseURLEndpoints
+		}
 	}
 
-	validator, validatorError := newModelValidator()
+	validator, validatorError := newModelValidator(configuration.SkipModelValidation)
+	modelsLoadedAt := time.Now()
 	if validatorError != nil {
-		return nil, validatorError
+		return nil, nil, validatorError
+	}
+	if defaultModelError := validator.Verify(configuration.DefaultModel); defaultModelError != nil {
+		return nil, nil, defaultModelError
+	}
+	for _, fallbackModel := range configuration.FallbackModels {
+		if fallbackModelError := validator.Verify(fallbackModel); fallbackModelError != nil {
+			return nil, nil, fallbackModelError
+		}
+	}
+	for _, modelPool := range configuration.ModelPools {
+		for _, pooledModel := range modelPool.Models {
+			if pooledModelError := validator.Verify(resolveModelAlias(pooledModel, configuration.ModelAliases)); pooledModelError != nil {
+				return nil, nil, pooledModelError
+			}
+		}
 	}
 
 	if strings.ToLower(configuration.LogLevel) == LogLevelDebug {
@@ -54,73 +187,329 @@ func BuildRouter(configuration Configuration, structuredLogger *zap.SugaredLogge
 	}
 
 	router := gin.New()
+	if trustError := router.SetTrustedProxies(configuration.TrustedProxies); trustError != nil {
+		return nil, nil, trustError
+	}
+	router.Use(requestIDMiddleware())
+	if !utils.IsBlank(configuration.AuditLogPath) {
+		auditLogger, auditLoggerError := newAuditLogger(configuration.AuditLogPath)
+		if auditLoggerError != nil {
+			return nil, nil, auditLoggerError
+		}
+		router.Use(auditMiddleware(auditLogger))
+	}
 	if normalizedLogLevel := strings.ToLower(configuration.LogLevel); normalizedLogLevel == LogLevelInfo || normalizedLogLevel == LogLevelDebug {
-		router.Use(requestResponseLogger(structuredLogger))
+		if configuration.AccessLogFormat == AccessLogFormatCLF {
+			router.Use(commonLogFormatLogger(AccessLogWriter, configuration.RedactPrompts))
+		} else {
+			router.Use(requestResponseLogger(structuredLogger, configuration.RedactPrompts))
+		}
 	}
 
-	taskQueue := make(chan requestTask, configuration.QueueSize)
+	taskQueues := newModelQueues(configuration.QueueSize, configuration.ModelPools, configuration.ModelAliases)
 	requestTimeout := time.Duration(configuration.RequestTimeoutSeconds) * time.Second
 	pollTimeout := time.Duration(configuration.UpstreamPollTimeoutSeconds) * time.Second
-	openAIClient := NewOpenAIClient(HTTPClient, configuration.Endpoints, requestTimeout, configuration.MaxOutputTokens, pollTimeout)
-	for workerIndex := 0; workerIndex < configuration.WorkerCount; workerIndex++ {
-		go func() {
-			for pending := range taskQueue {
-				text, requestError := openAIClient.openAIRequest(
+	pollInterval := time.Duration(DefaultPollIntervalMillis) * time.Millisecond
+	if configuration.PollIntervalMillis > 0 {
+		pollInterval = time.Duration(configuration.PollIntervalMillis) * time.Millisecond
+	}
+	openAIClient := NewOpenAIClient(HTTPClient, configuration.Endpoints, requestTimeout, configuration.MaxOutputTokens, pollTimeout, pollInterval)
+	openAIClient.promptPrefix = configuration.PromptPrefix
+	openAIClient.promptSuffix = configuration.PromptSuffix
+	openAIClient.modelOutputTokenCeilings = configuration.ModelOutputTokenCeilings
+	openAIClient.logRedactedFields = logRedactionPaths(configuration.LogRedactedFields, configuration.RedactPrompts)
+	openAIClient.retryOnLengthTruncation = configuration.RetryOnLengthTruncation
+	openAIClient.retryOnParseFailure = configuration.RetryOnParseFailure
+	openAIClient.synthesisBudgetFraction = configuration.SynthesisBudgetFraction
+	openAIClient.extractionStrategy = configuration.ExtractionStrategy
+	openAIClient.organization = configuration.OpenAIOrganization
+	openAIClient.project = configuration.OpenAIProject
+	if configuration.Provider == ProviderAzure {
+		openAIClient.azureAPIVersion = configuration.AzureAPIVersion
+	}
+	openAIClient.backoffSettings = utils.BackoffSettings{
+		InitialInterval: time.Duration(configuration.RetryInitialIntervalMilliseconds) * time.Millisecond,
+		Multiplier:      configuration.RetryMultiplier,
+		MaxElapsedTime:  time.Duration(configuration.RetryMaxElapsedMilliseconds) * time.Millisecond,
+	}
+	openAIClient.stuckSessionPollThreshold = configuration.StuckSessionPollThreshold
+	openAIClient.tracer = newTracer(configuration.TracerProvider)
+	openAIClient.circuitBreaker = newCircuitBreaker(
+		configuration.CircuitBreakerFailureThreshold,
+		time.Duration(configuration.CircuitBreakerWindowSeconds)*time.Second,
+		time.Duration(configuration.CircuitBreakerCooldownSeconds)*time.Second,
+	)
+	if configuration.WarmupEnabled {
+		startupTimeout := time.Duration(configuration.StartupTimeoutSeconds) * time.Second
+		if warmupError := warmUpstream(openAIClient, configuration.OpenAIKey, configuration.DefaultModel, startupTimeout, structuredLogger); warmupError != nil && configuration.WarmupFailureFatal {
+			return nil, nil, warmupError
+		}
+	}
+	var answerCache *responseCache
+	if configuration.ResponseCacheSize > 0 {
+		answerCache = newResponseCache(configuration.ResponseCacheSize, time.Duration(configuration.ResponseCacheTTLSeconds)*time.Second)
+	}
+	taskPayloadOptions := func(pending requestTask) RequestPayloadOptions {
+		options := pending.payloadOptions()
+		if configuration.DegradeReasoningUnderLoad && modelAllowsRequestField(pending.model, keyReasoning) {
+			if queueDepth := len(taskQueues.queueFor(pending.model)); queueDepth > configuration.DegradeReasoningQueueDepth {
+				options.ReasoningEffort = degradedReasoningEffort(options.ReasoningEffort)
+				structuredLogger.Debugw(logEventReasoningDegraded, logFieldModel, pending.model, logFieldQueueDepth, queueDepth, logFieldReasoningEffort, options.ReasoningEffort)
+			}
+		}
+		return options
+	}
+	processTask := func(pending requestTask) {
+		taskLogger := requestLogger(structuredLogger, pending.requestID)
+		queueWaitMillis := int64(0)
+		if !pending.enqueuedAt.IsZero() {
+			queueWaitMillis = time.Since(pending.enqueuedAt).Milliseconds()
+		}
+		if pending.streamDeltas != nil {
+			upstreamReply, requestError := openAIClient.streamRequest(
+				withPassthroughHeaders(pending.streamContext, pending.passthroughHeaders),
+				configuration.OpenAIKey,
+				pending.model,
+				pending.prompt,
+				pending.systemPrompt,
+				taskPayloadOptions(pending),
+				forwardStreamDelta(pending),
+				taskLogger,
+			)
+			close(pending.streamDeltas)
+			pending.reply <- result{text: upstreamReply.text, finishReason: upstreamReply.finishReason, upstreamLatencyMillis: upstreamReply.latencyMillis, queueWaitMillis: queueWaitMillis, requestError: requestError}
+			return
+		}
+		cacheable := answerCache != nil && pending.candidateCount <= 1
+		cacheKey := constants.EmptyString
+		if cacheable {
+			cacheKey = responseCacheKey(pending)
+			if cachedReply, cached := answerCache.get(cacheKey, time.Now()); cached {
+				taskLogger.Debugw(logEventResponseCacheHit, logFieldModel, pending.model)
+				pending.reply <- result{text: cachedReply.text, finishReason: cachedReply.finishReason, queueWaitMillis: queueWaitMillis, fallbackUsed: cachedReply.fallbackUsed, servedModel: cachedReply.model, responseID: cachedReply.responseID}
+				return
+			}
+		}
+		upstreamReply, candidateTexts, requestError := completeCandidates(pending.candidateCount, func() (upstreamResponse, error) {
+			return completeWithFallbackModels(func(modelIdentifier string, retryLimit int) (upstreamResponse, error) {
+				return openAIClient.completeRequest(
+					withServerErrorRetryLimit(pending.upstreamTraceContext(), retryLimit),
 					configuration.OpenAIKey,
-					pending.model,
+					modelIdentifier,
 					pending.prompt,
 					pending.systemPrompt,
-					pending.webSearchEnabled,
-					structuredLogger,
+					taskPayloadOptions(pending),
+					taskLogger,
 				)
-				pending.reply <- result{text: text, requestError: requestError}
-			}
-		}()
+			}, pending.model, configuration.FallbackModels, taskLogger)
+		})
+		if cacheable && requestError == nil && !utils.IsBlank(upstreamReply.text) && upstreamReply.model == pending.model {
+			answerCache.put(cacheKey, upstreamReply, time.Now())
+		}
+		if candidateTexts != nil {
+			upstreamReply.text = strings.Join(candidateTexts, candidateSeparator)
+		}
+		pending.reply <- result{text: upstreamReply.text, candidates: candidateTexts, finishReason: upstreamReply.finishReason, upstreamLatencyMillis: upstreamReply.latencyMillis, queueWaitMillis: queueWaitMillis, fallbackUsed: upstreamReply.fallbackUsed, usage: upstreamReply.usage, servedModel: upstreamReply.model, responseID: upstreamReply.responseID, requestError: requestError}
+	}
+	workers := taskQueues.startWorkers(configuration.WorkerCount, configuration.ModelPools, configuration.ModelAliases, processTask)
+
+	var overflowQueue *diskOverflowQueue
+	if !utils.IsBlank(configuration.DiskQueuePath) {
+		var overflowError error
+		overflowQueue, overflowError = newDiskOverflowQueue(configuration.DiskQueuePath, configuration.DiskQueueMaxEntries, taskQueues)
+		if overflowError != nil {
+			return nil, nil, overflowError
+		}
+		overflowQueue.startReplay(workers.stopSignal, structuredLogger)
 	}
 
-	router.Use(gin.Recovery(), secretMiddleware(configuration.ServiceSecret, structuredLogger))
-	router.GET(rootPath, chatHandler(taskQueue, configuration.SystemPrompt, validator, requestTimeout, structuredLogger))
-	return router, nil
+	router.Use(gin.Recovery())
+	if configuration.TracerProvider != nil {
+		router.Use(tracingMiddleware(openAIClient.tracer))
+	}
+	if configuration.ExposeVersionHeader {
+		router.Use(versionHeaderMiddleware(buildinfo.Version()))
+	}
+	if configuration.RateLimitPerSecond > 0 {
+		clientRateLimiter := newKeyedRateLimiter(configuration.RateLimitPerSecond, configuration.RateLimitBurst)
+		clientRateLimiter.startCleanup(rateLimiterCleanupInterval)
+		router.Use(rateLimitMiddleware(clientRateLimiter))
+	}
+	clientKeyMiddleware := secretMiddleware(append([]string{configuration.ServiceSecret}, configuration.ServiceSecrets...), structuredLogger)
+	modelLimiter := newModelRateLimiter(configuration.ModelRateLimits)
+	var requestSlots chan struct{}
+	if configuration.MaxConcurrentRequests > 0 {
+		requestSlots = make(chan struct{}, configuration.MaxConcurrentRequests)
+	}
+	chatRequestHandlers := []gin.HandlerFunc{chatHandler(taskQueues, overflowQueue, configuration, validator, modelLimiter, openAIClient, requestSlots, requestTimeout, structuredLogger)}
+	var recentRequests *recentRequestBuffer
+	if configuration.RecentBufferSize > 0 {
+		recentRequests = newRecentRequestBuffer(configuration.RecentBufferSize)
+		chatRequestHandlers = append([]gin.HandlerFunc{recentRequestsMiddleware(recentRequests)}, chatRequestHandlers...)
+	}
+	if configuration.EnableCompression {
+		chatRequestHandlers = append([]gin.HandlerFunc{compressionMiddleware()}, chatRequestHandlers...)
+	}
+	if configuration.MaxPromptBytes > 0 {
+		// Registered ahead of the router-wide client key check, which must run after the upload is parsed so that
+		// the key may be sent as a form field.
+		askFileHandlers := []gin.HandlerFunc{uploadedKeyMiddleware(configuration.MaxPromptBytes), clientKeyMiddleware, uploadedPromptMiddleware(configuration.MaxPromptBytes)}
+		router.POST(askFilePath, append(askFileHandlers, chatRequestHandlers...)...)
+	}
+	router.GET(versionPath, versionHandler())
+	router.Use(clientKeyMiddleware)
+	if recentRequests != nil {
+		router.GET(recentPath, recentRequestsHandler(recentRequests))
+	}
+	router.GET(modelsPath, modelsHandler(validator))
+	if len(configuration.ModelPricing) > 0 {
+		router.GET(pricingPath, pricingHandler(configuration.ModelPricing))
+	}
+	upstreamHealthCacheDuration := time.Duration(DefaultUpstreamHealthCacheMillis) * time.Millisecond
+	if configuration.UpstreamHealthCacheMillis > 0 {
+		upstreamHealthCacheDuration = time.Duration(configuration.UpstreamHealthCacheMillis) * time.Millisecond
+	}
+	upstreamProbe := newUpstreamHealthProbe(openAIClient, configuration.OpenAIKey, upstreamHealthCacheDuration)
+	router.GET(upstreamHealthPath, upstreamHealthHandler(upstreamProbe, structuredLogger))
+	router.GET(healthDetailPath, healthDetailHandler(upstreamProbe, validator, modelsLoadedAt, taskQueues, workers, newLoadSheddingMonitor(configuration, openAIClient.circuitBreaker, requestSlots, taskQueues, overflowQueue), structuredLogger))
+	router.GET(capabilitiesPath, capabilitiesHandler(validator, configuration.DefaultModel, configuration.ModelAliases))
+	router.GET(rootPath, chatRequestHandlers...)
+	router.POST(rootPath, chatRequestHandlers...)
+	router.POST(chatCompletionsPath, chatCompletionsHandler(taskQueues, overflowQueue, configuration, validator, modelLimiter, openAIClient.circuitBreaker, requestSlots, requestTimeout, structuredLogger))
+	return router, workers, nil
 }
 
-// Serve builds the router from the supplied configuration and structuredLogger and starts the HTTP server on the configured port.
-func Serve(configuration Configuration, structuredLogger *zap.SugaredLogger) error {
-	router, buildError := BuildRouter(configuration, structuredLogger)
-	if buildError != nil {
-		return buildError
+// warmUpstream issues a tiny throwaway prompt to the default model to establish the upstream connection and confirm
+// the credentials end to end. Failures are logged and returned so that the caller can decide whether they are fatal.
+// A positive startupTimeout bounds the wait; the abandoned request still ends within the client request timeout.
+func warmUpstream(openAIClient *OpenAIClient, openAIKey string, defaultModel string, startupTimeout time.Duration, structuredLogger *zap.SugaredLogger) error {
+	warmupStart := time.Now()
+	warmupDone := make(chan error, 1)
+	go func() {
+		_, requestError := openAIClient.openAIRequest(context.Background(), openAIKey, defaultModel, warmupPrompt, constants.EmptyString, RequestPayloadOptions{MaxOutputTokens: warmupMaxOutputTokens}, structuredLogger)
+		warmupDone <- requestError
+	}()
+	var startupDeadline <-chan time.Time
+	if startupTimeout > 0 {
+		startupTimer := time.NewTimer(startupTimeout)
+		defer startupTimer.Stop()
+		startupDeadline = startupTimer.C
+	}
+	var warmupError error
+	select {
+	case warmupError = <-warmupDone:
+	case <-startupDeadline:
+		structuredLogger.Warnw(logEventWarmupFailed, constants.LogFieldError, ErrStartupTimeout)
+		return fmt.Errorf(errorWrapWithCauseFormat, ErrWarmupFailed, ErrStartupTimeout)
+	}
+	if warmupError != nil {
+		structuredLogger.Warnw(logEventWarmupFailed, constants.LogFieldError, warmupError)
+		return fmt.Errorf(errorWrapWithDetailFormat, ErrWarmupFailed, warmupError.Error())
 	}
-	return router.Run(fmt.Sprintf(":%d", configuration.Port))
+	structuredLogger.Infow(logEventWarmupCompleted, constants.LogFieldLatencyMilliseconds, time.Since(warmupStart).Milliseconds())
+	return nil
 }
 
 // chatHandler returns a handler that forwards requests to the task queue.
-func chatHandler(taskQueue chan requestTask, defaultSystemPrompt string, validator *modelValidator, requestTimeout time.Duration, structuredLogger *zap.SugaredLogger) gin.HandlerFunc {
+// The prompt comes from the query string for GET and from the body for POST; all other parameters come from the query string.
+// Each task goes to the queue of the pool serving its resolved model. When overflowQueue is non-nil, tasks that do
+// not fit in that queue are spilled to disk instead of waiting for space.
+// configuration supplies the default system prompt, the per-request override policy, and prompt directive routing.
+// While the circuit breaker of openAIClient is open, requests are rejected with 503 before they are queued. A dry_run
+// request is answered with the upstream payload openAIClient would send, without queueing or contacting the upstream.
+// requestTimeout bounds the wait for a queue slot and for the reply unless the timeout query parameter replaces it
+// within the configured bounds. When
+// requestSlots is non-nil, each request holds one of its slots while in progress and requests finding every slot taken
+// are rejected with 503 at once.
+func chatHandler(taskQueues *modelQueues, overflowQueue *diskOverflowQueue, configuration Configuration, validator *modelValidator, modelLimiter *modelRateLimiter, openAIClient *OpenAIClient, requestSlots chan struct{}, requestTimeout time.Duration, structuredLogger *zap.SugaredLogger) gin.HandlerFunc {
 	return func(ginContext *gin.Context) {
-		userPrompt := ginContext.Query(queryParameterPrompt)
+		correlatedLogger := requestLogger(structuredLogger, ginContext.GetString(contextKeyRequestID))
+		if requestSlots != nil {
+			select {
+			case requestSlots <- struct{}{}:
+				defer func() { <-requestSlots }()
+			default:
+				ginContext.String(http.StatusServiceUnavailable, errorConcurrencyLimitReached)
+				return
+			}
+		}
+
+		if configuration.RejectDuplicateParams {
+			if duplicatedParameter, duplicated := findDuplicateParameter(ginContext, securityRelevantParameters); duplicated {
+				ginContext.String(http.StatusBadRequest, errorDuplicateParameterPrefix+duplicatedParameter)
+				return
+			}
+		}
+
+		userPrompt, promptStatus, promptError := requestPrompt(ginContext)
+		if promptError != nil {
+			ginContext.String(promptStatus, promptError.Error())
+			return
+		}
 		if userPrompt == constants.EmptyString {
 			ginContext.String(http.StatusBadRequest, errorMissingPrompt)
 			return
 		}
 
-		systemPrompt := ginContext.Query(queryParameterSystemPrompt)
-		if systemPrompt == constants.EmptyString {
-			systemPrompt = defaultSystemPrompt
+		var appliedOverrides []string
+		systemPrompt := configuration.SystemPrompt
+		if templateName := strings.TrimSpace(ginContext.Query(queryParameterSystemPromptName)); templateName != constants.EmptyString {
+			templatePrompt, knownTemplate := configuration.SystemPromptTemplates[templateName]
+			if !knownTemplate {
+				ginContext.String(http.StatusBadRequest, errorUnknownSystemPromptName)
+				return
+			}
+			systemPrompt = templatePrompt
+			appliedOverrides = append(appliedOverrides, queryParameterSystemPromptName)
+		}
+		if overridePrompt := ginContext.Query(queryParameterSystemPrompt); overridePrompt != constants.EmptyString {
+			if !configuration.DisableSystemPromptOverride {
+				systemPrompt = overridePrompt
+				appliedOverrides = append(appliedOverrides, queryParameterSystemPrompt)
+			} else {
+				correlatedLogger.Debugw(logEventSystemPromptOverrideIgnored, logFieldParameter, queryParameterSystemPrompt)
+			}
 		}
 
 		modelIdentifier := ginContext.Query(queryParameterModel)
+		if modelIdentifier != constants.EmptyString {
+			appliedOverrides = append(appliedOverrides, queryParameterModel)
+			modelIdentifier = resolveModelAlias(modelIdentifier, configuration.ModelAliases)
+		}
+		if strippedPrompt, prefixModel, prefixMatched := resolvePromptPrefix(userPrompt, configuration.PromptPrefixModelMap); prefixMatched {
+			if strippedPrompt == constants.EmptyString {
+				ginContext.String(http.StatusBadRequest, errorMissingPrompt)
+				return
+			}
+			userPrompt = strippedPrompt
+			if modelIdentifier == constants.EmptyString {
+				modelIdentifier = prefixModel
+				appliedOverrides = append(appliedOverrides, overridePromptPrefix)
+			}
+		}
 		if modelIdentifier == constants.EmptyString {
-			modelIdentifier = DefaultModel
+			modelIdentifier = configuration.DefaultModel
+			if configuration.ABTestModel != constants.EmptyString {
+				modelIdentifier = selectABTestModel(configuration.DefaultModel, configuration.ABTestModel, configuration.ABTestPercentage, systemPrompt, userPrompt)
+				ginContext.Header(headerServedModel, modelIdentifier)
+				correlatedLogger.Infow(logEventABTestRouted, logFieldModel, modelIdentifier)
+			}
 		}
+		ginContext.Set(contextKeyAuditModel, modelIdentifier)
+		ginContext.Set(contextKeyAuditPrompt, userPrompt)
 		if verificationError := validator.Verify(modelIdentifier); verificationError != nil {
 			ginContext.String(http.StatusBadRequest, verificationError.Error())
 			return
 		}
 
+		writeDeprecationWarning(ginContext, modelIdentifier, configuration.DeprecatedModels)
+
 		webSearchQuery := strings.TrimSpace(ginContext.Query(queryParameterWebSearch))
 		webSearchEnabled := false
 		if webSearchQuery != constants.EmptyString {
 			parsedWebSearch, parseError := strconv.ParseBool(webSearchQuery)
 			if parseError != nil {
-				structuredLogger.Warnw(
+				correlatedLogger.Warnw(
 					logEventParseWebSearchParameterFailed,
 					logFieldValue, webSearchQuery,
 					constants.LogFieldError, parseError,
@@ -130,44 +519,215 @@ func chatHandler(taskQueue chan requestTask, defaultSystemPrompt string, validat
 			}
 		}
 
+		requestedMaxOutputTokens := 0
+		if maxTokensQuery := strings.TrimSpace(ginContext.Query(queryParameterMaxTokens)); maxTokensQuery != constants.EmptyString {
+			parsedMaxTokens, parseError := strconv.Atoi(maxTokensQuery)
+			if parseError != nil || parsedMaxTokens <= 0 {
+				ginContext.String(http.StatusBadRequest, errorInvalidMaxTokens)
+				return
+			}
+			if parsedMaxTokens > configuration.MaxOutputTokensCeiling {
+				ginContext.String(http.StatusBadRequest, fmt.Sprintf(errorMaxTokensAboveCeilingFormat, configuration.MaxOutputTokensCeiling))
+				return
+			}
+			requestedMaxOutputTokens = parsedMaxTokens
+		}
+
+		var requestedTemperature *float64
+		if temperatureQuery := strings.TrimSpace(ginContext.Query(queryParameterTemperature)); temperatureQuery != constants.EmptyString {
+			parsedTemperature, parseError := strconv.ParseFloat(temperatureQuery, 64)
+			if parseError != nil || math.IsNaN(parsedTemperature) || parsedTemperature < MinTemperature || parsedTemperature > MaxTemperature {
+				ginContext.String(http.StatusBadRequest, errorInvalidTemperature)
+				return
+			}
+			requestedTemperature = &parsedTemperature
+		}
+
+		requestedReasoningEffort := strings.ToLower(strings.TrimSpace(ginContext.Query(queryParameterReasoningEffort)))
+		if requestedReasoningEffort != constants.EmptyString && !isSupportedReasoningEffort(requestedReasoningEffort) {
+			ginContext.String(http.StatusBadRequest, errorInvalidReasoningEffort)
+			return
+		}
+
+		var requestedSeed *int64
+		if seedQuery := strings.TrimSpace(ginContext.Query(queryParameterSeed)); seedQuery != constants.EmptyString {
+			parsedSeed, parseError := strconv.ParseInt(seedQuery, 10, 64)
+			if parseError != nil {
+				ginContext.String(http.StatusBadRequest, errorInvalidSeed)
+				return
+			}
+			requestedSeed = &parsedSeed
+		}
+
+		requestedTools, toolsError := requestTools(ginContext, webSearchEnabled, configuration.MaxTools)
+		if toolsError != nil {
+			ginContext.String(http.StatusBadRequest, toolsError.Error())
+			return
+		}
+
+		var requestedSearchLocation *UserLocation
+		requestedSearchContextSize := constants.EmptyString
+		if webSearchEnabled || slices.Contains(requestedTools, toolTypeWebSearch) {
+			var searchLocationError error
+			requestedSearchLocation, searchLocationError = requestSearchLocation(ginContext)
+			if searchLocationError != nil {
+				ginContext.String(http.StatusBadRequest, searchLocationError.Error())
+				return
+			}
+			requestedSearchContextSize = strings.ToLower(strings.TrimSpace(ginContext.Query(queryParameterSearchContext)))
+			if requestedSearchContextSize != constants.EmptyString && !slices.Contains(supportedSearchContextSizes, requestedSearchContextSize) {
+				ginContext.String(http.StatusBadRequest, errorInvalidSearchContext)
+				return
+			}
+		}
+
+		requestedPreviousResponseID := strings.TrimSpace(ginContext.Query(queryParameterPreviousResponseID))
+		if requestedPreviousResponseID != constants.EmptyString && !previousResponseIDPattern.MatchString(requestedPreviousResponseID) {
+			ginContext.String(http.StatusBadRequest, errorInvalidPreviousResponseID)
+			return
+		}
+
+		requestedResponseSchema, schemaError := requestResponseSchema(ginContext)
+		if schemaError != nil {
+			ginContext.String(http.StatusBadRequest, schemaError.Error())
+			return
+		}
+
+		effectiveRequestTimeout := requestTimeout
+		if timeoutQuery := strings.TrimSpace(ginContext.Query(queryParameterTimeout)); timeoutQuery != constants.EmptyString {
+			parsedTimeoutSeconds, parseError := strconv.Atoi(timeoutQuery)
+			if parseError != nil || parsedTimeoutSeconds < configuration.MinRequestTimeoutSeconds || parsedTimeoutSeconds > configuration.MaxRequestTimeoutSeconds {
+				ginContext.String(http.StatusBadRequest, fmt.Sprintf(errorInvalidTimeoutFormat, configuration.MinRequestTimeoutSeconds, configuration.MaxRequestTimeoutSeconds))
+				return
+			}
+			effectiveRequestTimeout = time.Duration(parsedTimeoutSeconds) * time.Second
+		}
+
+		responseCSVLayout, csvLayoutError := requestCSVLayout(ginContext)
+		if csvLayoutError != nil {
+			ginContext.String(http.StatusBadRequest, csvLayoutError.Error())
+			return
+		}
+		responseMime, negotiationError := negotiateResponseMime(ginContext, configuration.EnabledFormats)
+		if negotiationError != nil {
+			ginContext.String(http.StatusNotAcceptable, negotiationError.Error())
+			return
+		}
+
+		streamRequested, _ := strconv.ParseBool(strings.TrimSpace(ginContext.Query(queryParameterStream)))
+
+		requestedCandidateCount := 1
+		if candidateQuery := strings.TrimSpace(ginContext.Query(queryParameterCandidateCount)); candidateQuery != constants.EmptyString {
+			parsedCandidateCount, parseError := strconv.Atoi(candidateQuery)
+			if parseError != nil || parsedCandidateCount < 1 || parsedCandidateCount > maxCandidateCount {
+				ginContext.String(http.StatusBadRequest, fmt.Sprintf(errorInvalidCandidateCountFormat, maxCandidateCount))
+				return
+			}
+			if parsedCandidateCount > 1 && streamRequested {
+				ginContext.String(http.StatusBadRequest, errorStreamedCandidates)
+				return
+			}
+			requestedCandidateCount = parsedCandidateCount
+		}
+
+		taskQueue := taskQueues.queueFor(modelIdentifier)
 		replyChannel := make(chan result, 1)
-		requestDeadline, deadlineFound := ginContext.Request.Context().Deadline()
-		enqueueDuration := requestTimeout
-		if deadlineFound {
-			enqueueDuration = time.Until(requestDeadline)
+		pendingTask := requestTask{
+			prompt:             userPrompt,
+			systemPrompt:       systemPrompt,
+			model:              modelIdentifier,
+			webSearchEnabled:   webSearchEnabled,
+			tools:              requestedTools,
+			searchLocation:     requestedSearchLocation,
+			searchContextSize:  requestedSearchContextSize,
+			maxOutputTokens:    requestedMaxOutputTokens,
+			temperature:        requestedTemperature,
+			reasoningEffort:    requestedReasoningEffort,
+			responseSchema:     requestedResponseSchema,
+			seed:               requestedSeed,
+			candidateCount:     requestedCandidateCount,
+			previousResponseID: requestedPreviousResponseID,
+			passthroughHeaders: passthroughHeaders(ginContext.Request, configuration.PassthroughHeaders),
+			requestID:          ginContext.GetString(contextKeyRequestID),
+			sendRequestID:      configuration.SendRequestIDToUpstream,
+			traceContext:       detachedTraceContext(ginContext),
+			reply:              replyChannel,
 		}
-		enqueueContext, enqueueCancel := context.WithTimeout(ginContext.Request.Context(), enqueueDuration)
-		select {
-		case taskQueue <- requestTask{
-			prompt:           userPrompt,
-			systemPrompt:     systemPrompt,
-			model:            modelIdentifier,
-			webSearchEnabled: webSearchEnabled,
-			reply:            replyChannel,
-		}:
-			enqueueCancel()
-		case <-enqueueContext.Done():
-			enqueueCancel()
+		if dryRunRequested, _ := strconv.ParseBool(strings.TrimSpace(ginContext.Query(queryParameterDryRun))); dryRunRequested {
+			payloadOptions := pendingTask.payloadOptions()
+			payloadOptions.Stream = streamRequested
+			payload, _ := openAIClient.requestPayload(modelIdentifier, userPrompt, systemPrompt, payloadOptions, correlatedLogger)
+			payloadBytes, marshalError := json.Marshal(payload)
+			if marshalError != nil {
+				correlatedLogger.Errorw(logEventMarshalRequestPayload, constants.LogFieldError, marshalError)
+				ginContext.String(http.StatusInternalServerError, marshalError.Error())
+				return
+			}
+			ginContext.Data(http.StatusOK, mimeApplicationJSON, payloadBytes)
+			return
+		}
+
+		if allowed, wait := modelLimiter.allow(modelIdentifier, time.Now()); !allowed {
+			ginContext.Header(headerRetryAfter, retryAfterSeconds(wait))
+			ginContext.String(http.StatusTooManyRequests, fmt.Sprintf(errorModelRateLimitedFormat, modelIdentifier))
+			return
+		}
+
+		if rejecting, wait := openAIClient.circuitBreaker.rejecting(time.Now()); rejecting {
+			ginContext.Header(headerRetryAfter, retryAfterSeconds(wait))
+			ginContext.String(http.StatusServiceUnavailable, errorUpstreamCircuitOpen)
+			return
+		}
+
+		if streamRequested {
+			streamContext, streamCancel := context.WithCancel(ginContext.Request.Context())
+			defer streamCancel()
+			pendingTask.streamDeltas = make(chan string)
+			pendingTask.streamContext = streamContext
+		}
+		if !enqueueTask(ginContext, taskQueue, overflowQueue, pendingTask, effectiveRequestTimeout, correlatedLogger) {
+			ginContext.Header(headerRetryAfter, retryAfterSeconds(queueFullRetryAfter))
+			if configuration.VerboseQueueFull {
+				reportBody, contentType := newQueueFullReport(taskQueue, queueFullRetryAfter).encode(responseMime)
+				ginContext.Data(http.StatusServiceUnavailable, contentType, []byte(reportBody))
+				return
+			}
 			ginContext.String(http.StatusServiceUnavailable, errorQueueFull)
 			return
 		}
 
-		requestContext, requestCancel := context.WithTimeout(ginContext.Request.Context(), requestTimeout)
+		requestContext, requestCancel := context.WithTimeout(ginContext.Request.Context(), effectiveRequestTimeout)
+		if streamRequested {
+			relayStream(ginContext, requestContext, pendingTask.streamDeltas, replyChannel, configuration.MirrorUpstreamStatus)
+			requestCancel()
+			return
+		}
 		select {
 		case outcome := <-replyChannel:
 			requestCancel()
 			if outcome.requestError != nil {
-				if errors.Is(outcome.requestError, ErrUnknownModel) {
-					ginContext.String(http.StatusBadRequest, outcome.requestError.Error())
-				} else if errors.Is(outcome.requestError, context.DeadlineExceeded) {
-					ginContext.String(http.StatusGatewayTimeout, errorRequestTimedOut)
-				} else {
-					ginContext.String(http.StatusBadGateway, outcome.requestError.Error())
-				}
+				writeRequestError(ginContext, outcome.requestError, configuration.MirrorUpstreamStatus)
+				return
+			}
+			if configuration.RejectFallbackAnswer && outcome.fallbackUsed {
+				ginContext.String(http.StatusBadGateway, errorFallbackAnswer)
+				return
+			}
+			if configuration.TrimTrailingNewline {
+				outcome.text = strings.TrimRightFunc(outcome.text, unicode.IsSpace)
+			}
+			ginContext.Set(contextKeyAuditResponse, outcome.text)
+			mime := responseMime
+			if configuration.DisableFormatting {
+				mime = mimeTextPlain
+			}
+			echo := newRequestEcho(ginContext, configuration.LogLevel, modelIdentifier, webSearchEnabled, systemPrompt, mime, appliedOverrides)
+			formattedBody, contentType, formatError := formatResponse(outcome.text, outcome.candidates, mime, userPrompt, echo, responseCSVLayout, configuration.MaxFormattedBytes, correlatedLogger)
+			if formatError != nil {
+				ginContext.String(http.StatusBadGateway, formatError.Error())
 				return
 			}
-			mime := preferredMime(ginContext)
-			formattedBody, contentType := formatResponse(outcome.text, mime, userPrompt, structuredLogger)
+			writeOutcomeHeaders(ginContext, configuration, outcome, modelIdentifier)
 			ginContext.Data(http.StatusOK, contentType, []byte(formattedBody))
 		case <-requestContext.Done():
 			requestCancel()
@@ -175,3 +735,291 @@ func chatHandler(taskQueue chan requestTask, defaultSystemPrompt string, validat
 		}
 	}
 }
+
+// writeRequestError maps a worker error to the HTTP status reported to the client.
+func writeRequestError(ginContext *gin.Context, requestError error, mirrorUpstreamStatus bool) {
+	writeUpstreamRetryAfter(ginContext, requestError)
+	ginContext.String(requestErrorStatus(requestError, mirrorUpstreamStatus))
+}
+
+// writeUpstreamRetryAfter sets the Retry-After header when requestError is an upstream rate limit that named a delay.
+func writeUpstreamRetryAfter(ginContext *gin.Context, requestError error) {
+	if retryAfter := upstreamRetryAfter(requestError); retryAfter != constants.EmptyString {
+		ginContext.Header(headerRetryAfter, retryAfter)
+	}
+}
+
+// requestErrorStatus returns the HTTP status and message reported to the client for a worker error. With
+// mirrorUpstreamStatus, an error caused by an upstream response reports that response's status and message instead.
+// Otherwise an upstream rejection of the request itself is reported as a client error carrying the upstream details.
+// An exhausted deadline is always reported as 504, even when the last upstream response failed with another status.
+func requestErrorStatus(requestError error, mirrorUpstreamStatus bool) (int, string) {
+	if errors.Is(requestError, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout, errorRequestTimedOut
+	}
+	var statusError *upstreamStatusError
+	if errors.As(requestError, &statusError) {
+		if mirrorUpstreamStatus {
+			return statusError.statusCode, statusError.upstreamMessage
+		}
+		if clientStatus, clientMessage, isClientError := statusError.clientError(); isClientError {
+			return clientStatus, clientMessage
+		}
+	}
+	switch {
+	case errors.Is(requestError, ErrUnknownModel):
+		return http.StatusBadRequest, requestError.Error()
+	case errors.Is(requestError, ErrUpstreamCircuitOpen):
+		return http.StatusServiceUnavailable, requestError.Error()
+	case errors.Is(requestError, ErrUpstreamRateLimited):
+		return http.StatusTooManyRequests, errorUpstreamRateLimited
+	case errors.Is(requestError, ErrUpstreamIncomplete):
+		return http.StatusGatewayTimeout, errorUpstreamIncomplete
+	default:
+		return http.StatusBadGateway, requestError.Error()
+	}
+}
+
+// writeDeprecationWarning sets a Warning header when modelIdentifier is one of deprecatedModels.
+func writeDeprecationWarning(ginContext *gin.Context, modelIdentifier string, deprecatedModels []string) {
+	if slices.Contains(deprecatedModels, modelIdentifier) {
+		ginContext.Header(headerWarning, fmt.Sprintf(deprecatedModelWarningFormat, modelIdentifier))
+	}
+}
+
+// writeOutcomeHeaders sets the finish reason, upstream latency, queue wait, token usage and estimated cost headers
+// of a non-streamed answer according to configuration. modelIdentifier is the model the request asked for.
+func writeOutcomeHeaders(ginContext *gin.Context, configuration Configuration, outcome result, modelIdentifier string) {
+	if configuration.IncludeFinishReason && outcome.finishReason != constants.EmptyString {
+		ginContext.Header(headerFinishReason, outcome.finishReason)
+	}
+	if configuration.ExposeUpstreamLatency {
+		ginContext.Header(headerUpstreamLatency, strconv.FormatInt(outcome.upstreamLatencyMillis, 10))
+	}
+	if configuration.ExposeQueueWait {
+		ginContext.Header(headerQueueWait, strconv.FormatInt(outcome.queueWaitMillis, 10))
+	}
+	if outcome.responseID != constants.EmptyString {
+		ginContext.Header(headerResponseID, outcome.responseID)
+	}
+	writeUsageHeaders(ginContext, outcome.usage)
+	if configuration.ReportCost {
+		servedModel := outcome.servedModel
+		if servedModel == constants.EmptyString {
+			servedModel = modelIdentifier
+		}
+		writeEstimatedCostHeader(ginContext, outcome.usage, servedModel, configuration.ModelPricing)
+	}
+}
+
+// forwardStreamDelta returns a delta handler that hands each text increment to the client waiting on pending,
+// giving up once that client has gone away.
+func forwardStreamDelta(pending requestTask) func(string) error {
+	return func(textDelta string) error {
+		select {
+		case pending.streamDeltas <- textDelta:
+			return nil
+		case <-pending.streamContext.Done():
+			return pending.streamContext.Err()
+		}
+	}
+}
+
+// relayStream forwards text deltas to the client as server-sent events until the worker replies, then sends a done
+// event carrying the finish reason. Headers are committed with the first delta, so failures before any text arrives
+// keep their usual status codes; later failures are reported as an error event. A task replayed from the disk
+// overflow queue is not streamed by the worker, and its whole answer is relayed as a single delta.
+func relayStream(ginContext *gin.Context, requestContext context.Context, streamDeltas <-chan string, replyChannel <-chan result, mirrorUpstreamStatus bool) {
+	streamStarted := false
+	sendEvent := func(eventName string, eventData string) {
+		if !streamStarted {
+			streamStarted = true
+			ginContext.Header(headerContentType, mimeTextEventStream)
+			ginContext.Header(headerCacheControl, cacheControlNoCache)
+			ginContext.Status(http.StatusOK)
+		}
+		ginContext.SSEvent(eventName, eventData)
+		ginContext.Writer.Flush()
+	}
+	for {
+		select {
+		case textDelta, deltasOpen := <-streamDeltas:
+			if !deltasOpen {
+				streamDeltas = nil
+				continue
+			}
+			sendEvent(streamEventNameDelta, textDelta)
+		case outcome := <-replyChannel:
+			if outcome.requestError != nil {
+				if !streamStarted {
+					writeRequestError(ginContext, outcome.requestError, mirrorUpstreamStatus)
+					return
+				}
+				sendEvent(streamEventNameError, outcome.requestError.Error())
+				return
+			}
+			ginContext.Set(contextKeyAuditResponse, outcome.text)
+			if !streamStarted {
+				sendEvent(streamEventNameDelta, outcome.text)
+			}
+			sendEvent(streamEventNameDone, outcome.finishReason)
+			return
+		case <-requestContext.Done():
+			if !streamStarted {
+				ginContext.String(http.StatusGatewayTimeout, errorRequestTimedOut)
+				return
+			}
+			sendEvent(streamEventNameError, errorRequestTimedOut)
+			return
+		}
+	}
+}
+
+// enqueueTask places pendingTask on taskQueue and reports whether it was accepted. Without an overflow queue it waits
+// for space until the request deadline. With an overflow queue a full taskQueue spills the task to disk immediately,
+// and only a full or failing disk buffer rejects it. While spilled tasks of the same queue are waiting, new tasks join
+// them on disk so that tasks are served in arrival order.
+func enqueueTask(ginContext *gin.Context, taskQueue chan requestTask, overflowQueue *diskOverflowQueue, pendingTask requestTask, requestTimeout time.Duration, structuredLogger *zap.SugaredLogger) bool {
+	pendingTask.enqueuedAt = time.Now()
+	if overflowQueue != nil {
+		if !overflowQueue.backlogged(taskQueue) {
+			select {
+			case taskQueue <- pendingTask:
+				return true
+			default:
+			}
+		}
+		if spillError := overflowQueue.Enqueue(pendingTask, ginContext.Request.Context()); spillError != nil {
+			structuredLogger.Warnw(logEventDiskQueueSpillFailed, constants.LogFieldError, spillError)
+			return false
+		}
+		return true
+	}
+
+	requestDeadline, deadlineFound := ginContext.Request.Context().Deadline()
+	enqueueDuration := requestTimeout
+	if deadlineFound {
+		enqueueDuration = time.Until(requestDeadline)
+	}
+	enqueueContext, enqueueCancel := context.WithTimeout(ginContext.Request.Context(), enqueueDuration)
+	defer enqueueCancel()
+	select {
+	case taskQueue <- pendingTask:
+		return true
+	case <-enqueueContext.Done():
+		return false
+	}
+}
+
+// requestPrompt returns the user prompt. GET requests read the prompt query parameter. POST requests read the prompt
+// form field of an application/x-www-form-urlencoded body or the whole text/plain body, so that prompts too long for a
+// URL survive intermediaries. A prompt file uploaded to the ask-file endpoint takes precedence over both. On failure
+// it also returns the HTTP status to report: 413 for a body larger than maxPromptBodyBytes and 400 for another
+// unreadable body.
+func requestPrompt(ginContext *gin.Context) (string, int, error) {
+	if uploadedPrompt, uploaded := ginContext.Get(contextKeyUploadedPrompt); uploaded {
+		return uploadedPrompt.(string), http.StatusOK, nil
+	}
+	if ginContext.Request.Method != http.MethodPost {
+		return ginContext.Query(queryParameterPrompt), http.StatusOK, nil
+	}
+	ginContext.Request.Body = http.MaxBytesReader(ginContext.Writer, ginContext.Request.Body, maxPromptBodyBytes)
+	switch ginContext.ContentType() {
+	case mimeApplicationFormURLEncoded:
+		if parseError := ginContext.Request.ParseForm(); parseError != nil {
+			return constants.EmptyString, unreadableBodyStatus(parseError), errors.New(errorInvalidRequestBody)
+		}
+		return ginContext.Request.PostForm.Get(queryParameterPrompt), http.StatusOK, nil
+	case mimeTextPlainBase:
+		bodyBytes, readError := io.ReadAll(ginContext.Request.Body)
+		if readError != nil {
+			return constants.EmptyString, unreadableBodyStatus(readError), errors.New(errorInvalidRequestBody)
+		}
+		return string(bodyBytes), http.StatusOK, nil
+	default:
+		return constants.EmptyString, http.StatusUnsupportedMediaType, errors.New(errorUnsupportedMediaType)
+	}
+}
+
+// unreadableBodyStatus returns 413 when readError reports a body beyond the limit of http.MaxBytesReader and 400
+// otherwise.
+func unreadableBodyStatus(readError error) int {
+	var tooLargeError *http.MaxBytesError
+	if errors.As(readError, &tooLargeError) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// requestResponseSchema returns the compacted response_schema parameter, read from the query string or, for
+// form-encoded POST requests, from the body. It returns an empty schema when none was sent and an error when the
+// value is not a JSON object.
+func requestResponseSchema(ginContext *gin.Context) (string, error) {
+	schemaText := ginContext.Query(queryParameterResponseSchema)
+	if schemaText == constants.EmptyString && ginContext.Request.PostForm != nil {
+		schemaText = ginContext.Request.PostForm.Get(queryParameterResponseSchema)
+	}
+	if strings.TrimSpace(schemaText) == constants.EmptyString {
+		return constants.EmptyString, nil
+	}
+	var schemaObject map[string]any
+	if json.Unmarshal([]byte(schemaText), &schemaObject) != nil || schemaObject == nil {
+		return constants.EmptyString, errors.New(errorInvalidResponseSchema)
+	}
+	var compactSchema bytes.Buffer
+	if compactError := json.Compact(&compactSchema, []byte(schemaText)); compactError != nil {
+		return constants.EmptyString, errors.New(errorInvalidResponseSchema)
+	}
+	return compactSchema.String(), nil
+}
+
+// requestTools returns the hosted tool types listed in the tools parameter. It fails when an entry names no supported
+// tool or when the tools, together with web_search when webSearchEnabled is set, number more than maxTools.
+func requestTools(ginContext *gin.Context, webSearchEnabled bool, maxTools int) ([]string, error) {
+	toolsText := strings.TrimSpace(ginContext.Query(queryParameterTools))
+	if toolsText == constants.EmptyString {
+		return nil, nil
+	}
+	var toolTypes []string
+	for _, toolEntry := range strings.Split(toolsText, toolListSeparator) {
+		toolType := strings.ToLower(strings.TrimSpace(toolEntry))
+		if !slices.Contains(supportedToolTypes, toolType) {
+			return nil, fmt.Errorf(errorUnsupportedToolFormat, toolType, strings.Join(supportedToolTypes, supportedToolListSeparator))
+		}
+		toolTypes = append(toolTypes, toolType)
+	}
+	attachedTools := RequestPayloadOptions{WebSearchEnabled: webSearchEnabled, Tools: toolTypes}.attachedTools()
+	if len(attachedTools) > maxTools {
+		return nil, fmt.Errorf(errorTooManyToolsFormat, maxTools)
+	}
+	return toolTypes, nil
+}
+
+// requestSearchLocation returns the approximate user location named by the search_location parameter, a two-letter
+// country code optionally followed by a slash and a city, or nil when the parameter is absent.
+func requestSearchLocation(ginContext *gin.Context) (*UserLocation, error) {
+	locationText := strings.TrimSpace(ginContext.Query(queryParameterSearchLocation))
+	if locationText == constants.EmptyString {
+		return nil, nil
+	}
+	country, city, _ := strings.Cut(locationText, searchLocationSeparator)
+	country = strings.ToUpper(strings.TrimSpace(country))
+	city = strings.TrimSpace(city)
+	if len(country) != 2 || strings.IndexFunc(country, func(letter rune) bool { return letter < 'A' || letter > 'Z' }) >= 0 {
+		return nil, errors.New(errorInvalidSearchLocation)
+	}
+	return &UserLocation{Type: userLocationTypeApproximate, Country: country, City: city}, nil
+}
+
+// securityRelevantParameters lists query parameters whose repetition is rejected when RejectDuplicateParams is set.
+var securityRelevantParameters = []string{queryParameterKey, queryParameterModel, queryParameterWebSearch}
+
+// findDuplicateParameter reports the first of parameterNames that appears more than once in the query string.
+func findDuplicateParameter(ginContext *gin.Context, parameterNames []string) (string, bool) {
+	for _, parameterName := range parameterNames {
+		if parameterValues, _ := ginContext.GetQueryArray(parameterName); len(parameterValues) > 1 {
+			return parameterName, true
+		}
+	}
+	return constants.EmptyString, false
+}
@@ -0,0 +1,58 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Serve builds the router from the supplied configuration and structuredLogger and starts the HTTP server on the configured port.
+// It shuts down gracefully on SIGINT or SIGTERM.
+func Serve(configuration Configuration, structuredLogger *zap.SugaredLogger) error {
+	signalContext, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	return ServeContext(signalContext, configuration, structuredLogger)
+}
+
+// ServeContext serves HTTP on the configured port until serveContext is done, then stops accepting connections,
+// waits for in-flight requests and lets the workers drain the task queue. The whole shutdown is bounded by
+// ShutdownGraceSeconds; exceeding it returns context.DeadlineExceeded.
+func ServeContext(serveContext context.Context, configuration Configuration, structuredLogger *zap.SugaredLogger) error {
+	router, workers, buildError := buildRouterWithWorkers(configuration, structuredLogger)
+	if buildError != nil {
+		return buildError
+	}
+	configuration.ApplyTunables()
+	httpServer := &http.Server{Addr: fmt.Sprintf(":%d", configuration.Port), Handler: router}
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- httpServer.ListenAndServe()
+	}()
+	select {
+	case serveError := <-serveErrors:
+		return serveError
+	case <-serveContext.Done():
+	}
+
+	structuredLogger.Infow(logEventShutdownStarted)
+	shutdownContext, cancelShutdown := context.WithTimeout(context.Background(), time.Duration(configuration.ShutdownGraceSeconds)*time.Second)
+	defer cancelShutdown()
+	if shutdownError := httpServer.Shutdown(shutdownContext); shutdownError != nil {
+		return shutdownError
+	}
+	if drainError := workers.shutdown(shutdownContext); drainError != nil {
+		return drainError
+	}
+	if serveError := <-serveErrors; !errors.Is(serveError, http.ErrServerClosed) {
+		return serveError
+	}
+	structuredLogger.Infow(logEventShutdownCompleted)
+	return nil
+}
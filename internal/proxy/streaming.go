@@ -0,0 +1,144 @@
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	// sseDataPrefix starts a data line in a server-sent event stream.
+	sseDataPrefix = "data:"
+	// sseDoneMarker ends an OpenAI-compatible event stream.
+	sseDoneMarker = "[DONE]"
+	// sseMaxLineBytes bounds a single line read from the upstream event stream.
+	sseMaxLineBytes = 1 << 20
+
+	// streamEventOutputTextDelta carries an incremental piece of output text.
+	streamEventOutputTextDelta = "response.output_text.delta"
+	// streamEventCompleted reports that the response finished.
+	streamEventCompleted = "response.completed"
+	// streamEventIncomplete reports that the response stopped early.
+	streamEventIncomplete = "response.incomplete"
+	// streamEventFailed reports that the response failed upstream.
+	streamEventFailed = "response.failed"
+	// streamEventError reports a stream-level error.
+	streamEventError = "error"
+
+	// jsonFieldType holds the event type of a streamed event.
+	jsonFieldType = "type"
+	// jsonFieldDelta holds the text increment of a delta event.
+	jsonFieldDelta = "delta"
+)
+
+// streamRequest sends the prompt to the streaming responses API and passes each output text delta to deltaHandler
+// as it arrives. It returns the accumulated text once the stream ends, with the time until the upstream response
+// headers arrived as its latency. streamContext cancels the upstream request, and an error returned by deltaHandler
+// aborts the stream.
+func (client *OpenAIClient) streamRequest(streamContext context.Context, openAIKey string, modelIdentifier string, userPrompt string, systemPrompt string, options RequestPayloadOptions, deltaHandler func(string) error, structuredLogger *zap.SugaredLogger) (upstreamResponse, error) {
+	options.Stream = true
+	payload, _ := client.requestPayload(modelIdentifier, userPrompt, systemPrompt, options, structuredLogger)
+	payloadBytes, marshalError := json.Marshal(payload)
+	if marshalError != nil {
+		structuredLogger.Errorw(logEventMarshalRequestPayload, constants.LogFieldError, marshalError)
+		return upstreamResponse{}, marshalError
+	}
+
+	requestContext, cancelRequest := context.WithTimeout(streamContext, client.requestTimeout)
+	defer cancelRequest()
+	httpRequest, buildError := client.buildAuthorizedJSONRequest(requestContext, http.MethodPost, client.endpoints.ResponsesURLForModel(modelIdentifier), openAIKey, bytes.NewReader(payloadBytes))
+	if buildError != nil {
+		structuredLogger.Errorw(logEventBuildHTTPRequest, constants.LogFieldError, buildError)
+		return upstreamResponse{}, buildError
+	}
+	httpRequest.Header.Set(headerAccept, mimeTextEventStream)
+
+	requestStart := time.Now()
+	httpResponse, requestError := client.httpClient.Do(httpRequest)
+	if requestError != nil {
+		structuredLogger.Errorw(logEventOpenAIRequestError, constants.LogFieldError, requestError)
+		if errors.Is(requestError, context.DeadlineExceeded) {
+			return upstreamResponse{}, requestError
+		}
+		return upstreamResponse{}, errors.New(errorOpenAIRequest)
+	}
+	defer httpResponse.Body.Close()
+	headerLatencyMillis := time.Since(requestStart).Milliseconds()
+	if httpResponse.StatusCode < http.StatusOK || httpResponse.StatusCode >= http.StatusMultipleChoices {
+		responseBytes, _ := io.ReadAll(httpResponse.Body)
+		structuredLogger.Desugar().Error(
+			errorOpenAIAPI,
+			zap.Int(logFieldStatus, httpResponse.StatusCode),
+			zap.ByteString(logFieldResponseBody, redactJSONFields(responseBytes, client.logRedactedFields)),
+		)
+		return upstreamResponse{}, withUpstreamStatus(errors.New(errorOpenAIAPI), httpResponse.StatusCode, responseBytes)
+	}
+
+	var accumulatedText strings.Builder
+	finishReason := constants.EmptyString
+	lineScanner := bufio.NewScanner(httpResponse.Body)
+	lineScanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), sseMaxLineBytes)
+	for lineScanner.Scan() {
+		eventData, isData := strings.CutPrefix(lineScanner.Text(), sseDataPrefix)
+		if !isData {
+			continue
+		}
+		eventData = strings.TrimSpace(eventData)
+		if eventData == sseDoneMarker {
+			break
+		}
+		var decodedEvent map[string]any
+		if json.Unmarshal([]byte(eventData), &decodedEvent) != nil {
+			continue
+		}
+		switch utils.GetString(decodedEvent, jsonFieldType) {
+		case streamEventOutputTextDelta:
+			textDelta := utils.GetString(decodedEvent, jsonFieldDelta)
+			if textDelta == constants.EmptyString {
+				continue
+			}
+			accumulatedText.WriteString(textDelta)
+			if handlerError := deltaHandler(textDelta); handlerError != nil {
+				return upstreamResponse{}, handlerError
+			}
+		case streamEventCompleted, streamEventIncomplete:
+			if responseObject, isObject := decodedEvent[jsonFieldResponse].(map[string]any); isObject {
+				finishReason = extractFinishReason(responseObject)
+			}
+		case streamEventFailed, streamEventError:
+			errorSource := decodedEvent
+			if responseObject, isObject := decodedEvent[jsonFieldResponse].(map[string]any); isObject {
+				errorSource = responseObject
+			}
+			if embeddedError := embeddedUpstreamError(errorSource); embeddedError != nil {
+				return upstreamResponse{}, embeddedError
+			}
+			if upstreamMessage := utils.GetString(decodedEvent, jsonFieldMessage); !utils.IsBlank(upstreamMessage) {
+				return upstreamResponse{}, fmt.Errorf(errorWrapWithDetailFormat, ErrUpstreamErrorObject, upstreamMessage)
+			}
+			return upstreamResponse{}, errors.New(errorOpenAIFailedStatus)
+		}
+	}
+	if scanError := lineScanner.Err(); scanError != nil {
+		structuredLogger.Errorw(logEventOpenAIRequestError, constants.LogFieldError, scanError)
+		if errors.Is(scanError, context.DeadlineExceeded) {
+			return upstreamResponse{}, scanError
+		}
+		return upstreamResponse{}, errors.New(errorOpenAIRequest)
+	}
+	if accumulatedText.Len() == 0 {
+		return upstreamResponse{}, errors.New(errorOpenAIAPINoText)
+	}
+	return upstreamResponse{text: accumulatedText.String(), finishReason: finishReason, latencyMillis: headerLatencyMillis}, nil
+}
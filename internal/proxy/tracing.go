@@ -0,0 +1,95 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// tracerName identifies the proxy as the instrumentation scope of its spans.
+	tracerName = "github.com/temirov/llm-proxy/internal/proxy"
+	// spanNameUpstreamRequest names the span around one upstream call, including its retries.
+	spanNameUpstreamRequest = "openai.responses.request"
+	// spanNamePoll names the span around polling a response until it completes.
+	spanNamePoll = "openai.responses.poll"
+	// spanNameSynthesis names the span around starting a synthesis continuation.
+	spanNameSynthesis = "openai.responses.synthesis"
+	// spanAttributeModel records the model serving the request.
+	spanAttributeModel = "llm_proxy.model"
+	// spanAttributeResponseID records the upstream response identifier.
+	spanAttributeResponseID = "llm_proxy.response_id"
+	// spanAttributeLatencyMilliseconds records the latency of the traced operation.
+	spanAttributeLatencyMilliseconds = "llm_proxy.latency_ms"
+	// spanAttributeHTTPMethod records the HTTP method of a traced request.
+	spanAttributeHTTPMethod = "http.request.method"
+	// spanAttributeHTTPRoute records the matched route of a proxied request.
+	spanAttributeHTTPRoute = "http.route"
+	// spanAttributeHTTPStatusCode records the HTTP status code of a traced request.
+	spanAttributeHTTPStatusCode = "http.response.status_code"
+)
+
+// newTracer returns the proxy tracer of tracerProvider, or a tracer that records nothing when tracerProvider is nil.
+func newTracer(tracerProvider trace.TracerProvider) trace.Tracer {
+	if tracerProvider == nil {
+		return noop.NewTracerProvider().Tracer(tracerName)
+	}
+	return tracerProvider.Tracer(tracerName)
+}
+
+// tracingMiddleware starts a root span for every request, named after its method and matched route, and records the
+// resolved model, the response status and the latency once the request is handled. Upstream spans started by the
+// workers become children of this span.
+func tracingMiddleware(tracer trace.Tracer) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		requestStart := time.Now()
+		spanContext, requestSpan := tracer.Start(
+			ginContext.Request.Context(),
+			ginContext.Request.Method,
+			trace.WithNewRoot(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String(spanAttributeHTTPMethod, ginContext.Request.Method)),
+		)
+		defer requestSpan.End()
+		ginContext.Request = ginContext.Request.WithContext(spanContext)
+
+		ginContext.Next()
+
+		responseStatus := ginContext.Writer.Status()
+		if matchedRoute := ginContext.FullPath(); matchedRoute != constants.EmptyString {
+			requestSpan.SetName(ginContext.Request.Method + " " + matchedRoute)
+			requestSpan.SetAttributes(attribute.String(spanAttributeHTTPRoute, matchedRoute))
+		}
+		requestSpan.SetAttributes(
+			attribute.String(spanAttributeModel, ginContext.GetString(contextKeyAuditModel)),
+			attribute.Int(spanAttributeHTTPStatusCode, responseStatus),
+			attribute.Int64(spanAttributeLatencyMilliseconds, time.Since(requestStart).Milliseconds()),
+		)
+		if responseStatus >= http.StatusInternalServerError {
+			requestSpan.SetStatus(codes.Error, http.StatusText(responseStatus))
+		}
+	}
+}
+
+// detachedTraceContext returns a context carrying only the request span of ginContext, so that upstream spans
+// started by a worker join the request trace while the upstream calls keep their own deadlines and are not cancelled
+// with the client request.
+func detachedTraceContext(ginContext *gin.Context) context.Context {
+	return trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ginContext.Request.Context()))
+}
+
+// endSpan records operationError on span, if any, and ends it.
+func endSpan(span trace.Span, operationError error) {
+	if operationError != nil {
+		span.RecordError(operationError)
+		span.SetStatus(codes.Error, operationError.Error())
+	}
+	span.End()
+}
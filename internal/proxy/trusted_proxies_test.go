@@ -0,0 +1,70 @@
+package proxy_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/temirov/llm-proxy/internal/proxy"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const (
+	directRemoteAddress   = "192.0.2.10:4321"
+	directClientIP        = "192.0.2.10"
+	forwardedClientIP     = "203.0.113.7"
+	trustedProxyRange     = "192.0.2.0/24"
+	headerForwardedFor    = "X-Forwarded-For"
+	logEventRequestLogged = "request received"
+	logFieldClientIP      = "client_ip"
+	messageClientIP       = "client_ip=%v want=%v"
+)
+
+// TestClientIPHonorsTrustedProxies verifies that forwarding headers are ignored unless the sender is a trusted proxy.
+func TestClientIPHonorsTrustedProxies(testingInstance *testing.T) {
+	const finalResponse = `{"status":"completed","output_text":"ok"}`
+	testScenarios := []struct {
+		scenarioName     string
+		trustedProxies   []string
+		expectedClientIP string
+	}{
+		{scenarioName: "no trusted proxies uses remote address", trustedProxies: nil, expectedClientIP: directClientIP},
+		{scenarioName: "trusted proxy forwards client address", trustedProxies: []string{trustedProxyRange}, expectedClientIP: forwardedClientIP},
+	}
+	for _, testScenario := range testScenarios {
+		testingInstance.Run(testScenario.scenarioName, func(subTestInstance *testing.T) {
+			mockServer := NewSessionMockServer(finalResponse)
+			defer mockServer.Close()
+			endpoints := proxy.NewEndpoints()
+			endpoints.SetResponsesURL(mockServer.URL)
+			observedCore, observedLogs := observer.New(zapcore.InfoLevel)
+			router, buildError := proxy.BuildRouter(proxy.Configuration{
+				ServiceSecret:  TestSecret,
+				OpenAIKey:      TestAPIKey,
+				LogLevel:       proxy.LogLevelInfo,
+				WorkerCount:    1,
+				QueueSize:      1,
+				TrustedProxies: testScenario.trustedProxies,
+				Endpoints:      endpoints,
+			}, zap.New(observedCore).Sugar())
+			if buildError != nil {
+				subTestInstance.Fatalf(messageBuildRouterError, buildError)
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/?prompt="+TestPrompt+"&key="+TestSecret, nil)
+			request.RemoteAddr = directRemoteAddress
+			request.Header.Set(headerForwardedFor, forwardedClientIP)
+			router.ServeHTTP(httptest.NewRecorder(), request)
+
+			requestEntries := observedLogs.FilterMessage(logEventRequestLogged).All()
+			if len(requestEntries) == 0 {
+				subTestInstance.Fatalf(messageClientIP, nil, testScenario.expectedClientIP)
+			}
+			if loggedClientIP := requestEntries[0].ContextMap()[logFieldClientIP]; loggedClientIP != testScenario.expectedClientIP {
+				subTestInstance.Fatalf(messageClientIP, loggedClientIP, testScenario.expectedClientIP)
+			}
+		})
+	}
+}
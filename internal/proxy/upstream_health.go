@@ -0,0 +1,92 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"go.uber.org/zap"
+)
+
+const (
+	// upstreamHealthPath reports whether the OpenAI models endpoint answers with 200.
+	upstreamHealthPath = "/healthz/upstream"
+	// upstreamHealthProbeTimeout bounds a single probe of the models endpoint.
+	upstreamHealthProbeTimeout = 5 * time.Second
+	// upstreamHealthStatusOK and upstreamHealthStatusUnavailable describe the outcome of the last probe.
+	upstreamHealthStatusOK          = "ok"
+	upstreamHealthStatusUnavailable = "unavailable"
+)
+
+// upstreamHealthReport is the JSON document served by the upstream health endpoint. UpstreamStatus is omitted when
+// the models endpoint could not be reached at all.
+type upstreamHealthReport struct {
+	Status         string `json:"status"`
+	UpstreamStatus int    `json:"upstream_status,omitempty"`
+}
+
+// upstreamHealthProbe performs an authenticated GET of the models endpoint and caches the outcome for cacheDuration,
+// so that frequent health checks do not hammer OpenAI. Probes bypass the retry policy and the circuit breaker.
+type upstreamHealthProbe struct {
+	accessMutex   sync.Mutex
+	client        *OpenAIClient
+	openAIKey     string
+	cacheDuration time.Duration
+	checkedAt     time.Time
+	lastStatus    int
+}
+
+// newUpstreamHealthProbe creates a probe that checks the models endpoint of client with openAIKey.
+func newUpstreamHealthProbe(client *OpenAIClient, openAIKey string, cacheDuration time.Duration) *upstreamHealthProbe {
+	return &upstreamHealthProbe{client: client, openAIKey: openAIKey, cacheDuration: cacheDuration}
+}
+
+// upstreamStatus returns the HTTP status the models endpoint answered with, probing it when the cached outcome is
+// older than cacheDuration. Zero means the endpoint could not be reached. Concurrent callers share one probe.
+func (probe *upstreamHealthProbe) upstreamStatus(now time.Time, structuredLogger *zap.SugaredLogger) int {
+	probe.accessMutex.Lock()
+	defer probe.accessMutex.Unlock()
+	if !probe.checkedAt.IsZero() && now.Sub(probe.checkedAt) < probe.cacheDuration {
+		return probe.lastStatus
+	}
+	probe.lastStatus = probe.fetchModelsStatus(structuredLogger)
+	probe.checkedAt = now
+	return probe.lastStatus
+}
+
+// fetchModelsStatus issues a single GET to the models endpoint and returns its status code, or zero on a transport error.
+func (probe *upstreamHealthProbe) fetchModelsStatus(structuredLogger *zap.SugaredLogger) int {
+	requestContext, cancel := context.WithTimeout(context.Background(), upstreamHealthProbeTimeout)
+	defer cancel()
+	httpRequest, buildError := probe.client.buildAuthorizedJSONRequest(requestContext, http.MethodGet, probe.client.endpoints.GetModelsURL(), probe.openAIKey, nil)
+	if buildError != nil {
+		structuredLogger.Warnw(logEventUpstreamHealthProbeFailed, constants.LogFieldError, buildError)
+		return 0
+	}
+	httpResponse, requestError := probe.client.httpClient.Do(httpRequest)
+	if requestError != nil {
+		structuredLogger.Warnw(logEventUpstreamHealthProbeFailed, constants.LogFieldError, requestError)
+		return 0
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		structuredLogger.Warnw(logEventUpstreamHealthProbeFailed, logFieldStatus, httpResponse.StatusCode)
+	}
+	return httpResponse.StatusCode
+}
+
+// upstreamHealthHandler answers 200 when OpenAI's models endpoint last answered 200 and 503 otherwise, reporting the
+// upstream status in the body. It is independent of the proxy's own liveness.
+func upstreamHealthHandler(probe *upstreamHealthProbe, structuredLogger *zap.SugaredLogger) gin.HandlerFunc {
+	return func(ginContext *gin.Context) {
+		upstreamStatus := probe.upstreamStatus(time.Now(), structuredLogger)
+		if upstreamStatus != http.StatusOK {
+			ginContext.JSON(http.StatusServiceUnavailable, upstreamHealthReport{Status: upstreamHealthStatusUnavailable, UpstreamStatus: upstreamStatus})
+			return
+		}
+		ginContext.JSON(http.StatusOK, upstreamHealthReport{Status: upstreamHealthStatusOK, UpstreamStatus: upstreamStatus})
+	}
+}
@@ -0,0 +1,124 @@
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/utils"
+)
+
+// upstreamStatusError records the HTTP status and message of the upstream response behind a failed request. Its
+// message is that of the wrapped error, so clients see the usual proxy error unless MirrorUpstreamStatus is set or
+// the upstream described a problem with the request itself.
+type upstreamStatusError struct {
+	statusCode      int
+	upstreamMessage string
+	// errorMessage, errorType and errorParam hold the fields of the upstream error object; errorMessage is blank
+	// when the response carried none.
+	errorMessage string
+	errorType    string
+	errorParam   string
+	cause        error
+}
+
+// Error returns the message of the wrapped error.
+func (statusError *upstreamStatusError) Error() string {
+	return statusError.cause.Error()
+}
+
+// Unwrap returns the wrapped error so that errors.Is keeps matching it.
+func (statusError *upstreamStatusError) Unwrap() error {
+	return statusError.cause
+}
+
+// clientError reports the status and message returned to the client when the upstream rejected the request itself
+// with a descriptive error object: 422 for an unprocessable request and 400 for other client errors. Authentication,
+// permission and rate limit errors concern the proxy's own account rather than the request and are not reported.
+func (statusError *upstreamStatusError) clientError() (int, string, bool) {
+	if statusError.statusCode < http.StatusBadRequest || statusError.statusCode >= http.StatusInternalServerError || utils.IsBlank(statusError.errorMessage) {
+		return 0, constants.EmptyString, false
+	}
+	switch statusError.statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return 0, constants.EmptyString, false
+	}
+	clientStatus := http.StatusBadRequest
+	if statusError.statusCode == http.StatusUnprocessableEntity {
+		clientStatus = http.StatusUnprocessableEntity
+	}
+	clientMessage := fmt.Sprintf(errorUpstreamRequestRejectedFormat, statusError.errorMessage)
+	var details []string
+	if !utils.IsBlank(statusError.errorType) {
+		details = append(details, fmt.Sprintf(errorUpstreamTypeFormat, statusError.errorType))
+	}
+	if !utils.IsBlank(statusError.errorParam) {
+		details = append(details, fmt.Sprintf(errorUpstreamParamFormat, statusError.errorParam))
+	}
+	if len(details) > 0 {
+		clientMessage = fmt.Sprintf(errorUpstreamDetailsFormat, clientMessage, strings.Join(details, errorUpstreamDetailSeparator))
+	}
+	return clientStatus, clientMessage, true
+}
+
+// withUpstreamStatus attaches the upstream status code and error object found in responseBytes to cause. It returns
+// cause unchanged when no upstream response was received.
+func withUpstreamStatus(cause error, statusCode int, responseBytes []byte) error {
+	if statusCode == 0 {
+		return cause
+	}
+	var decodedObject map[string]any
+	_ = json.Unmarshal(responseBytes, &decodedObject)
+	statusError := &upstreamStatusError{statusCode: statusCode, upstreamMessage: http.StatusText(statusCode), cause: cause}
+	if errorObject, isObject := decodedObject[jsonFieldError].(map[string]any); isObject {
+		statusError.errorMessage = strings.TrimSpace(utils.GetString(errorObject, jsonFieldMessage))
+		statusError.errorType = utils.GetString(errorObject, jsonFieldType)
+		statusError.errorParam = utils.GetString(errorObject, jsonFieldParam)
+		if !utils.IsBlank(statusError.errorMessage) {
+			statusError.upstreamMessage = statusError.errorMessage
+		}
+	}
+	return statusError
+}
+
+// upstreamRateLimitError reports that the upstream kept answering 429 until the retries were exhausted. retryAfter
+// holds the Retry-After header of the last upstream response and is blank when the response carried none.
+type upstreamRateLimitError struct {
+	retryAfter string
+}
+
+// Error returns the rate limit message.
+func (rateLimitError *upstreamRateLimitError) Error() string {
+	return errorUpstreamRateLimited
+}
+
+// Unwrap returns ErrUpstreamRateLimited so that errors.Is matches it.
+func (rateLimitError *upstreamRateLimitError) Unwrap() error {
+	return ErrUpstreamRateLimited
+}
+
+// upstreamRetryAfter returns the Retry-After value to report to the client for requestError as a whole number of
+// seconds, accepting either form the upstream may send. It returns an empty string when requestError is not an
+// upstream rate limit or the upstream gave no usable delay.
+func upstreamRetryAfter(requestError error) string {
+	var rateLimitError *upstreamRateLimitError
+	if !errors.As(requestError, &rateLimitError) {
+		return constants.EmptyString
+	}
+	retryAfter := strings.TrimSpace(rateLimitError.retryAfter)
+	if delaySeconds, parseError := strconv.Atoi(retryAfter); parseError == nil {
+		if delaySeconds < 0 {
+			return constants.EmptyString
+		}
+		return strconv.Itoa(delaySeconds)
+	}
+	if retryTime, parseError := http.ParseTime(retryAfter); parseError == nil {
+		return retryAfterSeconds(time.Until(retryTime))
+	}
+	return constants.EmptyString
+}
@@ -0,0 +1,75 @@
+package proxy
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// jsonFieldUsage holds the token accounting object of an upstream response.
+	jsonFieldUsage = "usage"
+	// jsonFieldInputTokens holds the prompt token count in a Responses API usage object.
+	jsonFieldInputTokens = "input_tokens"
+	// jsonFieldOutputTokens holds the generated token count in a Responses API usage object.
+	jsonFieldOutputTokens = "output_tokens"
+	// jsonFieldTotalTokens holds the combined token count of a usage object.
+	jsonFieldTotalTokens = "total_tokens"
+	// jsonFieldPromptTokens holds the prompt token count in a chat-completions usage object.
+	jsonFieldPromptTokens = "prompt_tokens"
+	// jsonFieldCompletionTokens holds the generated token count in a chat-completions usage object.
+	jsonFieldCompletionTokens = "completion_tokens"
+
+	// headerUsageInputTokens reports the prompt tokens consumed by a request.
+	headerUsageInputTokens = "X-Usage-Input-Tokens"
+	// headerUsageOutputTokens reports the tokens generated for a request.
+	headerUsageOutputTokens = "X-Usage-Output-Tokens"
+	// headerUsageTotalTokens reports the combined token count of a request.
+	headerUsageTotalTokens = "X-Usage-Total-Tokens"
+)
+
+// tokenUsage holds the token counts an upstream response reports for a request.
+type tokenUsage struct {
+	inputTokens  int64
+	outputTokens int64
+	totalTokens  int64
+}
+
+// extractTokenUsage reads the usage object of a decoded upstream response. Responses API payloads report
+// input_tokens and output_tokens; chat-completions payloads report prompt_tokens and completion_tokens.
+// A missing total is derived from the other two counts. It returns nil when the response carries no usage object.
+func extractTokenUsage(decodedObject map[string]any) *tokenUsage {
+	usageObject, isObject := decodedObject[jsonFieldUsage].(map[string]any)
+	if !isObject {
+		return nil
+	}
+	usage := &tokenUsage{
+		inputTokens:  firstTokenCount(usageObject, jsonFieldInputTokens, jsonFieldPromptTokens),
+		outputTokens: firstTokenCount(usageObject, jsonFieldOutputTokens, jsonFieldCompletionTokens),
+		totalTokens:  firstTokenCount(usageObject, jsonFieldTotalTokens),
+	}
+	if usage.totalTokens == 0 {
+		usage.totalTokens = usage.inputTokens + usage.outputTokens
+	}
+	return usage
+}
+
+// firstTokenCount returns the first numeric value found under fieldNames, or zero when none is present.
+func firstTokenCount(usageObject map[string]any, fieldNames ...string) int64 {
+	for _, fieldName := range fieldNames {
+		if tokenCount, isNumber := usageObject[fieldName].(float64); isNumber {
+			return int64(tokenCount)
+		}
+	}
+	return 0
+}
+
+// writeUsageHeaders sets the X-Usage-* response headers when the upstream reported token usage.
+func writeUsageHeaders(ginContext *gin.Context, usage *tokenUsage) {
+	if usage == nil {
+		return
+	}
+	ginContext.Header(headerUsageInputTokens, strconv.FormatInt(usage.inputTokens, 10))
+	ginContext.Header(headerUsageOutputTokens, strconv.FormatInt(usage.outputTokens, 10))
+	ginContext.Header(headerUsageTotalTokens, strconv.FormatInt(usage.totalTokens, 10))
+}
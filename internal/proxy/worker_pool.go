@@ -0,0 +1,72 @@
+package proxy
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// workerPool runs the goroutines that serve the task queue and lets shutdown wait for them to drain it.
+type workerPool struct {
+	stopSignal chan struct{}
+	waitGroup  sync.WaitGroup
+	// workerCount is the number of workers started across all queues.
+	workerCount int
+	// inFlight counts the tasks being processed at this moment.
+	inFlight atomic.Int64
+}
+
+// newWorkerPool starts workerCount goroutines that pass every task received from taskQueue to processTask.
+func newWorkerPool(workerCount int, taskQueue <-chan requestTask, processTask func(requestTask)) *workerPool {
+	pool := &workerPool{stopSignal: make(chan struct{})}
+	pool.addWorkers(workerCount, taskQueue, processTask)
+	return pool
+}
+
+// addWorkers starts workerCount more goroutines serving taskQueue, stopped by the same shutdown as the others.
+func (pool *workerPool) addWorkers(workerCount int, taskQueue <-chan requestTask, processTask func(requestTask)) {
+	pool.workerCount += workerCount
+	countedProcessTask := func(pending requestTask) {
+		pool.inFlight.Add(1)
+		defer pool.inFlight.Add(-1)
+		processTask(pending)
+	}
+	for workerIndex := 0; workerIndex < workerCount; workerIndex++ {
+		pool.waitGroup.Add(1)
+		go func() {
+			defer pool.waitGroup.Done()
+			for {
+				select {
+				case pending := <-taskQueue:
+					countedProcessTask(pending)
+				case <-pool.stopSignal:
+					for {
+						select {
+						case pending := <-taskQueue:
+							countedProcessTask(pending)
+						default:
+							return
+						}
+					}
+				}
+			}
+		}()
+	}
+}
+
+// shutdown tells the workers to finish the tasks still queued and then exit, waiting for them until
+// shutdownContext is done.
+func (pool *workerPool) shutdown(shutdownContext context.Context) error {
+	close(pool.stopSignal)
+	drained := make(chan struct{})
+	go func() {
+		pool.waitGroup.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-shutdownContext.Done():
+		return shutdownContext.Err()
+	}
+}
@@ -14,3 +14,9 @@ func Fingerprint(secret string) string {
 	}
 	return hexed[:8]
 }
+
+// ContentHash returns the full hex-encoded SHA-256 digest of value for records that must not retain the content itself.
+func ContentHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
@@ -14,6 +14,7 @@ const (
 	fingerprintEmpty    = "e3b0c442"
 	fingerprintABC      = "ba7816bf"
 	fingerprintLLMProxy = "c30d6864"
+	contentHashABC      = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
 )
 
 type fingerprintTestDefinition struct {
@@ -38,3 +39,10 @@ func TestFingerprint_OutputMatchesExpected(testingInstance *testing.T) {
 		})
 	}
 }
+
+// TestContentHash_ReturnsFullDigest verifies that ContentHash returns the complete SHA-256 digest.
+func TestContentHash_ReturnsFullDigest(testingInstance *testing.T) {
+	if actualHash := utils.ContentHash(secretABC); actualHash != contentHashABC {
+		testingInstance.Fatalf("hash=%s expected=%s", actualHash, contentHashABC)
+	}
+}
@@ -17,9 +17,34 @@ var exponentialBackoffPool = sync.Pool{
 	},
 }
 
-// AcquireExponentialBackoff retrieves a reusable exponential backoff instance.
-func AcquireExponentialBackoff() *backoff.ExponentialBackOff {
-	return exponentialBackoffPool.Get().(*backoff.ExponentialBackOff)
+// BackoffSettings tunes the exponential backoff used to retry requests. Zero fields keep the library defaults.
+type BackoffSettings struct {
+	// InitialInterval is the wait before the first retry.
+	InitialInterval time.Duration
+	// Multiplier scales the wait after each retry.
+	Multiplier float64
+	// MaxElapsedTime stops retrying once this much time has passed since the first attempt.
+	MaxElapsedTime time.Duration
+}
+
+// AcquireExponentialBackoff retrieves a reusable exponential backoff instance configured with settings and
+// restarted, so its elapsed time is measured from this call.
+func AcquireExponentialBackoff(settings BackoffSettings) *backoff.ExponentialBackOff {
+	exponentialBackoff := exponentialBackoffPool.Get().(*backoff.ExponentialBackOff)
+	exponentialBackoff.InitialInterval = backoff.DefaultInitialInterval
+	if settings.InitialInterval > 0 {
+		exponentialBackoff.InitialInterval = settings.InitialInterval
+	}
+	exponentialBackoff.Multiplier = backoff.DefaultMultiplier
+	if settings.Multiplier > 0 {
+		exponentialBackoff.Multiplier = settings.Multiplier
+	}
+	exponentialBackoff.MaxElapsedTime = backoff.DefaultMaxElapsedTime
+	if settings.MaxElapsedTime > 0 {
+		exponentialBackoff.MaxElapsedTime = settings.MaxElapsedTime
+	}
+	exponentialBackoff.Reset()
+	return exponentialBackoff
 }
 
 // ReleaseExponentialBackoff resets the backoff and returns it to the pool.
@@ -41,8 +66,9 @@ func BuildHTTPRequestWithHeaders(method string, requestURL string, body io.Reade
 }
 
 // PerformHTTPRequest issues the HTTP request using executeRequest and returns the status code, body, and latency.
-// It automatically retries transport failures using exponential backoff.
-func PerformHTTPRequest(executeRequest func(*http.Request) (*http.Response, error), httpRequest *http.Request, structuredLogger *zap.SugaredLogger, logEventOnTransportError string) (int, []byte, int64, error) {
+// It automatically retries transport failures using exponential backoff tuned by backoffSettings, never past the
+// deadline of the request context.
+func PerformHTTPRequest(executeRequest func(*http.Request) (*http.Response, error), httpRequest *http.Request, backoffSettings BackoffSettings, structuredLogger *zap.SugaredLogger, logEventOnTransportError string) (int, []byte, int64, error) {
 	startTime := time.Now()
 	var httpResponse *http.Response
 	operation := func() error {
@@ -64,7 +90,7 @@ func PerformHTTPRequest(executeRequest func(*http.Request) (*http.Response, erro
 		return nil
 	}
 
-	exponentialBackoff := AcquireExponentialBackoff()
+	exponentialBackoff := AcquireExponentialBackoff(backoffSettings)
 	defer ReleaseExponentialBackoff(exponentialBackoff)
 	retryError := backoff.Retry(operation, backoff.WithContext(exponentialBackoff, httpRequest.Context()))
 	latencyMillis := time.Since(startTime).Milliseconds()
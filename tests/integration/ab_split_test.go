@@ -0,0 +1,82 @@
+package integration_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// servedModelHeader reports which model served an A/B test request.
+	servedModelHeader = "X-Served-Model"
+	// abTestRequestCount is the number of distinct default-model requests sent during the A/B test.
+	abTestRequestCount = 400
+	// abTestPercentage is the configured share of requests routed to the candidate model.
+	abTestPercentage = 30
+	// abTestTolerance is the accepted deviation, in percentage points, from the configured share.
+	abTestTolerance = 10
+	// abTestPromptFormat builds a distinct prompt per request.
+	abTestPromptFormat = "question %d"
+	// abTestShareFormat reports a candidate share outside the accepted range.
+	abTestShareFormat = "candidate served %d of %d requests; want about %d%%"
+	// abTestServedMismatchFormat reports a served-model header that disagrees with the forwarded model.
+	abTestServedMismatchFormat = "served model header=%q forwarded model=%v"
+)
+
+// TestABTestSplitsDefaultModelTraffic verifies that roughly the configured share of default-model requests reaches the candidate.
+func TestABTestSplitsDefaultModelTraffic(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	endpoints := proxy.NewEndpoints()
+	client, capturedPayload := makeHTTPClient(testingInstance, false, endpoints)
+	server := newRouterServer(testingInstance, client, endpoints, func(configuration *proxy.Configuration) {
+		configuration.LogLevel = logLevelInfo
+		configuration.ABTestModel = proxy.ModelNameGPT4o
+		configuration.ABTestPercentage = abTestPercentage
+	})
+
+	sendRequest := func(queryValues url.Values) *http.Response {
+		queryValues.Set(keyQueryParameter, serviceSecretValue)
+		httpResponse, requestError := http.Get(server.URL + "?" + queryValues.Encode())
+		if requestError != nil {
+			testingInstance.Fatalf(requestErrorFormat, requestError)
+		}
+		_, _ = io.Copy(io.Discard, httpResponse.Body)
+		_ = httpResponse.Body.Close()
+		if httpResponse.StatusCode != http.StatusOK {
+			testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+		}
+		return httpResponse
+	}
+
+	candidateCount := 0
+	for requestIndex := 0; requestIndex < abTestRequestCount; requestIndex++ {
+		httpResponse := sendRequest(url.Values{promptQueryParameter: {fmt.Sprintf(abTestPromptFormat, requestIndex)}})
+		servedModel := httpResponse.Header.Get(servedModelHeader)
+		if servedModel != (*capturedPayload)[modelField] {
+			testingInstance.Fatalf(abTestServedMismatchFormat, servedModel, (*capturedPayload)[modelField])
+		}
+		if servedModel == proxy.ModelNameGPT4o {
+			candidateCount++
+		}
+	}
+	candidateShare := candidateCount * 100 / abTestRequestCount
+	if candidateShare < abTestPercentage-abTestTolerance || candidateShare > abTestPercentage+abTestTolerance {
+		testingInstance.Fatalf(abTestShareFormat, candidateCount, abTestRequestCount, abTestPercentage)
+	}
+
+	for requestIndex := 0; requestIndex < abTestRequestCount/10; requestIndex++ {
+		httpResponse := sendRequest(url.Values{
+			promptQueryParameter: {fmt.Sprintf(abTestPromptFormat, requestIndex)},
+			modelQueryParameter:  {proxy.ModelNameGPT41},
+		})
+		if servedModel := httpResponse.Header.Get(servedModelHeader); servedModel != constants.EmptyString || (*capturedPayload)[modelField] != proxy.ModelNameGPT41 {
+			testingInstance.Fatalf(abTestServedMismatchFormat, servedModel, (*capturedPayload)[modelField])
+		}
+	}
+}
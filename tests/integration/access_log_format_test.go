@@ -0,0 +1,42 @@
+package integration_test
+
+import (
+	"bytes"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// commonLogFormatPattern matches the access log line of an authorized ping request with the key redacted.
+	commonLogFormatPattern = `^127\.0\.0\.1 - - \[\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "GET /\?key=%2A%2A%2AREDACTED%2A%2A%2A&prompt=ping HTTP/1\.1" 200 14\n$`
+	// accessLogMismatchFormat reports an access log line that does not match the Common Log Format.
+	accessLogMismatchFormat = "access log=%q want match for %s"
+)
+
+// TestAccessLogCommonLogFormat verifies that the clf access log format writes one Common Log Format line per request.
+func TestAccessLogCommonLogFormat(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var accessLog bytes.Buffer
+	originalWriter := proxy.AccessLogWriter
+	proxy.AccessLogWriter = &accessLog
+	testingInstance.Cleanup(func() { proxy.AccessLogWriter = originalWriter })
+	applicationServer := newIntegrationServer(testingInstance, newStaticOpenAIHandler(completedResponseBody), func(configuration *proxy.Configuration) {
+		configuration.AccessLogFormat = proxy.AccessLogFormatCLF
+	})
+
+	httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	_ = httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+	}
+	if !regexp.MustCompile(commonLogFormatPattern).MatchString(accessLog.String()) {
+		testingInstance.Fatalf(accessLogMismatchFormat, accessLog.String(), commonLogFormatPattern)
+	}
+}
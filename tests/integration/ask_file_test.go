@@ -0,0 +1,96 @@
+package integration_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// askFilePath accepts an uploaded prompt file.
+	askFilePath = "/ask-file"
+	// promptFileField names the multipart file field holding the prompt.
+	promptFileField = "file"
+	// promptFileName is the name of the uploaded prompt file.
+	promptFileName = "document.txt"
+	// askFileMaxPromptBytes bounds the uploaded prompt in the test.
+	askFileMaxPromptBytes = 64 * 1024
+	// uploadedPromptMismatchFormat reports a forwarded prompt that differs from the uploaded file.
+	uploadedPromptMismatchFormat = "forwarded input length=%d want=%d"
+)
+
+// newPromptUpload builds a multipart body carrying formFields followed by promptText as the prompt file and then
+// trailingFields.
+func newPromptUpload(testingInstance *testing.T, promptText string, formFields map[string]string, trailingFields map[string]string) (*bytes.Buffer, string) {
+	testingInstance.Helper()
+	var uploadBody bytes.Buffer
+	multipartWriter := multipart.NewWriter(&uploadBody)
+	for fieldName, fieldValue := range formFields {
+		_ = multipartWriter.WriteField(fieldName, fieldValue)
+	}
+	fileWriter, createError := multipartWriter.CreateFormFile(promptFileField, promptFileName)
+	if createError != nil {
+		testingInstance.Fatalf(requestErrorFormat, createError)
+	}
+	_, _ = io.WriteString(fileWriter, promptText)
+	for fieldName, fieldValue := range trailingFields {
+		_ = multipartWriter.WriteField(fieldName, fieldValue)
+	}
+	_ = multipartWriter.Close()
+	return &uploadBody, multipartWriter.FormDataContentType()
+}
+
+// TestAskFileUsesUploadedPrompt verifies that the uploaded file becomes the prompt, that the secret may be sent as a
+// form field ahead of the file, and that missing secrets and oversized files are rejected. Files of unauthorized
+// requests are never read, so they are refused with 403 whatever their size.
+func TestAskFileUsesUploadedPrompt(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	documentPrompt := strings.Repeat("Summarize this document line.\n", 1000)
+	testCases := []struct {
+		name           string
+		promptText     string
+		formFields     map[string]string
+		trailingFields map[string]string
+		expectedStatus int
+	}{
+		{name: "key form field", promptText: documentPrompt, formFields: map[string]string{keyQueryParameter: serviceSecretValue, adaptiveModelQueryParameter: proxy.ModelNameGPT41}, expectedStatus: http.StatusOK},
+		{name: "model after file", promptText: documentPrompt, formFields: map[string]string{keyQueryParameter: serviceSecretValue}, trailingFields: map[string]string{adaptiveModelQueryParameter: proxy.ModelNameGPT41}, expectedStatus: http.StatusOK},
+		{name: "missing key", promptText: documentPrompt, expectedStatus: http.StatusForbidden},
+		{name: "key after file", promptText: documentPrompt, trailingFields: map[string]string{keyQueryParameter: serviceSecretValue}, expectedStatus: http.StatusForbidden},
+		{name: "oversized file without key", promptText: strings.Repeat("x", askFileMaxPromptBytes+1), expectedStatus: http.StatusForbidden},
+		{name: "file too large", promptText: strings.Repeat("x", askFileMaxPromptBytes+1), formFields: map[string]string{keyQueryParameter: serviceSecretValue}, expectedStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.MaxPromptBytes = askFileMaxPromptBytes
+			})
+
+			uploadBody, contentType := newPromptUpload(subTest, testCase.promptText, testCase.formFields, testCase.trailingFields)
+			httpResponse, requestError := http.Post(server.URL+askFilePath, contentType, uploadBody)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				return
+			}
+			forwardedInput, _ := (*capturedPayload)[inputField].(string)
+			if forwardedInput != testCase.promptText {
+				subTest.Fatalf(uploadedPromptMismatchFormat, len(forwardedInput), len(testCase.promptText))
+			}
+		})
+	}
+}
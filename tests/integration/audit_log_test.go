@@ -0,0 +1,92 @@
+package integration_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+	"github.com/temirov/llm-proxy/internal/utils"
+)
+
+const (
+	// auditLogFileName is the audit log file created inside the test directory.
+	auditLogFileName = "audit.log"
+	// auditPromptValue is the prompt sent in audited requests.
+	auditPromptValue = "confidential question"
+	// auditEntryCountFormat reports an unexpected number of audit entries.
+	auditEntryCountFormat = "audit entries=%d want=%d"
+	// auditFieldMismatchFormat reports an unexpected audit field value.
+	auditFieldMismatchFormat = "audit field %s=%v want=%v"
+	// auditContentLeakFormat reports prompt or response content in the audit log.
+	auditContentLeakFormat = "audit log contains content %q: %s"
+)
+
+// TestAuditLogRecordsHashesWithoutContent verifies that audit entries carry hashes and metadata but no content.
+func TestAuditLogRecordsHashesWithoutContent(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auditLogPath := filepath.Join(testingInstance.TempDir(), auditLogFileName)
+	endpoints := proxy.NewEndpoints()
+	client, _ := makeHTTPClient(testingInstance, false, endpoints)
+	server := newRouterServer(testingInstance, client, endpoints, func(configuration *proxy.Configuration) {
+		configuration.QueueSize = 8
+		configuration.AuditLogPath = auditLogPath
+	})
+
+	requestURL, _ := url.Parse(server.URL)
+	queryValues := requestURL.Query()
+	queryValues.Set(promptQueryParameter, auditPromptValue)
+	queryValues.Set(keyQueryParameter, serviceSecretValue)
+	requestURL.RawQuery = queryValues.Encode()
+	httpResponse, requestError := http.Get(requestURL.String())
+	if requestError != nil {
+		testingInstance.Fatalf(getFailedFormat, requestError)
+	}
+	_ = httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+	}
+
+	auditBytes, readError := os.ReadFile(auditLogPath)
+	if readError != nil {
+		testingInstance.Fatalf(requestErrorFormat, readError)
+	}
+	for _, content := range []string{auditPromptValue, integrationOKBody} {
+		if strings.Contains(string(auditBytes), content) {
+			testingInstance.Fatalf(auditContentLeakFormat, content, string(auditBytes))
+		}
+	}
+	var auditEntries []map[string]any
+	lineScanner := bufio.NewScanner(strings.NewReader(string(auditBytes)))
+	for lineScanner.Scan() {
+		var auditEntry map[string]any
+		if json.Unmarshal(lineScanner.Bytes(), &auditEntry) == nil {
+			auditEntries = append(auditEntries, auditEntry)
+		}
+	}
+	if len(auditEntries) != 1 {
+		testingInstance.Fatalf(auditEntryCountFormat, len(auditEntries), 1)
+	}
+	expectedFields := map[string]any{
+		"model":         proxy.DefaultModel,
+		"prompt_hash":   utils.ContentHash(auditPromptValue),
+		"response_hash": utils.ContentHash(integrationOKBody),
+		"status":        float64(http.StatusOK),
+	}
+	for fieldName, expectedValue := range expectedFields {
+		if auditEntries[0][fieldName] != expectedValue {
+			testingInstance.Fatalf(auditFieldMismatchFormat, fieldName, auditEntries[0][fieldName], expectedValue)
+		}
+	}
+	for _, requiredField := range []string{"timestamp", "client_fingerprint", "latency_ms"} {
+		if _, present := auditEntries[0][requiredField]; !present {
+			testingInstance.Fatalf(auditFieldMismatchFormat, requiredField, nil, "present")
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// authorizationHeader carries the client key as a bearer token.
+	authorizationHeader = "Authorization"
+	// bearerTokenPrefix precedes the client key in the Authorization header.
+	bearerTokenPrefix = "Bearer "
+)
+
+// TestAuthorizationHeaderSecret verifies that the client key is accepted from a bearer header and preferred over the query.
+func TestAuthorizationHeaderSecret(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		headerKey      string
+		queryKey       string
+		expectedStatus int
+	}{
+		{name: "header only", headerKey: serviceSecretValue, expectedStatus: http.StatusOK},
+		{name: "query only", queryKey: serviceSecretValue, expectedStatus: http.StatusOK},
+		{name: "both with valid header", headerKey: serviceSecretValue, queryKey: unknownServiceSecret, expectedStatus: http.StatusOK},
+		{name: "both with invalid header", headerKey: unknownServiceSecret, queryKey: serviceSecretValue, expectedStatus: http.StatusForbidden},
+		{name: "neither", expectedStatus: http.StatusForbidden},
+	}
+	applicationServer := newIntegrationServer(testingInstance, newStaticOpenAIHandler(completedResponseBody), nil)
+
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			requestURL, _ := url.Parse(applicationServer.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			if testCase.queryKey != "" {
+				queryValues.Set(keyQueryParameter, testCase.queryKey)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpRequest, buildError := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+			if buildError != nil {
+				subTest.Fatalf(requestErrorFormat, buildError)
+			}
+			if testCase.headerKey != "" {
+				httpRequest.Header.Set(authorizationHeader, bearerTokenPrefix+testCase.headerKey)
+			}
+			httpResponse, requestError := http.DefaultClient.Do(httpRequest)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				responseBody, _ := io.ReadAll(httpResponse.Body)
+				subTest.Fatalf(unexpectedStatusFormat, httpResponse.StatusCode, string(responseBody))
+			}
+		})
+	}
+}
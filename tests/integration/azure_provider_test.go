@@ -0,0 +1,138 @@
+package integration_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// azureAPIKeyHeader carries the API key of an Azure OpenAI resource.
+	azureAPIKeyHeader = "api-key"
+	// azureAPIVersionParameter selects the Azure OpenAI API version.
+	azureAPIVersionParameter = "api-version"
+	// azureAPIVersionValue is the API version configured for the test.
+	azureAPIVersionValue = "2025-03-01-preview"
+	// azureDeploymentPath is the path at which the default model's deployment creates responses.
+	azureDeploymentPath = "/openai/deployments/" + proxy.ModelNameGPT41 + "/responses"
+	// azureStoredResponsePath is the path under which the queued response is continued and polled.
+	azureStoredResponsePath = "/openai/responses/resp_queued"
+	// azureRequestMismatchFormat reports an upstream request not shaped for Azure OpenAI.
+	azureRequestMismatchFormat = "%s %s api-key=%q authorization=%q api-version=%q"
+	// azurePathMismatchFormat reports an upstream request sent to an unexpected path.
+	azurePathMismatchFormat = "request %d: %s %s want %s %s"
+)
+
+// capturedAzureRequest records the URL and authentication of one upstream call.
+type capturedAzureRequest struct {
+	method        string
+	path          string
+	apiKey        string
+	authorization string
+	apiVersion    string
+}
+
+// TestAzureProviderRouting verifies that the azure provider creates responses at the deployment named after the model,
+// polls the stored response on the resource, and authenticates every call with the api-key header and api-version.
+func TestAzureProviderRouting(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var capturedMutex sync.Mutex
+	var capturedRequests []capturedAzureRequest
+	azureServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		_, _ = io.Copy(io.Discard, httpRequest.Body)
+		capturedMutex.Lock()
+		capturedRequests = append(capturedRequests, capturedAzureRequest{
+			method:        httpRequest.Method,
+			path:          httpRequest.URL.Path,
+			apiKey:        httpRequest.Header.Get(azureAPIKeyHeader),
+			authorization: httpRequest.Header.Get(authorizationHeader),
+			apiVersion:    httpRequest.URL.Query().Get(azureAPIVersionParameter),
+		})
+		capturedMutex.Unlock()
+		responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+		if httpRequest.Method == http.MethodPost {
+			_, _ = io.WriteString(responseWriter, queuedResponseBody)
+			return
+		}
+		_, _ = io.WriteString(responseWriter, completedResponseBody)
+	}))
+	testingInstance.Cleanup(azureServer.Close)
+	originalClient := proxy.HTTPClient
+	proxy.HTTPClient = azureServer.Client()
+	testingInstance.Cleanup(func() { proxy.HTTPClient = originalClient })
+	router, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret:   serviceSecretValue,
+		OpenAIKey:       openAIKeyValue,
+		LogLevel:        logLevelDebug,
+		WorkerCount:     1,
+		QueueSize:       4,
+		Provider:        proxy.ProviderAzure,
+		AzureAPIVersion: azureAPIVersionValue,
+		OpenAIBaseURL:   azureServer.URL,
+	}, newLogger(testingInstance))
+	if buildRouterError != nil {
+		testingInstance.Fatalf(buildRouterFailedFormat, buildRouterError)
+	}
+	applicationServer := httptest.NewServer(router)
+	testingInstance.Cleanup(applicationServer.Close)
+
+	httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	_ = httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+	}
+	capturedMutex.Lock()
+	defer capturedMutex.Unlock()
+	if len(capturedRequests) < 2 {
+		testingInstance.Fatalf(upstreamRequestCountFormat, len(capturedRequests), 2)
+	}
+	if capturedRequests[0].method != http.MethodPost || capturedRequests[0].path != azureDeploymentPath {
+		testingInstance.Fatalf(azurePathMismatchFormat, 0, capturedRequests[0].method, capturedRequests[0].path, http.MethodPost, azureDeploymentPath)
+	}
+	for requestIndex, capturedRequest := range capturedRequests {
+		if requestIndex > 0 && !strings.HasPrefix(capturedRequest.path, azureStoredResponsePath) {
+			testingInstance.Fatalf(azurePathMismatchFormat, requestIndex, capturedRequest.method, capturedRequest.path, capturedRequest.method, azureStoredResponsePath)
+		}
+		if capturedRequest.apiKey != openAIKeyValue || capturedRequest.authorization != "" || capturedRequest.apiVersion != azureAPIVersionValue {
+			testingInstance.Fatalf(azureRequestMismatchFormat, capturedRequest.method, capturedRequest.path, capturedRequest.apiKey, capturedRequest.authorization, capturedRequest.apiVersion)
+		}
+	}
+}
+
+// TestAzureProviderValidation verifies that an unknown provider and an azure provider without a base URL are rejected.
+func TestAzureProviderValidation(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name          string
+		provider      string
+		expectedError error
+	}{
+		{name: "unknown provider", provider: "bedrock", expectedError: proxy.ErrInvalidProvider},
+		{name: "azure without base url", provider: proxy.ProviderAzure, expectedError: proxy.ErrMissingAzureBaseURL},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			_, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+				ServiceSecret: serviceSecretValue,
+				OpenAIKey:     openAIKeyValue,
+				LogLevel:      logLevelDebug,
+				WorkerCount:   1,
+				QueueSize:     4,
+				Provider:      testCase.provider,
+			}, newLogger(subTest))
+			if !errors.Is(buildRouterError, testCase.expectedError) {
+				subTest.Fatalf(expectedErrorFormat, testCase.expectedError, buildRouterError)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// candidateCountQueryParameter requests several candidate answers.
+	candidateCountQueryParameter = "n"
+	// candidateAnswerFormat produces the answer text of the numbered upstream call.
+	candidateAnswerFormat = "candidate %d"
+	// candidateResponseBodyFormat is the upstream response carrying the numbered answer.
+	candidateResponseBodyFormat = `{"id":"resp_candidate","status":"completed","output":[{"type":"message","role":"assistant","content":[{"type":"output_text","text":"` + candidateAnswerFormat + `"}]}]}`
+	// candidateResponseMismatchFormat reports an unexpected response field or upstream call count.
+	candidateResponseMismatchFormat = "response=%v calls=%d want response=%v calls=%d"
+)
+
+// TestCandidateCount verifies that n=2 returns both candidate answers as a JSON array, that n=1 keeps the single
+// answer string, and that out-of-range or streamed counts are rejected before reaching the upstream.
+func TestCandidateCount(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name             string
+		candidateCount   string
+		stream           bool
+		expectedStatus   int
+		expectedResponse any
+		expectedCalls    int64
+	}{
+		{name: "two candidates", candidateCount: "2", expectedStatus: http.StatusOK, expectedResponse: []any{"candidate 1", "candidate 2"}, expectedCalls: 2},
+		{name: "one candidate", candidateCount: "1", expectedStatus: http.StatusOK, expectedResponse: "candidate 1", expectedCalls: 1},
+		{name: "omitted", expectedStatus: http.StatusOK, expectedResponse: "candidate 1", expectedCalls: 1},
+		{name: "above maximum", candidateCount: "5", expectedStatus: http.StatusBadRequest},
+		{name: "zero", candidateCount: "0", expectedStatus: http.StatusBadRequest},
+		{name: "streamed", candidateCount: "2", stream: true, expectedStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			var upstreamCalls atomic.Int64
+			openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				callNumber := upstreamCalls.Add(1)
+				responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+				_, _ = io.WriteString(responseWriter, fmt.Sprintf(candidateResponseBodyFormat, callNumber))
+			})
+			applicationServer := newIntegrationServer(subTest, openAIHandler, nil)
+
+			requestURL, _ := url.Parse(applicationServer.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(formatQueryParameter, contentTypeJSON)
+			if testCase.candidateCount != "" {
+				queryValues.Set(candidateCountQueryParameter, testCase.candidateCount)
+			}
+			if testCase.stream {
+				queryValues.Set(streamField, "1")
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				if upstreamCalls.Load() != 0 {
+					subTest.Fatalf(candidateResponseMismatchFormat, nil, upstreamCalls.Load(), nil, 0)
+				}
+				return
+			}
+			var responseEnvelope map[string]any
+			if decodeError := json.Unmarshal(responseBytes, &responseEnvelope); decodeError != nil {
+				subTest.Fatalf(requestErrorFormat, decodeError)
+			}
+			if !reflect.DeepEqual(responseEnvelope["response"], testCase.expectedResponse) || upstreamCalls.Load() != testCase.expectedCalls {
+				subTest.Fatalf(candidateResponseMismatchFormat, responseEnvelope["response"], upstreamCalls.Load(), testCase.expectedResponse, testCase.expectedCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// capabilitiesPath serves the capability map of a model.
+	capabilitiesPath = "/capabilities"
+	// capabilitiesMismatchFormat reports an unexpected capability map.
+	capabilitiesMismatchFormat = "capabilities=%+v want %+v"
+)
+
+// capabilitiesResponse mirrors the JSON document served by the capabilities endpoint.
+type capabilitiesResponse struct {
+	Model                string   `json:"model"`
+	AllowedRequestFields []string `json:"allowed_request_fields"`
+	SupportsTemperature  bool     `json:"supports_temperature"`
+	SupportsTools        bool     `json:"supports_tools"`
+	SupportsReasoning    bool     `json:"supports_reasoning"`
+}
+
+// TestCapabilities verifies the capability map reported for models with and without temperature support.
+func TestCapabilities(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	applicationServer := newIntegrationServer(testingInstance, newStaticOpenAIHandler(completedResponseBody), func(configuration *proxy.Configuration) {
+		configuration.QueueSize = 1
+	})
+
+	testCases := []struct {
+		name     string
+		expected capabilitiesResponse
+	}{
+		{
+			name: proxy.ModelNameGPT5,
+			expected: capabilitiesResponse{
+				Model:                proxy.ModelNameGPT5,
+				AllowedRequestFields: proxy.SchemaGPT5.AllowedRequestFields,
+				SupportsTools:        true,
+				SupportsReasoning:    true,
+			},
+		},
+		{
+			name: proxy.ModelNameGPT4oMini,
+			expected: capabilitiesResponse{
+				Model:                proxy.ModelNameGPT4oMini,
+				AllowedRequestFields: proxy.SchemaGPT4oMini.AllowedRequestFields,
+				SupportsTemperature:  true,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			requestURL, _ := url.Parse(applicationServer.URL + capabilitiesPath)
+			queryValues := requestURL.Query()
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, testCase.name)
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			var capabilities capabilitiesResponse
+			if decodeError := json.NewDecoder(httpResponse.Body).Decode(&capabilities); decodeError != nil {
+				subTest.Fatalf(requestErrorFormat, decodeError)
+			}
+			if capabilities.Model != testCase.expected.Model ||
+				!slices.Equal(capabilities.AllowedRequestFields, testCase.expected.AllowedRequestFields) ||
+				capabilities.SupportsTemperature != testCase.expected.SupportsTemperature ||
+				capabilities.SupportsTools != testCase.expected.SupportsTools ||
+				capabilities.SupportsReasoning != testCase.expected.SupportsReasoning {
+				subTest.Fatalf(capabilitiesMismatchFormat, capabilities, testCase.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// chatCompletionText is the assistant content of the chat-completions stub.
+	chatCompletionText = "chat completion answer"
+	// chatCompletionUpstreamCallsFormat reports extra upstream calls for a final chat-completions body.
+	chatCompletionUpstreamCallsFormat = "upstream calls=%d want=1"
+)
+
+// TestChatCompletionsFallback verifies that a chat-completions-shaped body from an OpenAI-compatible upstream is extracted.
+func TestChatCompletionsFallback(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name         string
+		responseBody string
+	}{
+		{name: "string content", responseBody: `{"id":"chatcmpl-1","object":"chat.completion","choices":[{"index":0,"message":{"role":"assistant","content":"` + chatCompletionText + `"},"finish_reason":"stop"}]}`},
+		{name: "content parts", responseBody: `{"id":"chatcmpl-2","object":"chat.completion","choices":[{"index":0,"message":{"role":"assistant","content":[{"type":"text","text":"` + chatCompletionText + `"}]},"finish_reason":"stop"}]}`},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			var upstreamCalls atomic.Int32
+			openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				upstreamCalls.Add(1)
+				responseWriter.Header().Set("Content-Type", contentTypeJSON)
+				_, _ = io.WriteString(responseWriter, testCase.responseBody)
+			})
+			applicationServer := newIntegrationServer(subTest, openAIHandler, nil)
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != chatCompletionText {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+			}
+			if upstreamCalls.Load() != 1 {
+				subTest.Fatalf(chatCompletionUpstreamCallsFormat, upstreamCalls.Load())
+			}
+		})
+	}
+}
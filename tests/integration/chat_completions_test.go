@@ -0,0 +1,99 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// chatCompletionsPath is the OpenAI-compatible chat completions endpoint of the proxy.
+	chatCompletionsPath = "/v1/chat/completions"
+	// chatCompletionsSystemMessage is the system message sent in the test body.
+	chatCompletionsSystemMessage = "Answer tersely."
+	// chatCompletionsUserMessage is the user message sent in the test body.
+	chatCompletionsUserMessage = "Say hello."
+	// chatCompletionsRequestBody carries a system and a user message.
+	chatCompletionsRequestBody = `{"model":"` + proxy.ModelNameGPT41 + `","temperature":0.2,"messages":[{"role":"system","content":"` + chatCompletionsSystemMessage + `"},{"role":"user","content":"` + chatCompletionsUserMessage + `"}]}`
+	// chatCompletionObjectType is the object type of a non-streamed chat completion.
+	chatCompletionObjectType = "chat.completion"
+	// chatCompletionShapeFormat reports a response that is not a well-formed chat completion.
+	chatCompletionShapeFormat = "malformed chat completion: %s"
+	// chatCompletionInputFormat reports a forwarded input that does not combine the messages.
+	chatCompletionInputFormat = "forwarded input=%q want=%q"
+)
+
+// chatCompletionBody mirrors the fields of a chat completion checked by the test.
+type chatCompletionBody struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Model   string `json:"model"`
+	Choices []struct {
+		Index   int `json:"index"`
+		Message struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// TestChatCompletionsEndpoint verifies that a two-message chat completions body is forwarded as the combined prompt,
+// answered with a chat completion, and rejected without the client key.
+func TestChatCompletionsEndpoint(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "bearer key", authorization: bearerTokenPrefix + serviceSecretValue, expectedStatus: http.StatusOK},
+		{name: "missing key", expectedStatus: http.StatusForbidden},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, nil)
+
+			httpRequest, _ := http.NewRequest(http.MethodPost, server.URL+chatCompletionsPath, strings.NewReader(chatCompletionsRequestBody))
+			httpRequest.Header.Set("Content-Type", contentTypeJSON)
+			if testCase.authorization != "" {
+				httpRequest.Header.Set(authorizationHeader, testCase.authorization)
+			}
+			httpResponse, requestError := http.DefaultClient.Do(httpRequest)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var completion chatCompletionBody
+			if decodeError := json.Unmarshal(responseBytes, &completion); decodeError != nil {
+				subTest.Fatalf(chatCompletionShapeFormat, string(responseBytes))
+			}
+			if !strings.HasPrefix(completion.ID, "chatcmpl-") || completion.Object != chatCompletionObjectType || completion.Model != proxy.ModelNameGPT41 || len(completion.Choices) != 1 {
+				subTest.Fatalf(chatCompletionShapeFormat, string(responseBytes))
+			}
+			choice := completion.Choices[0]
+			if choice.Index != 0 || choice.Message.Role != "assistant" || choice.Message.Content != integrationOKBody || choice.FinishReason != "stop" {
+				subTest.Fatalf(chatCompletionShapeFormat, string(responseBytes))
+			}
+			expectedInput := chatCompletionsSystemMessage + "\n\n" + chatCompletionsUserMessage
+			if forwardedInput, _ := (*capturedPayload)[inputField].(string); forwardedInput != expectedInput {
+				subTest.Fatalf(chatCompletionInputFormat, forwardedInput, expectedInput)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// circuitOpenMessage is returned while the upstream circuit breaker is open.
+	circuitOpenMessage = "upstream unavailable; circuit open"
+	// circuitBreakerThreshold is the number of failed upstream calls that opens the circuit in the test.
+	circuitBreakerThreshold = 2
+	// circuitBreakerCooldownSeconds is the open-circuit cooldown used in the test.
+	circuitBreakerCooldownSeconds = 1
+	// circuitBreakerCallsFormat reports an unexpected number of upstream calls.
+	circuitBreakerCallsFormat = "upstream calls=%d want=%d"
+)
+
+// TestCircuitBreakerTripsAndRecovers verifies that repeated upstream failures open the circuit, that an open circuit
+// rejects requests without calling the upstream, and that a successful probe after the cooldown closes it again.
+func TestCircuitBreakerTripsAndRecovers(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var upstreamMutex sync.Mutex
+	upstreamFailing := true
+	upstreamCalls := 0
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		_, _ = io.Copy(io.Discard, httpRequest.Body)
+		upstreamMutex.Lock()
+		upstreamCalls++
+		failing := upstreamFailing
+		upstreamMutex.Unlock()
+		if failing {
+			responseWriter.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		responseWriter.Header().Set("Content-Type", contentTypeJSON)
+		_, _ = io.WriteString(responseWriter, completedResponseBody)
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.RetryInitialIntervalMilliseconds = 10
+		configuration.RetryMaxElapsedMilliseconds = 50
+		configuration.CircuitBreakerFailureThreshold = circuitBreakerThreshold
+		configuration.CircuitBreakerCooldownSeconds = circuitBreakerCooldownSeconds
+	})
+
+	sendRequest := func(expectedStatus int, expectedBody string) {
+		testingInstance.Helper()
+		httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+		if requestError != nil {
+			testingInstance.Fatalf(requestErrorFormat, requestError)
+		}
+		defer httpResponse.Body.Close()
+		responseBytes, _ := io.ReadAll(httpResponse.Body)
+		if httpResponse.StatusCode != expectedStatus || string(responseBytes) != expectedBody {
+			testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, expectedStatus, string(responseBytes))
+		}
+		if expectedStatus == http.StatusServiceUnavailable && httpResponse.Header.Get(retryAfterHeader) == constants.EmptyString {
+			testingInstance.Fatalf(retryAfterInvalidFormat, httpResponse.Header.Get(retryAfterHeader))
+		}
+	}
+	upstreamCallCount := func() int {
+		upstreamMutex.Lock()
+		defer upstreamMutex.Unlock()
+		return upstreamCalls
+	}
+
+	for attemptIndex := 0; attemptIndex < circuitBreakerThreshold; attemptIndex++ {
+		sendRequest(http.StatusBadGateway, upstreamRequestErrorMessage)
+	}
+	callsWhenOpened := upstreamCallCount()
+	sendRequest(http.StatusServiceUnavailable, circuitOpenMessage)
+	if actualCalls := upstreamCallCount(); actualCalls != callsWhenOpened {
+		testingInstance.Fatalf(circuitBreakerCallsFormat, actualCalls, callsWhenOpened)
+	}
+
+	upstreamMutex.Lock()
+	upstreamFailing = false
+	upstreamMutex.Unlock()
+	time.Sleep(circuitBreakerCooldownSeconds*time.Second + 100*time.Millisecond)
+	sendRequest(http.StatusOK, integrationOKBody)
+	sendRequest(http.StatusOK, integrationOKBody)
+	if actualCalls := upstreamCallCount(); actualCalls != callsWhenOpened+2 {
+		testingInstance.Fatalf(circuitBreakerCallsFormat, actualCalls, callsWhenOpened+2)
+	}
+}
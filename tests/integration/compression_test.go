@@ -0,0 +1,96 @@
+package integration_test
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// acceptEncodingHeader lists the content codings the client accepts.
+	acceptEncodingHeader = "Accept-Encoding"
+	// contentEncodingHeader names the content coding of the response body.
+	contentEncodingHeader = "Content-Encoding"
+	// contentEncodingMismatchFormat reports an unexpected Content-Encoding header.
+	contentEncodingMismatchFormat = "Content-Encoding=%q want %q"
+)
+
+// TestCompressionEncodesResponses verifies that, with EnableCompression, answers are compressed with the coding the
+// client accepts while keeping their content type, and sent as is when the client accepts none.
+func TestCompressionEncodesResponses(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name             string
+		acceptEncoding   string
+		expectedEncoding string
+		decode           func(io.Reader) (io.Reader, error)
+	}{
+		{
+			name:             "gzip",
+			acceptEncoding:   "gzip, deflate",
+			expectedEncoding: "gzip",
+			decode:           func(body io.Reader) (io.Reader, error) { return gzip.NewReader(body) },
+		},
+		{
+			name:             "deflate",
+			acceptEncoding:   "deflate, gzip;q=0",
+			expectedEncoding: "deflate",
+			decode:           func(body io.Reader) (io.Reader, error) { return flate.NewReader(body), nil },
+		},
+		{
+			name:             "identity",
+			acceptEncoding:   "identity",
+			expectedEncoding: "",
+			decode:           func(body io.Reader) (io.Reader, error) { return body, nil },
+		},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, _ := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.EnableCompression = true
+			})
+
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			requestURL.RawQuery = queryValues.Encode()
+			httpRequest, _ := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+			httpRequest.Header.Set(acceptEncodingHeader, testCase.acceptEncoding)
+			// A custom Accept-Encoding header stops the transport from decompressing the body itself.
+			httpResponse, requestError := http.DefaultClient.Do(httpRequest)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			if contentEncoding := httpResponse.Header.Get(contentEncodingHeader); contentEncoding != testCase.expectedEncoding {
+				subTest.Fatalf(contentEncodingMismatchFormat, contentEncoding, testCase.expectedEncoding)
+			}
+			if contentType := httpResponse.Header.Get(contentTypeHeaderKey); contentType != contentTypePlainText {
+				subTest.Fatalf(contentTypeMismatchFormat, contentType, contentTypePlainText)
+			}
+			decodedBody, decodeError := testCase.decode(httpResponse.Body)
+			if decodeError != nil {
+				subTest.Fatalf("decode body: %v", decodeError)
+			}
+			responseBytes, readError := io.ReadAll(decodedBody)
+			if readError != nil {
+				subTest.Fatalf("read body: %v", readError)
+			}
+			if string(responseBytes) != integrationOKBody {
+				subTest.Fatalf(bodyMismatchFormat, string(responseBytes), integrationOKBody)
+			}
+		})
+	}
+}
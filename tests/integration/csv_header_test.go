@@ -0,0 +1,80 @@
+package integration_test
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// csvHeaderQueryParameter adds a header row to CSV responses.
+	csvHeaderQueryParameter = "header"
+	// lfQuotedResponseText is crlfQuotedResponseText as decoded by encoding/csv, which reads CRLF inside a field as LF.
+	lfQuotedResponseText = "line one\nsay \"hi\""
+	// crlfQuotedResponseBody is a completed upstream response carrying crlfQuotedResponseText.
+	crlfQuotedResponseBody = `{"id":"resp_crlf","status":"completed","output_text":"line one\r\nsay \"hi\""}`
+	// csvRecordsMismatchFormat reports CSV records that differ from the expected ones.
+	csvRecordsMismatchFormat = "records=%q error=%v want %q"
+)
+
+// TestCSVHeaderRow verifies the optional header row and that CRLF and quotes inside the answer survive a CSV round trip.
+func TestCSVHeaderRow(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name            string
+		includeHeader   bool
+		expectedBody    string
+		expectedRecords [][]string
+	}{
+		{
+			name:            "with header",
+			includeHeader:   true,
+			expectedBody:    "request,response\n" + promptValue + ",\"line one\r\nsay \"\"hi\"\"\"\n",
+			expectedRecords: [][]string{{"request", "response"}, {promptValue, lfQuotedResponseText}},
+		},
+		{
+			name:            "without header",
+			expectedBody:    "\"line one\r\nsay \"\"hi\"\"\"\n",
+			expectedRecords: [][]string{{lfQuotedResponseText}},
+		},
+	}
+	applicationServer := newIntegrationServer(testingInstance, newStaticOpenAIHandler(crlfQuotedResponseBody), nil)
+
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			requestURL, _ := url.Parse(applicationServer.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(formatQueryParameter, contentTypeCSV)
+			if testCase.includeHeader {
+				queryValues.Set(csvHeaderQueryParameter, "1")
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+			}
+			if string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(bodyMismatchFormat, string(responseBytes), testCase.expectedBody)
+			}
+			csvReader := csv.NewReader(strings.NewReader(string(responseBytes)))
+			csvReader.FieldsPerRecord = -1
+			decodedRecords, decodeError := csvReader.ReadAll()
+			if decodeError != nil || !slices.EqualFunc(decodedRecords, testCase.expectedRecords, slices.Equal[[]string]) {
+				subTest.Fatalf(csvRecordsMismatchFormat, decodedRecords, decodeError, testCase.expectedRecords)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// contentTypeCSV requests a CSV response.
+	contentTypeCSV = "text/csv"
+	// csvModeQueryParameter selects the CSV layout.
+	csvModeQueryParameter = "csv_mode"
+	// csvPromptQueryParameter adds the prompt column in row mode.
+	csvPromptQueryParameter = "csv_prompt"
+	// multiLineResponseBody is a completed upstream response whose text spans several lines, one of them quoted.
+	multiLineResponseBody = `{"id":"resp_lines","status":"completed","output_text":"first line\nsecond \"quoted\" line\n\nthird line","output":[{"type":"message","role":"assistant","content":[{"type":"output_text","text":"first line"}]}]}`
+)
+
+// TestCSVRowsMode verifies that csv_mode=rows emits one CSV row per response line while single keeps one cell.
+func TestCSVRowsMode(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		csvMode        string
+		csvPrompt      string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "default single cell", expectedStatus: http.StatusOK, expectedBody: "\"first line\nsecond \"\"quoted\"\" line\n\nthird line\"\n"},
+		{name: "rows", csvMode: "rows", expectedStatus: http.StatusOK, expectedBody: "first line\n\"second \"\"quoted\"\" line\"\nthird line\n"},
+		{name: "rows with prompt", csvMode: "rows", csvPrompt: "1", expectedStatus: http.StatusOK, expectedBody: promptValue + ",first line\n" + promptValue + ",\"second \"\"quoted\"\" line\"\n" + promptValue + ",third line\n"},
+		{name: "unknown mode", csvMode: "columns", expectedStatus: http.StatusBadRequest},
+	}
+	applicationServer := newIntegrationServer(testingInstance, newStaticOpenAIHandler(multiLineResponseBody), nil)
+
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			requestURL, _ := url.Parse(applicationServer.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(formatQueryParameter, contentTypeCSV)
+			if testCase.csvMode != "" {
+				queryValues.Set(csvModeQueryParameter, testCase.csvMode)
+			}
+			if testCase.csvPrompt != "" {
+				queryValues.Set(csvPromptQueryParameter, testCase.csvPrompt)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+			if testCase.expectedStatus == http.StatusOK && string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(bodyMismatchFormat, string(responseBytes), testCase.expectedBody)
+			}
+		})
+	}
+}
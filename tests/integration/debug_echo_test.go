@@ -0,0 +1,113 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+	"github.com/temirov/llm-proxy/internal/utils"
+)
+
+const (
+	// debugEchoQueryParameter requests the resolved parameter echo.
+	debugEchoQueryParameter = "debug_echo"
+	// formatQueryParameter selects the response format.
+	formatQueryParameter = "format"
+	// modelQueryParameter selects the model.
+	modelQueryParameter = "model"
+	// logLevelInfo represents the info logging level.
+	logLevelInfo = "info"
+	// echoMismatchFormat reports an unexpected echo object.
+	echoMismatchFormat = "echo=%+v want=%+v"
+	// echoPresenceFormat reports an unexpected echo presence.
+	echoPresenceFormat = "echo present=%v want=%v body=%s"
+)
+
+// echoObject mirrors the echo object returned in JSON responses.
+type echoObject struct {
+	Model                   string   `json:"model"`
+	WebSearch               bool     `json:"web_search"`
+	SystemPromptFingerprint string   `json:"system_prompt_fingerprint"`
+	Format                  string   `json:"format"`
+	Overrides               []string `json:"overrides"`
+}
+
+// TestDebugEchoReflectsResolvedParameters verifies that debug_echo returns the interpreted request in debug mode only.
+func TestDebugEchoReflectsResolvedParameters(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name         string
+		logLevel     string
+		expectEcho   bool
+		expectedEcho echoObject
+	}{
+		{
+			name:       "debug level echoes",
+			logLevel:   logLevelDebug,
+			expectEcho: true,
+			expectedEcho: echoObject{
+				Model:                   proxy.ModelNameGPT4o,
+				WebSearch:               true,
+				SystemPromptFingerprint: utils.Fingerprint(overrideSystemPrompt),
+				Format:                  contentTypeJSON,
+				Overrides:               []string{systemPromptQueryParameter, modelQueryParameter},
+			},
+		},
+		{name: "info level omits echo", logLevel: logLevelInfo, expectEcho: false},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, _ := makeHTTPClient(subTest, true, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.LogLevel = testCase.logLevel
+				configuration.QueueSize = 8
+			})
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(modelQueryParameter, proxy.ModelNameGPT4o)
+			queryValues.Set(webSearchQueryParameter, "1")
+			queryValues.Set(systemPromptQueryParameter, overrideSystemPrompt)
+			queryValues.Set(formatQueryParameter, contentTypeJSON)
+			queryValues.Set(debugEchoQueryParameter, "1")
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(getFailedFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(unexpectedStatusFormat, httpResponse.StatusCode, string(responseBytes))
+			}
+			var decodedResponse struct {
+				Echo *echoObject `json:"echo"`
+			}
+			_ = json.Unmarshal(responseBytes, &decodedResponse)
+			if (decodedResponse.Echo != nil) != testCase.expectEcho {
+				subTest.Fatalf(echoPresenceFormat, decodedResponse.Echo != nil, testCase.expectEcho, string(responseBytes))
+			}
+			if !testCase.expectEcho {
+				return
+			}
+			actualEcho := *decodedResponse.Echo
+			expectedEcho := testCase.expectedEcho
+			if actualEcho.Model != expectedEcho.Model || actualEcho.WebSearch != expectedEcho.WebSearch ||
+				actualEcho.SystemPromptFingerprint != expectedEcho.SystemPromptFingerprint || actualEcho.Format != expectedEcho.Format ||
+				len(actualEcho.Overrides) != len(expectedEcho.Overrides) {
+				subTest.Fatalf(echoMismatchFormat, actualEcho, expectedEcho)
+			}
+			for overrideIndex := range expectedEcho.Overrides {
+				if actualEcho.Overrides[overrideIndex] != expectedEcho.Overrides[overrideIndex] {
+					subTest.Fatalf(echoMismatchFormat, actualEcho, expectedEcho)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package integration_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+// TestConfiguredDefaultModel verifies that requests without a model use the configured default.
+func TestConfiguredDefaultModel(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	endpoints := proxy.NewEndpoints()
+	client, captured := makeHTTPClient(testingInstance, false, endpoints)
+	server := newRouterServer(testingInstance, client, endpoints, func(configuration *proxy.Configuration) {
+		configuration.DefaultModel = proxy.ModelNameGPT4oMini
+	})
+
+	httpResponse, requestError := http.Get(server.URL + "?prompt=ping&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	_ = httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+	}
+	if (*captured)[modelField] != proxy.ModelNameGPT4oMini {
+		testingInstance.Fatalf(modelMismatchFormat, (*captured)[modelField], proxy.ModelNameGPT4oMini)
+	}
+}
+
+// TestUnknownDefaultModelRejected verifies that BuildRouter refuses an unrecognized default model.
+func TestUnknownDefaultModelRejected(testingInstance *testing.T) {
+	_, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret: serviceSecretValue,
+		OpenAIKey:     openAIKeyValue,
+		LogLevel:      logLevelDebug,
+		WorkerCount:   1,
+		QueueSize:     1,
+		DefaultModel:  unmappedModelName,
+		Endpoints:     proxy.NewEndpoints(),
+	}, newLogger(testingInstance))
+	if !errors.Is(buildRouterError, proxy.ErrUnknownModel) {
+		testingInstance.Fatalf(expectedErrorFormat, proxy.ErrUnknownModel, buildRouterError)
+	}
+}
@@ -0,0 +1,63 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// warningHeader carries the deprecation warning.
+	warningHeader = "Warning"
+	// deprecationWarningCode is the RFC 7234 miscellaneous persistent warning code.
+	deprecationWarningCode = "299 "
+	// warningHeaderMismatchFormat reports an unexpected Warning header for a model.
+	warningHeaderMismatchFormat = "model %s Warning=%q want present=%v"
+)
+
+// TestDeprecatedModelWarning verifies that a deprecated model is still served with a Warning header and that other
+// models carry no warning.
+func TestDeprecatedModelWarning(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name          string
+		model         string
+		expectWarning bool
+	}{
+		{name: "deprecated model", model: proxy.ModelNameGPT41, expectWarning: true},
+		{name: "current model", model: proxy.ModelNameGPT5Mini, expectWarning: false},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, _ := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.DeprecatedModels = []string{proxy.ModelNameGPT41}
+			})
+
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, testCase.model)
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			warningValue := httpResponse.Header.Get(warningHeader)
+			warningPresent := strings.HasPrefix(warningValue, deprecationWarningCode) && strings.Contains(warningValue, testCase.model)
+			if warningPresent != testCase.expectWarning || (!testCase.expectWarning && warningValue != "") {
+				subTest.Fatalf(warningHeaderMismatchFormat, testCase.model, warningValue, testCase.expectWarning)
+			}
+		})
+	}
+}
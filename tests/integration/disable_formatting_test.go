@@ -0,0 +1,67 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// acceptHeaderName negotiates the response format.
+	acceptHeaderName = "Accept"
+	// contentTypePlainText is the content type of raw model text responses.
+	contentTypePlainText = "text/plain; charset=utf-8"
+	// contentTypeMismatchFormat reports an unexpected response content type.
+	contentTypeMismatchFormat = "content type=%q want=%q"
+)
+
+// TestDisableFormattingReturnsRawText verifies that formatting requests are ignored when formatting is disabled.
+func TestDisableFormattingReturnsRawText(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	applicationServer := newIntegrationServer(testingInstance, newStaticOpenAIHandler(completedResponseBody), func(configuration *proxy.Configuration) {
+		configuration.DisableFormatting = true
+	})
+
+	testCases := []struct {
+		name         string
+		format       string
+		acceptHeader string
+	}{
+		{name: "json format parameter", format: contentTypeJSON},
+		{name: "csv format parameter", format: contentTypeCSV},
+		{name: "json accept header", acceptHeader: contentTypeJSON},
+		{name: "csv accept header", acceptHeader: contentTypeCSV},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			requestURL, _ := url.Parse(applicationServer.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			if testCase.format != "" {
+				queryValues.Set(formatQueryParameter, testCase.format)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpRequest, _ := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+			if testCase.acceptHeader != "" {
+				httpRequest.Header.Set(acceptHeaderName, testCase.acceptHeader)
+			}
+			httpResponse, requestError := http.DefaultClient.Do(httpRequest)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != integrationOKBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+			}
+			if contentType := httpResponse.Header.Get("Content-Type"); contentType != contentTypePlainText {
+				subTest.Fatalf(contentTypeMismatchFormat, contentType, contentTypePlainText)
+			}
+		})
+	}
+}
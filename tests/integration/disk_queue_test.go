@@ -0,0 +1,285 @@
+package integration_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// diskQueueRequestCount is the number of concurrent requests sent to saturate one worker and a one-slot queue.
+	diskQueueRequestCount = 4
+	// diskQueueSpillPattern matches task files written to the overflow directory.
+	diskQueueSpillPattern = "*.task.json"
+	// diskQueueSpillWait bounds how long the test waits for a task to spill to disk.
+	diskQueueSpillWait = 2 * time.Second
+	// diskQueuePollInterval is the delay between overflow directory checks.
+	diskQueuePollInterval = 10 * time.Millisecond
+	// diskQueueNoSpillMessage reports that no task reached the overflow directory.
+	diskQueueNoSpillMessage = "no task spilled to the disk overflow queue"
+	// diskQueueLeftoverFormat reports task files that were never replayed.
+	diskQueueLeftoverFormat = "overflow directory still holds %d task files"
+	// diskQueueBusyPrompt is the prompt of the request that occupies the only worker.
+	diskQueueBusyPrompt = "busy"
+	// diskQueueQueuedPrompt is the prompt of the request that fills the in-memory queue.
+	diskQueueQueuedPrompt = "queued"
+	// diskQueueAbandonedPrompt is the prompt of the spilled request whose client stops waiting.
+	diskQueueAbandonedPrompt = "abandoned"
+	// diskQueueSettleDelay gives a request time to reach the in-memory queue, or the proxy time to notice a client
+	// that went away.
+	diskQueueSettleDelay = 200 * time.Millisecond
+	// diskQueueImpatientTimeout is how long the client of the abandoned request waits.
+	diskQueueImpatientTimeout = 300 * time.Millisecond
+	// diskQueueAbandonedSentFormat reports an abandoned task that reached the upstream.
+	diskQueueAbandonedSentFormat = "abandoned task was sent upstream: %s"
+)
+
+// makeGatedHTTPClient returns an HTTP client whose responses endpoint blocks until releaseGate is closed.
+func makeGatedHTTPClient(testingInstance *testing.T, endpoints *proxy.Endpoints, releaseGate <-chan struct{}) *http.Client {
+	testingInstance.Helper()
+	return &http.Client{Transport: roundTripperFunc(func(httpRequest *http.Request) (*http.Response, error) {
+		if httpRequest.URL.String() != endpoints.GetResponsesURL() {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{}`)), Header: make(http.Header)}, nil
+		}
+		<-releaseGate
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"output_text":"` + integrationOKBody + `"}`)), Header: make(http.Header)}, nil
+	})}
+}
+
+// TestDiskQueueOverflowIsReplayed verifies that tasks exceeding the in-memory queue spill to disk and are processed later.
+func TestDiskQueueOverflowIsReplayed(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	releaseGate := make(chan struct{})
+	endpoints := proxy.NewEndpoints()
+	client := makeGatedHTTPClient(testingInstance, endpoints, releaseGate)
+	overflowDirectory := testingInstance.TempDir()
+	server := newRouterServer(testingInstance, client, endpoints, func(configuration *proxy.Configuration) {
+		configuration.QueueSize = 1
+		configuration.RequestTimeoutSeconds = 10
+		configuration.DiskQueuePath = overflowDirectory
+		configuration.DiskQueueMaxEntries = diskQueueRequestCount
+	})
+
+	statusCodes := make(chan int, diskQueueRequestCount)
+	var requestGroup sync.WaitGroup
+	for requestIndex := 0; requestIndex < diskQueueRequestCount; requestIndex++ {
+		requestGroup.Add(1)
+		go func() {
+			defer requestGroup.Done()
+			httpResponse, requestError := http.Get(server.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				statusCodes <- 0
+				return
+			}
+			_, _ = io.Copy(io.Discard, httpResponse.Body)
+			_ = httpResponse.Body.Close()
+			statusCodes <- httpResponse.StatusCode
+		}()
+	}
+
+	spillDeadline := time.Now().Add(diskQueueSpillWait)
+	spilledFiles, _ := filepath.Glob(filepath.Join(overflowDirectory, diskQueueSpillPattern))
+	for len(spilledFiles) == 0 && time.Now().Before(spillDeadline) {
+		time.Sleep(diskQueuePollInterval)
+		spilledFiles, _ = filepath.Glob(filepath.Join(overflowDirectory, diskQueueSpillPattern))
+	}
+	close(releaseGate)
+	requestGroup.Wait()
+	close(statusCodes)
+
+	if len(spilledFiles) == 0 {
+		testingInstance.Fatal(diskQueueNoSpillMessage)
+	}
+	for statusCode := range statusCodes {
+		if statusCode != http.StatusOK {
+			testingInstance.Fatalf(statusWantFormat, statusCode, http.StatusOK)
+		}
+	}
+	leftoverFiles, _ := filepath.Glob(filepath.Join(overflowDirectory, diskQueueSpillPattern))
+	if len(leftoverFiles) != 0 {
+		testingInstance.Fatalf(diskQueueLeftoverFormat, len(leftoverFiles))
+	}
+}
+
+// TestDiskQueueDropsAbandonedTasks verifies that a spilled task whose client stopped waiting is deleted without being
+// sent upstream.
+func TestDiskQueueDropsAbandonedTasks(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	releaseGate := make(chan struct{})
+	var receivedMutex sync.Mutex
+	var receivedBodies []string
+	endpoints := proxy.NewEndpoints()
+	gatedClient := &http.Client{Transport: roundTripperFunc(func(httpRequest *http.Request) (*http.Response, error) {
+		requestBytes, _ := io.ReadAll(httpRequest.Body)
+		receivedMutex.Lock()
+		receivedBodies = append(receivedBodies, string(requestBytes))
+		receivedMutex.Unlock()
+		<-releaseGate
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"output_text":"` + integrationOKBody + `"}`)), Header: make(http.Header)}, nil
+	})}
+	overflowDirectory := testingInstance.TempDir()
+	server := newRouterServer(testingInstance, gatedClient, endpoints, func(configuration *proxy.Configuration) {
+		configuration.QueueSize = 1
+		configuration.RequestTimeoutSeconds = 10
+		configuration.DiskQueuePath = overflowDirectory
+		configuration.DiskQueueMaxEntries = diskQueueRequestCount
+	})
+
+	var waitingGroup sync.WaitGroup
+	sendWaitingRequest := func(prompt string) {
+		waitingGroup.Add(1)
+		go func() {
+			defer waitingGroup.Done()
+			httpResponse, requestError := http.Get(server.URL + "?prompt=" + prompt + "&key=" + serviceSecretValue)
+			if requestError == nil {
+				_, _ = io.Copy(io.Discard, httpResponse.Body)
+				_ = httpResponse.Body.Close()
+			}
+		}()
+	}
+	sendWaitingRequest(diskQueueBusyPrompt)
+	spillDeadline := time.Now().Add(diskQueueSpillWait)
+	for time.Now().Before(spillDeadline) {
+		receivedMutex.Lock()
+		workerBusy := len(receivedBodies) > 0
+		receivedMutex.Unlock()
+		if workerBusy {
+			break
+		}
+		time.Sleep(diskQueuePollInterval)
+	}
+	sendWaitingRequest(diskQueueQueuedPrompt)
+	time.Sleep(diskQueueSettleDelay)
+
+	impatientClient := &http.Client{Timeout: diskQueueImpatientTimeout}
+	if httpResponse, requestError := impatientClient.Get(server.URL + "?prompt=" + diskQueueAbandonedPrompt + "&key=" + serviceSecretValue); requestError == nil {
+		_ = httpResponse.Body.Close()
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, 0)
+	}
+	time.Sleep(diskQueueSettleDelay)
+	close(releaseGate)
+	waitingGroup.Wait()
+
+	spilledFiles, _ := filepath.Glob(filepath.Join(overflowDirectory, diskQueueSpillPattern))
+	drainDeadline := time.Now().Add(diskQueueSpillWait)
+	for len(spilledFiles) > 0 && time.Now().Before(drainDeadline) {
+		time.Sleep(diskQueuePollInterval)
+		spilledFiles, _ = filepath.Glob(filepath.Join(overflowDirectory, diskQueueSpillPattern))
+	}
+	if len(spilledFiles) != 0 {
+		testingInstance.Fatalf(diskQueueLeftoverFormat, len(spilledFiles))
+	}
+	receivedMutex.Lock()
+	defer receivedMutex.Unlock()
+	for _, receivedBody := range receivedBodies {
+		if strings.Contains(receivedBody, diskQueueAbandonedPrompt) {
+			testingInstance.Fatalf(diskQueueAbandonedSentFormat, receivedBody)
+		}
+	}
+}
+
+// TestDiskQueueKeepsStreaming verifies that a streaming request spilled to disk still relays the upstream deltas one
+// by one once it is replayed.
+func TestDiskQueueKeepsStreaming(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	upstreamDeltas := []string{"Hel", "lo, ", "world"}
+	releaseGate := make(chan struct{})
+	workerBusy := make(chan struct{}, diskQueueRequestCount)
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		var payload map[string]any
+		_ = json.NewDecoder(httpRequest.Body).Decode(&payload)
+		if payload[streamField] != true {
+			workerBusy <- struct{}{}
+			<-releaseGate
+			responseWriter.Header().Set("Content-Type", contentTypeJSON)
+			_, _ = io.WriteString(responseWriter, `{"output_text":"`+integrationOKBody+`"}`)
+			return
+		}
+		responseWriter.Header().Set("Content-Type", contentTypeEventStream)
+		for _, upstreamDelta := range upstreamDeltas {
+			_, _ = fmt.Fprintf(responseWriter, upstreamDeltaEventFormat, upstreamDelta)
+		}
+		_, _ = io.WriteString(responseWriter, upstreamCompletedEvent)
+	})
+	overflowDirectory := testingInstance.TempDir()
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.QueueSize = 1
+		configuration.RequestTimeoutSeconds = 10
+		configuration.DiskQueuePath = overflowDirectory
+		configuration.DiskQueueMaxEntries = diskQueueRequestCount
+	})
+
+	var waitingGroup sync.WaitGroup
+	for _, prompt := range []string{diskQueueBusyPrompt, diskQueueQueuedPrompt, diskQueueQueuedPrompt} {
+		waitingGroup.Add(1)
+		go func() {
+			defer waitingGroup.Done()
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=" + prompt + "&key=" + serviceSecretValue)
+			if requestError == nil {
+				_, _ = io.Copy(io.Discard, httpResponse.Body)
+				_ = httpResponse.Body.Close()
+			}
+		}()
+		if prompt == diskQueueBusyPrompt {
+			<-workerBusy
+			continue
+		}
+		time.Sleep(diskQueueSettleDelay)
+	}
+
+	type streamOutcome struct {
+		httpResponse *http.Response
+		requestError error
+	}
+	streamOutcomes := make(chan streamOutcome, 1)
+	go func() {
+		httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&stream=1&key=" + serviceSecretValue)
+		streamOutcomes <- streamOutcome{httpResponse: httpResponse, requestError: requestError}
+	}()
+	spillDeadline := time.Now().Add(diskQueueSpillWait)
+	spilledFiles, _ := filepath.Glob(filepath.Join(overflowDirectory, diskQueueSpillPattern))
+	for len(spilledFiles) == 0 && time.Now().Before(spillDeadline) {
+		time.Sleep(diskQueuePollInterval)
+		spilledFiles, _ = filepath.Glob(filepath.Join(overflowDirectory, diskQueueSpillPattern))
+	}
+	close(releaseGate)
+	waitingGroup.Wait()
+	if len(spilledFiles) == 0 {
+		testingInstance.Fatal(diskQueueNoSpillMessage)
+	}
+
+	outcome := <-streamOutcomes
+	if outcome.requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, outcome.requestError)
+	}
+	httpResponse := outcome.httpResponse
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+	}
+	var relayedDeltas []string
+	lineScanner := bufio.NewScanner(httpResponse.Body)
+	currentEvent := ""
+	for lineScanner.Scan() {
+		line := lineScanner.Text()
+		switch {
+		case strings.HasPrefix(line, proxyEventPrefix):
+			currentEvent = strings.TrimPrefix(line, proxyEventPrefix)
+		case strings.HasPrefix(line, proxyDataPrefix) && currentEvent == proxyDeltaEvent:
+			relayedDeltas = append(relayedDeltas, strings.TrimPrefix(line, proxyDataPrefix))
+		}
+	}
+	if strings.Join(relayedDeltas, "|") != strings.Join(upstreamDeltas, "|") {
+		testingInstance.Fatalf(streamDeltasMismatchFormat, relayedDeltas, upstreamDeltas)
+	}
+}
@@ -0,0 +1,88 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// dryRunQueryParameter requests the upstream payload instead of an answer.
+	dryRunQueryParameter = "dry_run"
+	// dryRunUnknownModel names a model the proxy rejects.
+	dryRunUnknownModel = "not-a-real-model"
+	// dryRunUpstreamCallsFormat reports unexpected upstream traffic.
+	dryRunUpstreamCallsFormat = "upstream calls=%d want 0"
+	// dryRunPayloadFieldFormat reports an unexpected field in the returned payload.
+	dryRunPayloadFieldFormat = "payload %s=%v want %v"
+)
+
+// TestDryRunReturnsPayload verifies that dry_run=1 answers with the upstream request body without contacting the
+// upstream, and that model validation still applies.
+func TestDryRunReturnsPayload(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var upstreamCalls atomic.Int64
+	client := &http.Client{Transport: roundTripperFunc(func(httpRequest *http.Request) (*http.Response, error) {
+		if httpRequest.Method == http.MethodPost {
+			upstreamCalls.Add(1)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(tracedCompletedBody)), Header: make(http.Header)}, nil
+	})}
+	endpoints := proxy.NewEndpoints()
+	server := newRouterServer(testingInstance, client, endpoints, nil)
+
+	sendDryRun := func(modelIdentifier string) *http.Response {
+		requestURL, _ := url.Parse(server.URL)
+		queryValues := requestURL.Query()
+		queryValues.Set(promptQueryParameter, promptValue)
+		queryValues.Set(keyQueryParameter, serviceSecretValue)
+		queryValues.Set(adaptiveModelQueryParameter, modelIdentifier)
+		queryValues.Set(maxTokensQueryParameter, "321")
+		queryValues.Set(dryRunQueryParameter, "1")
+		requestURL.RawQuery = queryValues.Encode()
+		httpResponse, requestError := http.Get(requestURL.String())
+		if requestError != nil {
+			testingInstance.Fatalf(requestErrorFormat, requestError)
+		}
+		return httpResponse
+	}
+
+	httpResponse := sendDryRun(proxy.ModelNameGPT41)
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+	}
+	if contentType := httpResponse.Header.Get(contentTypeHeaderKey); !strings.HasPrefix(contentType, contentTypeJSON) {
+		testingInstance.Fatalf("content type=%q want %q", contentType, contentTypeJSON)
+	}
+	var payload map[string]any
+	if decodeError := json.NewDecoder(httpResponse.Body).Decode(&payload); decodeError != nil {
+		testingInstance.Fatalf("decode payload: %v", decodeError)
+	}
+	expectedFields := map[string]any{
+		"model":              proxy.ModelNameGPT41,
+		"input":              promptValue,
+		maxOutputTokensField: float64(321),
+	}
+	for fieldName, expectedValue := range expectedFields {
+		if payload[fieldName] != expectedValue {
+			testingInstance.Fatalf(dryRunPayloadFieldFormat, fieldName, payload[fieldName], expectedValue)
+		}
+	}
+
+	rejectedResponse := sendDryRun(dryRunUnknownModel)
+	_ = rejectedResponse.Body.Close()
+	if rejectedResponse.StatusCode != http.StatusBadRequest {
+		testingInstance.Fatalf(statusWantFormat, rejectedResponse.StatusCode, http.StatusBadRequest)
+	}
+	if calls := upstreamCalls.Load(); calls != 0 {
+		testingInstance.Fatalf(dryRunUpstreamCallsFormat, calls)
+	}
+}
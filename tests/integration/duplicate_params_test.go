@@ -0,0 +1,46 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+// duplicateKeyMessage is the error returned for a repeated key parameter.
+const duplicateKeyMessage = "duplicate query parameter: key"
+
+// TestDuplicateSecurityParametersAreRejected verifies that repeated key parameters are rejected only when enabled.
+func TestDuplicateSecurityParametersAreRejected(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		rejectEnabled  bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "rejection enabled", rejectEnabled: true, expectedStatus: http.StatusBadRequest, expectedBody: duplicateKeyMessage},
+		{name: "rejection disabled", rejectEnabled: false, expectedStatus: http.StatusOK, expectedBody: integrationOKBody},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, _ := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.QueueSize = 8
+				configuration.RejectDuplicateParams = testCase.rejectEnabled
+			})
+			httpResponse, requestError := http.Get(server.URL + "?prompt=ping&key=" + serviceSecretValue + "&key=other")
+			if requestError != nil {
+				subTest.Fatalf(getFailedFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus || string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package integration_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// contentTypeXML is the media type of XML responses.
+	contentTypeXML = "application/xml"
+	// formatDisabledMessage is returned when the format parameter names a disabled format.
+	formatDisabledMessage = "requested format is disabled"
+)
+
+// TestEnabledFormats verifies that disabled formats negotiated through Accept degrade to plain text while an explicit
+// format parameter naming one is rejected.
+func TestEnabledFormats(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name                string
+		enabledFormats      []string
+		acceptHeader        string
+		formatParameter     string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{name: "accept disabled xml", enabledFormats: []string{proxy.ResponseFormatJSON}, acceptHeader: contentTypeXML, expectedStatus: http.StatusOK, expectedContentType: contentTypePlainText, expectedBody: integrationOKBody},
+		{name: "accept enabled json", enabledFormats: []string{proxy.ResponseFormatJSON}, acceptHeader: contentTypeJSON, expectedStatus: http.StatusOK, expectedContentType: contentTypeJSON},
+		{name: "all formats enabled", acceptHeader: contentTypeXML, expectedStatus: http.StatusOK, expectedContentType: contentTypeXML},
+		{name: "format parameter disabled", enabledFormats: []string{proxy.ResponseFormatJSON}, formatParameter: contentTypeCSV, expectedStatus: http.StatusNotAcceptable, expectedBody: formatDisabledMessage},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			applicationServer := newIntegrationServer(subTest, newStaticOpenAIHandler(completedResponseBody), func(configuration *proxy.Configuration) {
+				configuration.EnabledFormats = testCase.enabledFormats
+			})
+
+			queryValues := url.Values{}
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			if testCase.formatParameter != constants.EmptyString {
+				queryValues.Set(formatQueryParameter, testCase.formatParameter)
+			}
+			httpRequest, buildRequestError := http.NewRequest(http.MethodGet, applicationServer.URL+"/?"+queryValues.Encode(), nil)
+			if buildRequestError != nil {
+				subTest.Fatalf(requestErrorFormat, buildRequestError)
+			}
+			if testCase.acceptHeader != constants.EmptyString {
+				httpRequest.Header.Set(acceptHeaderName, testCase.acceptHeader)
+			}
+			httpResponse, requestError := http.DefaultClient.Do(httpRequest)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+			if testCase.expectedContentType != constants.EmptyString {
+				if contentType := httpResponse.Header.Get("Content-Type"); contentType != testCase.expectedContentType {
+					subTest.Fatalf(contentTypeMismatchFormat, contentType, testCase.expectedContentType)
+				}
+			}
+			if testCase.expectedBody != constants.EmptyString && string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(bodyMismatchFormat, string(responseBytes), testCase.expectedBody)
+			}
+		})
+	}
+}
+
+// TestEnabledFormatsRejectUnknownFormat verifies that BuildRouter refuses an unsupported format name.
+func TestEnabledFormatsRejectUnknownFormat(testingInstance *testing.T) {
+	_, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret:  serviceSecretValue,
+		OpenAIKey:      openAIKeyValue,
+		LogLevel:       logLevelDebug,
+		WorkerCount:    1,
+		QueueSize:      1,
+		EnabledFormats: []string{"toml"},
+		Endpoints:      proxy.NewEndpoints(),
+	}, newLogger(testingInstance))
+	if !errors.Is(buildRouterError, proxy.ErrInvalidEnabledFormat) {
+		testingInstance.Fatalf(expectedErrorFormat, proxy.ErrInvalidEnabledFormat, buildRouterError)
+	}
+}
@@ -0,0 +1,71 @@
+package integration_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// staleOutputText is the output_text value that disagrees with the assistant message.
+	staleOutputText = "stale output text"
+	// freshMessageText is the assistant message text that disagrees with output_text.
+	freshMessageText = "fresh message text"
+	// disagreeingResponseBody is a completed upstream response whose output_text and assistant message differ.
+	disagreeingResponseBody = `{"id":"resp_disagree","status":"completed","output_text":"` + staleOutputText + `","output":[{"type":"message","role":"assistant","content":[{"type":"output_text","text":"` + freshMessageText + `"}]}]}`
+	// unknownExtractionStrategy is not a supported extraction strategy.
+	unknownExtractionStrategy = "newest_first"
+	// extractionStrategyErrorFormat reports an unexpected configuration error.
+	extractionStrategyErrorFormat = "BuildRouter error=%v want %v"
+)
+
+// TestExtractionStrategy verifies that each extraction strategy reads its preferred part of a disagreeing response.
+func TestExtractionStrategy(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name         string
+		strategy     string
+		expectedBody string
+	}{
+		{name: "default", strategy: "", expectedBody: staleOutputText},
+		{name: "output text first", strategy: proxy.ExtractionStrategyOutputTextFirst, expectedBody: staleOutputText},
+		{name: "message first", strategy: proxy.ExtractionStrategyMessageFirst, expectedBody: freshMessageText},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			applicationServer := newIntegrationServer(subTest, newStaticOpenAIHandler(disagreeingResponseBody), func(configuration *proxy.Configuration) {
+				configuration.ExtractionStrategy = testCase.strategy
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+			}
+		})
+	}
+}
+
+// TestExtractionStrategyRejectsUnknownValue verifies that an unsupported extraction strategy fails configuration.
+func TestExtractionStrategyRejectsUnknownValue(testingInstance *testing.T) {
+	_, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret:      serviceSecretValue,
+		OpenAIKey:          openAIKeyValue,
+		LogLevel:           logLevelDebug,
+		WorkerCount:        1,
+		QueueSize:          4,
+		ExtractionStrategy: unknownExtractionStrategy,
+		Endpoints:          proxy.NewEndpoints(),
+	}, newLogger(testingInstance))
+	if !errors.Is(buildRouterError, proxy.ErrInvalidExtractionStrategy) {
+		testingInstance.Fatalf(extractionStrategyErrorFormat, buildRouterError, proxy.ErrInvalidExtractionStrategy)
+	}
+}
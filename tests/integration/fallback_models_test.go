@@ -0,0 +1,183 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// upstreamRequestErrorMessage is returned when the upstream keeps failing with server errors.
+	upstreamRequestErrorMessage = "OpenAI request error"
+	// fallbackModelsMismatchFormat reports an unexpected sequence of upstream models.
+	fallbackModelsMismatchFormat = "models requested=%v want=%v"
+	// fallbackServerErrorAttempts is the number of calls made to a model that hands over to a fallback model.
+	fallbackServerErrorAttempts = 2
+	// fallbackRetryIntervalMilliseconds shortens the wait between retries of the last model tried.
+	fallbackRetryIntervalMilliseconds = 10
+	// fallbackRetryMaxElapsedMilliseconds bounds the retries of the last model tried.
+	fallbackRetryMaxElapsedMilliseconds = 300
+)
+
+// TestFallbackModels verifies that a request failing upstream is retried with the configured fallback models.
+func TestFallbackModels(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name                    string
+		fallbackModels          []string
+		failingModels           []string
+		failureStatus           int
+		expectedStatus          int
+		expectedBody            string
+		expectedModels          []string
+		expectedPrimaryAttempts int
+		minimumFallbackAttempts int
+	}{
+		{
+			name:                    "fallback answers",
+			fallbackModels:          []string{proxy.ModelNameGPT4o},
+			failingModels:           []string{proxy.ModelNameGPT41},
+			failureStatus:           http.StatusInternalServerError,
+			expectedStatus:          http.StatusOK,
+			expectedBody:            integrationOKBody,
+			expectedModels:          []string{proxy.ModelNameGPT41, proxy.ModelNameGPT4o},
+			expectedPrimaryAttempts: fallbackServerErrorAttempts,
+			minimumFallbackAttempts: 1,
+		},
+		{
+			name:                    "every model fails",
+			fallbackModels:          []string{proxy.ModelNameGPT4o},
+			failingModels:           []string{proxy.ModelNameGPT41, proxy.ModelNameGPT4o},
+			failureStatus:           http.StatusInternalServerError,
+			expectedStatus:          http.StatusBadGateway,
+			expectedBody:            upstreamRequestErrorMessage,
+			expectedModels:          []string{proxy.ModelNameGPT41, proxy.ModelNameGPT4o},
+			expectedPrimaryAttempts: fallbackServerErrorAttempts,
+			minimumFallbackAttempts: fallbackServerErrorAttempts + 1,
+		},
+		{
+			name:                    "client error is not retried with a fallback",
+			fallbackModels:          []string{proxy.ModelNameGPT4o},
+			failingModels:           []string{proxy.ModelNameGPT41},
+			failureStatus:           http.StatusBadRequest,
+			expectedStatus:          http.StatusBadGateway,
+			expectedBody:            expectedErrorMessage,
+			expectedModels:          []string{proxy.ModelNameGPT41},
+			expectedPrimaryAttempts: 1,
+		},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			var requestedModelsMutex sync.Mutex
+			var requestedModels []string
+			openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				var payload map[string]any
+				_ = json.NewDecoder(httpRequest.Body).Decode(&payload)
+				requestedModel, _ := payload[modelField].(string)
+				requestedModelsMutex.Lock()
+				requestedModels = append(requestedModels, requestedModel)
+				requestedModelsMutex.Unlock()
+				if slices.Contains(testCase.failingModels, requestedModel) {
+					responseWriter.WriteHeader(testCase.failureStatus)
+					return
+				}
+				responseWriter.Header().Set("Content-Type", contentTypeJSON)
+				_, _ = io.WriteString(responseWriter, completedResponseBody)
+			})
+			applicationServer := newIntegrationServer(subTest, openAIHandler, func(configuration *proxy.Configuration) {
+				configuration.FallbackModels = testCase.fallbackModels
+				configuration.RetryInitialIntervalMilliseconds = fallbackRetryIntervalMilliseconds
+				configuration.RetryMaxElapsedMilliseconds = fallbackRetryMaxElapsedMilliseconds
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus || string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+			requestedModelsMutex.Lock()
+			defer requestedModelsMutex.Unlock()
+			if !slices.Equal(slices.Compact(slices.Clone(requestedModels)), testCase.expectedModels) {
+				subTest.Fatalf(fallbackModelsMismatchFormat, requestedModels, testCase.expectedModels)
+			}
+			modelAttempts := make(map[string]int)
+			for _, requestedModel := range requestedModels {
+				modelAttempts[requestedModel]++
+			}
+			if modelAttempts[proxy.ModelNameGPT41] != testCase.expectedPrimaryAttempts || modelAttempts[proxy.ModelNameGPT4o] < testCase.minimumFallbackAttempts {
+				subTest.Fatalf(fallbackModelsMismatchFormat, requestedModels, testCase.expectedModels)
+			}
+		})
+	}
+}
+
+// TestFallbackModelsRejectUnknownModel verifies that BuildRouter refuses an unrecognized fallback model.
+func TestFallbackModelsRejectUnknownModel(testingInstance *testing.T) {
+	_, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret:  serviceSecretValue,
+		OpenAIKey:      openAIKeyValue,
+		LogLevel:       logLevelDebug,
+		WorkerCount:    1,
+		QueueSize:      1,
+		FallbackModels: []string{unmappedModelName},
+		Endpoints:      proxy.NewEndpoints(),
+	}, newLogger(testingInstance))
+	if !errors.Is(buildRouterError, proxy.ErrUnknownModel) {
+		testingInstance.Fatalf(expectedErrorFormat, proxy.ErrUnknownModel, buildRouterError)
+	}
+}
+
+// TestFallbackModelsAnswerIsNotCached verifies that an answer produced by a fallback model is not cached for the
+// requested model, so the next identical request tries the requested model again.
+func TestFallbackModelsAnswerIsNotCached(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var requestedModelsMutex sync.Mutex
+	var requestedModels []string
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		var payload map[string]any
+		_ = json.NewDecoder(httpRequest.Body).Decode(&payload)
+		requestedModel, _ := payload[modelField].(string)
+		requestedModelsMutex.Lock()
+		requestedModels = append(requestedModels, requestedModel)
+		requestedModelsMutex.Unlock()
+		if requestedModel == proxy.ModelNameGPT41 {
+			responseWriter.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		responseWriter.Header().Set("Content-Type", contentTypeJSON)
+		_, _ = io.WriteString(responseWriter, completedResponseBody)
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.FallbackModels = []string{proxy.ModelNameGPT4o}
+		configuration.ResponseCacheSize = 4
+	})
+
+	for requestIndex := 0; requestIndex < 2; requestIndex++ {
+		httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+		if requestError != nil {
+			testingInstance.Fatalf(requestErrorFormat, requestError)
+		}
+		responseBytes, _ := io.ReadAll(httpResponse.Body)
+		_ = httpResponse.Body.Close()
+		if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != integrationOKBody {
+			testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+		}
+	}
+	requestedModelsMutex.Lock()
+	defer requestedModelsMutex.Unlock()
+	expectedModels := []string{proxy.ModelNameGPT41, proxy.ModelNameGPT4o, proxy.ModelNameGPT41, proxy.ModelNameGPT4o}
+	if !slices.Equal(slices.Compact(slices.Clone(requestedModels)), expectedModels) {
+		testingInstance.Fatalf(fallbackModelsMismatchFormat, requestedModels, expectedModels)
+	}
+}
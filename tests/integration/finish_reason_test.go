@@ -0,0 +1,57 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// finishReasonHeader is the response header exposing why generation stopped.
+	finishReasonHeader = "X-Finish-Reason"
+	// truncatedResponseBody is a responses API payload cut short by the output token limit.
+	truncatedResponseBody = `{"id":"resp_truncated","status":"incomplete","incomplete_details":{"reason":"max_output_tokens"},"output_text":"` + integrationOKBody + `"}`
+	// completedResponseBody is a responses API payload that finished naturally.
+	completedResponseBody = `{"id":"resp_completed","status":"completed","output":[{"type":"message","role":"assistant","content":[{"type":"output_text","text":"` + integrationOKBody + `"}]}]}`
+	// finishReasonMismatchFormat reports an unexpected finish reason header.
+	finishReasonMismatchFormat = "finish reason=%q want=%q"
+)
+
+// TestFinishReasonHeader verifies that the finish reason reported upstream is exposed when enabled.
+func TestFinishReasonHeader(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name                 string
+		responseBody         string
+		includeFinishReason  bool
+		expectedFinishReason string
+	}{
+		{name: "length truncation", responseBody: truncatedResponseBody, includeFinishReason: true, expectedFinishReason: "length"},
+		{name: "natural stop", responseBody: completedResponseBody, includeFinishReason: true, expectedFinishReason: "stop"},
+		{name: "disabled", responseBody: truncatedResponseBody, includeFinishReason: false, expectedFinishReason: constants.EmptyString},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			applicationServer := newIntegrationServer(subTest, newStaticOpenAIHandler(testCase.responseBody), func(configuration *proxy.Configuration) {
+				configuration.IncludeFinishReason = testCase.includeFinishReason
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != integrationOKBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+			}
+			if actualFinishReason := httpResponse.Header.Get(finishReasonHeader); actualFinishReason != testCase.expectedFinishReason {
+				subTest.Fatalf(finishReasonMismatchFormat, actualFinishReason, testCase.expectedFinishReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// formatSizeLimit is the formatted body cap used by the size guard test.
+	formatSizeLimit = 64
+	// responseTooLargeMessage is the error returned when the formatted body exceeds the cap.
+	responseTooLargeMessage = "response too large to format"
+	// escapeHeavyText fits the cap as plain text but expands beyond it once XML-escaped.
+	escapeHeavyText = "<<<<<<<<<<<<<<<<<<<<"
+)
+
+// TestFormatSizeGuardRejectsOversizedResponses verifies that responses exceeding MaxFormattedBytes yield 502.
+func TestFormatSizeGuardRejectsOversizedResponses(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		responseText   string
+		format         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "small plain text", responseText: integrationOKBody, format: "text/plain", expectedStatus: http.StatusOK, expectedBody: integrationOKBody},
+		{name: "oversized plain text", responseText: strings.Repeat("A", formatSizeLimit+1), format: "text/plain", expectedStatus: http.StatusBadGateway, expectedBody: responseTooLargeMessage},
+		{name: "escaping exceeds cap", responseText: escapeHeavyText, format: "application/xml", expectedStatus: http.StatusBadGateway, expectedBody: responseTooLargeMessage},
+		{name: "JSON escaping exceeds cap", responseText: escapeHeavyText, format: "application/json", expectedStatus: http.StatusBadGateway, expectedBody: responseTooLargeMessage},
+		{name: "small CSV", responseText: integrationOKBody, format: "text/csv", expectedStatus: http.StatusOK, expectedBody: integrationOKBody + "\n"},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			applicationServer := newIntegrationServer(subTest, newOpenAIHandler(testCase.responseText, nil), func(configuration *proxy.Configuration) {
+				configuration.MaxFormattedBytes = formatSizeLimit
+			})
+			requestURL, _ := url.Parse(applicationServer.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(formatQueryParameter, testCase.format)
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus || string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+		})
+	}
+}
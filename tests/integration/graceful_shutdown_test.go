@@ -0,0 +1,119 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// inFlightUpstreamDelay keeps the upstream busy while shutdown is triggered.
+	inFlightUpstreamDelay = 300 * time.Millisecond
+	// serverReadyTimeout bounds the wait for the proxy to accept connections.
+	serverReadyTimeout = 5 * time.Second
+	// serverReadyPollInterval spaces the readiness probes.
+	serverReadyPollInterval = 10 * time.Millisecond
+	// shutdownGraceSeconds bounds the graceful shutdown in the test.
+	shutdownGraceSeconds = 5
+	// serveErrorFormat reports an unexpected error from ServeContext.
+	serveErrorFormat = "ServeContext error=%v want nil"
+	// serverNotReadyMessage reports a proxy that never accepted connections.
+	serverNotReadyMessage = "proxy did not start accepting connections"
+)
+
+// reserveLocalPort returns a TCP port that was free when checked.
+func reserveLocalPort(testingInstance *testing.T) int {
+	testingInstance.Helper()
+	listener, listenError := net.Listen("tcp", "127.0.0.1:0")
+	if listenError != nil {
+		testingInstance.Fatalf(requestErrorFormat, listenError)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// TestGracefulShutdownCompletesInFlightRequest verifies that a request in flight when shutdown starts still gets its reply.
+func TestGracefulShutdownCompletesInFlightRequest(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	upstreamReached := make(chan struct{}, 1)
+	openAIServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		upstreamReached <- struct{}{}
+		time.Sleep(inFlightUpstreamDelay)
+		responseWriter.Header().Set("Content-Type", contentTypeJSON)
+		_, _ = io.WriteString(responseWriter, completedResponseBody)
+	}))
+	testingInstance.Cleanup(openAIServer.Close)
+	endpoints := proxy.NewEndpoints()
+	endpoints.SetResponsesURL(openAIServer.URL + integrationResponsesPath)
+	originalClient := proxy.HTTPClient
+	proxy.HTTPClient = openAIServer.Client()
+	testingInstance.Cleanup(func() { proxy.HTTPClient = originalClient })
+
+	proxyPort := reserveLocalPort(testingInstance)
+	proxyURL := fmt.Sprintf("http://127.0.0.1:%d", proxyPort)
+	serveContext, triggerShutdown := context.WithCancel(context.Background())
+	defer triggerShutdown()
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- proxy.ServeContext(serveContext, proxy.Configuration{
+			ServiceSecret:        serviceSecretValue,
+			OpenAIKey:            openAIKeyValue,
+			Port:                 proxyPort,
+			LogLevel:             logLevelDebug,
+			WorkerCount:          1,
+			QueueSize:            4,
+			ShutdownGraceSeconds: shutdownGraceSeconds,
+			Endpoints:            endpoints,
+		}, newLogger(testingInstance))
+	}()
+	readyDeadline := time.Now().Add(serverReadyTimeout)
+	for {
+		probeResponse, probeError := http.Get(proxyURL)
+		if probeError == nil {
+			_ = probeResponse.Body.Close()
+			break
+		}
+		if time.Now().After(readyDeadline) {
+			testingInstance.Fatal(serverNotReadyMessage)
+		}
+		time.Sleep(serverReadyPollInterval)
+	}
+
+	type requestOutcome struct {
+		status int
+		body   string
+		err    error
+	}
+	outcomes := make(chan requestOutcome, 1)
+	go func() {
+		httpResponse, requestError := http.Get(proxyURL + "?prompt=ping&key=" + serviceSecretValue)
+		if requestError != nil {
+			outcomes <- requestOutcome{err: requestError}
+			return
+		}
+		defer httpResponse.Body.Close()
+		responseBytes, _ := io.ReadAll(httpResponse.Body)
+		outcomes <- requestOutcome{status: httpResponse.StatusCode, body: string(responseBytes)}
+	}()
+	<-upstreamReached
+	triggerShutdown()
+
+	outcome := <-outcomes
+	if outcome.err != nil {
+		testingInstance.Fatalf(requestErrorFormat, outcome.err)
+	}
+	if outcome.status != http.StatusOK || outcome.body != integrationOKBody {
+		testingInstance.Fatalf(statusWantBodyFormat, outcome.status, http.StatusOK, outcome.body)
+	}
+	if serveError := <-serveErrors; serveError != nil {
+		testingInstance.Fatalf(serveErrorFormat, serveError)
+	}
+}
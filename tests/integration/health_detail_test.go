@@ -0,0 +1,98 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// healthDetailPath reports the detailed health document.
+	healthDetailPath = "/health/detail"
+	// healthDetailPoolName names the model pool configured in the test.
+	healthDetailPoolName = "reasoning"
+	// healthDetailFieldMissingFormat reports a health detail document without an expected field.
+	healthDetailFieldMissingFormat = "health detail %s missing field %q"
+	// healthDetailValueFormat reports an unexpected value in the health detail document.
+	healthDetailValueFormat = "health detail %s=%v want %v"
+)
+
+// TestHealthDetailReportsSignals verifies that the health detail route requires the service secret and reports the
+// upstream reachability, the model table, the queues and the workers of all pools.
+func TestHealthDetailReportsSignals(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		if httpRequest.URL.Path != integrationModelsPath {
+			http.NotFound(responseWriter, httpRequest)
+			return
+		}
+		responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+		_, _ = io.WriteString(responseWriter, integrationModelListBody)
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.WorkerCount = 2
+		configuration.QueueSize = 3
+		configuration.ModelPools = map[string]proxy.ModelPool{
+			healthDetailPoolName: {Models: []string{proxy.ModelNameGPT5}, WorkerCount: 1, QueueSize: 2},
+		}
+	})
+
+	unauthorizedResponse, requestError := http.Get(applicationServer.URL + healthDetailPath)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	_ = unauthorizedResponse.Body.Close()
+	if unauthorizedResponse.StatusCode != http.StatusForbidden {
+		testingInstance.Fatalf(statusWantFormat, unauthorizedResponse.StatusCode, http.StatusForbidden)
+	}
+
+	httpResponse, requestError := http.Get(applicationServer.URL + healthDetailPath + "?key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, _ := io.ReadAll(httpResponse.Body)
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+	}
+	var detail map[string]any
+	if decodeError := json.Unmarshal(responseBytes, &detail); decodeError != nil {
+		testingInstance.Fatalf(requestErrorFormat, decodeError)
+	}
+	for _, fieldName := range []string{"status", "upstream", "models", "queue_depth", "queue_capacity", "worker_count", "in_flight"} {
+		if _, present := detail[fieldName]; !present {
+			testingInstance.Fatalf(healthDetailFieldMissingFormat, string(responseBytes), fieldName)
+		}
+	}
+	upstreamDetail, _ := detail["upstream"].(map[string]any)
+	modelsDetail, _ := detail["models"].(map[string]any)
+	for _, fieldName := range []string{"count", "age_seconds"} {
+		if _, present := modelsDetail[fieldName]; !present {
+			testingInstance.Fatalf(healthDetailFieldMissingFormat, string(responseBytes), "models."+fieldName)
+		}
+	}
+	expectedValues := []struct {
+		name     string
+		actual   any
+		expected any
+	}{
+		{name: "status", actual: detail["status"], expected: "ok"},
+		{name: "upstream.upstream_status", actual: upstreamDetail["upstream_status"], expected: float64(http.StatusOK)},
+		{name: "queue_depth", actual: detail["queue_depth"], expected: float64(0)},
+		{name: "queue_capacity", actual: detail["queue_capacity"], expected: float64(5)},
+		{name: "worker_count", actual: detail["worker_count"], expected: float64(3)},
+		{name: "in_flight", actual: detail["in_flight"], expected: float64(0)},
+	}
+	for _, expectedValue := range expectedValues {
+		if expectedValue.actual != expectedValue.expected {
+			testingInstance.Fatalf(healthDetailValueFormat, expectedValue.name, expectedValue.actual, expectedValue.expected)
+		}
+	}
+	if modelCount, _ := modelsDetail["count"].(float64); modelCount <= 0 {
+		testingInstance.Fatalf(healthDetailValueFormat, "models.count", modelsDetail["count"], "positive")
+	}
+}
@@ -0,0 +1,90 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// toolTypeField identifies the type of an attached tool.
+	toolTypeField = "type"
+	// toolContainerField holds the sandbox of the code interpreter tool.
+	toolContainerField = "container"
+	// hostedToolsMismatchFormat reports unexpected tool types in the captured payload.
+	hostedToolsMismatchFormat = "tool types=%v want %v"
+	// toolContainerMismatchFormat reports an unexpected code interpreter container.
+	toolContainerMismatchFormat = "container=%v want %v"
+)
+
+// TestHostedToolsParameter verifies that the tools parameter attaches every listed allowlisted tool, that
+// web_search=1 stands for tools=web_search, and that tools outside the allowlist are rejected before reaching the
+// upstream.
+func TestHostedToolsParameter(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name          string
+		webSearch     string
+		tools         string
+		expectedTypes []any
+	}{
+		{name: "several tools", tools: "web_search,code_interpreter", expectedTypes: []any{"web_search", "code_interpreter"}},
+		{name: "web search shortcut", webSearch: "1", tools: "code_interpreter", expectedTypes: []any{"web_search", "code_interpreter"}},
+		{name: "every tool", tools: "web_search,web_search_preview,code_interpreter", expectedTypes: []any{"web_search", "web_search_preview", "code_interpreter"}},
+		{name: "unknown tool", tools: "web_search,shell"},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, nil)
+
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, proxy.ModelNameGPT41)
+			queryValues.Set(toolsQueryParameter, testCase.tools)
+			if testCase.webSearch != "" {
+				queryValues.Set(webSearchQueryParameter, testCase.webSearch)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if testCase.expectedTypes == nil {
+				if httpResponse.StatusCode != http.StatusBadRequest {
+					subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusBadRequest)
+				}
+				if *capturedPayload != nil {
+					subTest.Fatalf(hostedToolsMismatchFormat, (*capturedPayload)[toolsField], nil)
+				}
+				return
+			}
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			attachedTools, _ := (*capturedPayload)[toolsField].([]any)
+			var attachedTypes []any
+			for _, attachedTool := range attachedTools {
+				toolObject, _ := attachedTool.(map[string]any)
+				attachedTypes = append(attachedTypes, toolObject[toolTypeField])
+				if toolObject[toolTypeField] == "code_interpreter" {
+					expectedContainer := map[string]any{toolTypeField: "auto"}
+					if !reflect.DeepEqual(toolObject[toolContainerField], expectedContainer) {
+						subTest.Fatalf(toolContainerMismatchFormat, toolObject[toolContainerField], expectedContainer)
+					}
+				}
+			}
+			if !reflect.DeepEqual(attachedTypes, testCase.expectedTypes) {
+				subTest.Fatalf(hostedToolsMismatchFormat, attachedTypes, testCase.expectedTypes)
+			}
+		})
+	}
+}
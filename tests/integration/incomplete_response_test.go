@@ -0,0 +1,51 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// neverCompletingResponseIdentifier identifies the session that stays in progress on every poll.
+	neverCompletingResponseIdentifier = "resp_never_completes"
+	// neverCompletingBody is the state the session reports on creation, continuation and every poll.
+	neverCompletingBody = `{"id":"` + neverCompletingResponseIdentifier + `","status":"in_progress","output":[]}`
+	// incompleteResponseMessage is the body returned when polling ends before the response completes.
+	incompleteResponseMessage = "OpenAI API error (incomplete response)"
+)
+
+// TestIncompleteResponseReturnsGatewayTimeout verifies that a response still in progress when the poll deadline
+// passes is reported as 504 with the incomplete-response message rather than as a generic upstream failure.
+func TestIncompleteResponseReturnsGatewayTimeout(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+		switch {
+		case httpRequest.Method == http.MethodPost && strings.HasPrefix(httpRequest.URL.Path, integrationResponsesPath):
+			_, _ = io.WriteString(responseWriter, neverCompletingBody)
+		case httpRequest.Method == http.MethodGet && strings.HasSuffix(httpRequest.URL.Path, neverCompletingResponseIdentifier):
+			_, _ = io.WriteString(responseWriter, neverCompletingBody)
+		default:
+			http.NotFound(responseWriter, httpRequest)
+		}
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.UpstreamPollTimeoutSeconds = 1
+		configuration.PollIntervalMillis = fastPollIntervalMillis
+	})
+
+	httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, _ := io.ReadAll(httpResponse.Body)
+	if httpResponse.StatusCode != http.StatusGatewayTimeout || string(responseBytes) != incompleteResponseMessage {
+		testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusGatewayTimeout, string(responseBytes))
+	}
+}
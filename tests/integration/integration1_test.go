@@ -3,12 +3,10 @@ package integration_test
 import (
 	"io"
 	"net/http"
-	"net/http/httptest"
 	"net/url"
 	"testing"
 
 	"github.com/temirov/llm-proxy/internal/proxy"
-	"go.uber.org/zap"
 )
 
 const (
@@ -36,34 +34,6 @@ const (
 	reasoningEffortMismatchFormat = "reasoning effort=%v want=%v"
 )
 
-// newIntegrationServerWithTimeout builds the application server pointing at the stub OpenAI server with a configurable request timeout.
-func newIntegrationServerWithTimeout(testingInstance *testing.T, openAIServer *httptest.Server, requestTimeoutSeconds int) *httptest.Server {
-	testingInstance.Helper()
-	endpoints := proxy.NewEndpoints()
-	endpoints.SetModelsURL(openAIServer.URL + integrationModelsPath)
-	endpoints.SetResponsesURL(openAIServer.URL + integrationResponsesPath)
-	originalClient := proxy.HTTPClient
-	proxy.HTTPClient = openAIServer.Client()
-	testingInstance.Cleanup(func() { proxy.HTTPClient = originalClient })
-	logger, _ := zap.NewDevelopment()
-	testingInstance.Cleanup(func() { _ = logger.Sync() })
-	router, buildRouterError := proxy.BuildRouter(proxy.Configuration{
-		ServiceSecret:         integrationServiceSecret,
-		OpenAIKey:             integrationOpenAIKey,
-		LogLevel:              logLevelDebug,
-		WorkerCount:           1,
-		QueueSize:             4,
-		RequestTimeoutSeconds: requestTimeoutSeconds,
-		Endpoints:             endpoints,
-	}, logger.Sugar())
-	if buildRouterError != nil {
-		testingInstance.Fatalf(buildRouterErrorFormat, buildRouterError)
-	}
-	server := httptest.NewServer(router)
-	testingInstance.Cleanup(server.Close)
-	return server
-}
-
 // TestProxyResponseDelivery verifies responses with and without web search.
 func TestProxyResponseDelivery(testingInstance *testing.T) {
 	testCases := []struct {
@@ -82,9 +52,7 @@ func TestProxyResponseDelivery(testingInstance *testing.T) {
 			if testCase.checkTools {
 				captureTarget = &captured
 			}
-			openAIServer := newOpenAIServer(subTest, testCase.body, captureTarget)
-			subTest.Cleanup(openAIServer.Close)
-			applicationServer := newIntegrationServer(subTest, openAIServer)
+			applicationServer := newIntegrationServer(subTest, newOpenAIHandler(testCase.body, captureTarget), nil)
 			requestURL := applicationServer.URL + "?prompt=ping&key=" + integrationServiceSecret
 			if testCase.webSearch {
 				requestURL += "&web_search=1"
@@ -121,9 +89,7 @@ func TestProxyResponseDelivery(testingInstance *testing.T) {
 // include tools, tool_choice, and reasoning fields.
 func TestProxyGPT5WebSearchIncludesReasoning(testingInstance *testing.T) {
 	var capturedPayload any
-	openAIServer := newOpenAIServer(testingInstance, integrationSearchBody, &capturedPayload)
-	testingInstance.Cleanup(openAIServer.Close)
-	applicationServer := newIntegrationServer(testingInstance, openAIServer)
+	applicationServer := newIntegrationServer(testingInstance, newOpenAIHandler(integrationSearchBody, &capturedPayload), nil)
 	requestURL, _ := url.Parse(applicationServer.URL)
 	queryValues := requestURL.Query()
 	queryValues.Set(promptQueryParameter, promptValue)
@@ -0,0 +1,47 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// invalidModelIdentifier is a model that no configuration recognizes.
+	invalidModelIdentifier = "gpt-does-not-exist"
+	// invalidModelRequestCount is the number of repeated invalid-model requests.
+	invalidModelRequestCount = 5
+	// modelsEndpointCallsFormat reports unexpected calls to the models endpoint.
+	modelsEndpointCallsFormat = "models endpoint calls=%d want=0"
+)
+
+// TestRepeatedInvalidModelRequestsSkipUpstream verifies that repeated invalid-model requests are rejected without contacting OpenAI.
+func TestRepeatedInvalidModelRequestsSkipUpstream(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var upstreamCalls atomic.Int64
+	endpoints := proxy.NewEndpoints()
+	client := &http.Client{Transport: roundTripperFunc(func(httpRequest *http.Request) (*http.Response, error) {
+		upstreamCalls.Add(1)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(availableModelsBody)), Header: make(http.Header)}, nil
+	})}
+	server := newRouterServer(testingInstance, client, endpoints, nil)
+
+	for requestIndex := 0; requestIndex < invalidModelRequestCount; requestIndex++ {
+		httpResponse, requestError := http.Get(server.URL + "?prompt=ping&model=" + invalidModelIdentifier + "&key=" + serviceSecretValue)
+		if requestError != nil {
+			testingInstance.Fatalf(requestErrorFormat, requestError)
+		}
+		_ = httpResponse.Body.Close()
+		if httpResponse.StatusCode != http.StatusBadRequest {
+			testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusBadRequest)
+		}
+	}
+	if calls := upstreamCalls.Load(); calls != 0 {
+		testingInstance.Fatalf(modelsEndpointCallsFormat, calls)
+	}
+}
@@ -0,0 +1,79 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// loadSheddingCooldownSeconds keeps the circuit open for the rest of the test.
+	loadSheddingCooldownSeconds = 60
+	// loadSheddingMismatchFormat reports an unexpected load shedding state.
+	loadSheddingMismatchFormat = "load_shedding=%+v want %+v"
+)
+
+// loadSheddingResponse mirrors the load_shedding object of the health detail document.
+type loadSheddingResponse struct {
+	Engaged            bool `json:"engaged"`
+	CircuitOpen        bool `json:"circuit_open"`
+	ConcurrencyLimited bool `json:"concurrency_limited"`
+	DegradingReasoning bool `json:"degrading_reasoning"`
+	QueueFull          bool `json:"queue_full"`
+}
+
+// TestLoadSheddingReportsOpenCircuit verifies that the health detail reports no engaged protection while the
+// upstream is healthy and an engaged circuit breaker once upstream failures have opened it.
+func TestLoadSheddingReportsOpenCircuit(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		_, _ = io.Copy(io.Discard, httpRequest.Body)
+		if httpRequest.URL.Path == integrationModelsPath {
+			responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+			_, _ = io.WriteString(responseWriter, integrationModelListBody)
+			return
+		}
+		responseWriter.WriteHeader(http.StatusInternalServerError)
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.RetryInitialIntervalMilliseconds = 10
+		configuration.RetryMaxElapsedMilliseconds = 50
+		configuration.CircuitBreakerFailureThreshold = 1
+		configuration.CircuitBreakerCooldownSeconds = loadSheddingCooldownSeconds
+	})
+
+	checkLoadShedding := func(expectedState loadSheddingResponse) {
+		testingInstance.Helper()
+		httpResponse, requestError := http.Get(applicationServer.URL + healthDetailPath + "?key=" + serviceSecretValue)
+		if requestError != nil {
+			testingInstance.Fatalf(requestErrorFormat, requestError)
+		}
+		defer httpResponse.Body.Close()
+		var detail struct {
+			LoadShedding loadSheddingResponse `json:"load_shedding"`
+		}
+		if decodeError := json.NewDecoder(httpResponse.Body).Decode(&detail); decodeError != nil {
+			testingInstance.Fatalf(requestErrorFormat, decodeError)
+		}
+		if detail.LoadShedding != expectedState {
+			testingInstance.Fatalf(loadSheddingMismatchFormat, detail.LoadShedding, expectedState)
+		}
+	}
+
+	checkLoadShedding(loadSheddingResponse{})
+
+	httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	_ = httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusBadGateway {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusBadGateway)
+	}
+
+	checkLoadShedding(loadSheddingResponse{Engaged: true, CircuitOpen: true})
+}
@@ -0,0 +1,90 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/proxy"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const (
+	// sensitiveAnswerText is model output that must not appear in logs.
+	sensitiveAnswerText = "PATIENT_RECORD_42"
+	// redactedResponseBody carries the sensitive text in both output_text and the message array.
+	redactedResponseBody = `{"id":"resp_pii","status":"completed","output_text":"` + sensitiveAnswerText + `","output":[{"type":"message","role":"assistant","content":[{"type":"output_text","text":"` + sensitiveAnswerText + `"}]}]}`
+	// logEventInitialResponseBody is the log message carrying the initial upstream body.
+	logEventInitialResponseBody = "OpenAI initial response body"
+	// logFieldResponseBody is the structured field holding a logged upstream body.
+	logFieldResponseBody = "response_body"
+	// redactedPlaceholderValue is the placeholder substituted for redacted values.
+	redactedPlaceholderValue = "***REDACTED***"
+	// sensitiveTextLoggedFormat reports that sensitive text leaked into a log entry.
+	sensitiveTextLoggedFormat = "log entry %q leaked sensitive text: %v"
+	// redactedBodyMissingFormat reports a missing or unredacted body log entry.
+	redactedBodyMissingFormat = "initial response body log missing redaction placeholder: %q"
+)
+
+// TestLogRedactionMasksConfiguredFields verifies that configured JSON paths are masked in logged upstream bodies.
+func TestLogRedactionMasksConfiguredFields(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	openAIServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		responseWriter.Header().Set("Content-Type", contentTypeJSON)
+		_, _ = io.WriteString(responseWriter, redactedResponseBody)
+	}))
+	testingInstance.Cleanup(openAIServer.Close)
+	endpoints := proxy.NewEndpoints()
+	endpoints.SetResponsesURL(openAIServer.URL + integrationResponsesPath)
+	originalClient := proxy.HTTPClient
+	proxy.HTTPClient = openAIServer.Client()
+	testingInstance.Cleanup(func() { proxy.HTTPClient = originalClient })
+
+	observedCore, observedLogs := observer.New(zapcore.DebugLevel)
+	router, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret:     serviceSecretValue,
+		OpenAIKey:         openAIKeyValue,
+		LogLevel:          logLevelDebug,
+		WorkerCount:       1,
+		QueueSize:         4,
+		LogRedactedFields: []string{"output_text", "output[].content[].text"},
+		Endpoints:         endpoints,
+	}, zap.New(observedCore).Sugar())
+	if buildRouterError != nil {
+		testingInstance.Fatalf(buildRouterFailedFormat, buildRouterError)
+	}
+	applicationServer := httptest.NewServer(router)
+	testingInstance.Cleanup(applicationServer.Close)
+
+	httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, _ := io.ReadAll(httpResponse.Body)
+	if string(responseBytes) != sensitiveAnswerText {
+		testingInstance.Fatalf(bodyMismatchFormat, string(responseBytes), sensitiveAnswerText)
+	}
+
+	for _, loggedEntry := range observedLogs.All() {
+		for fieldName, fieldValue := range loggedEntry.ContextMap() {
+			if valueText, isText := fieldValue.(string); isText && strings.Contains(valueText, sensitiveAnswerText) {
+				testingInstance.Fatalf(sensitiveTextLoggedFormat, loggedEntry.Message, fieldName)
+			}
+		}
+	}
+	bodyEntries := observedLogs.FilterMessage(logEventInitialResponseBody).All()
+	if len(bodyEntries) == 0 {
+		testingInstance.Fatalf(redactedBodyMissingFormat, constants.EmptyString)
+	}
+	loggedBody, _ := bodyEntries[0].ContextMap()[logFieldResponseBody].(string)
+	if !strings.Contains(loggedBody, redactedPlaceholderValue) {
+		testingInstance.Fatalf(redactedBodyMissingFormat, loggedBody)
+	}
+}
@@ -0,0 +1,115 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// concurrencyLimitMessage is the body of a request rejected by the concurrent-request cap.
+	concurrencyLimitMessage = "too many concurrent requests"
+	// concurrencyRejectionDeadline bounds the wait for the rejection, which must not wait for a queue slot.
+	concurrencyRejectionDeadline = time.Second
+)
+
+// TestMaxConcurrentRequestsRejectsImmediately verifies that once MaxConcurrentRequests requests are in progress,
+// a further request is answered with 503 at once even though the queue has room, and that a slot frees up once a
+// request completes.
+func TestMaxConcurrentRequestsRejectsImmediately(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	endpoints := proxy.NewEndpoints()
+	slowCallStarted := make(chan struct{})
+	releaseSlowCalls := make(chan struct{})
+	client := makeSlowModelHTTPClient(testingInstance, endpoints, proxy.ModelNameGPT5, slowCallStarted, releaseSlowCalls)
+	server := newRouterServer(testingInstance, client, endpoints, func(configuration *proxy.Configuration) {
+		configuration.MaxConcurrentRequests = 1
+	})
+	modelURL := func(modelIdentifier string) string {
+		requestURL, _ := url.Parse(server.URL)
+		queryValues := requestURL.Query()
+		queryValues.Set(promptQueryParameter, promptValue)
+		queryValues.Set(keyQueryParameter, serviceSecretValue)
+		queryValues.Set(adaptiveModelQueryParameter, modelIdentifier)
+		requestURL.RawQuery = queryValues.Encode()
+		return requestURL.String()
+	}
+
+	slowRequestDone := make(chan struct{})
+	go func() {
+		defer close(slowRequestDone)
+		if httpResponse, requestError := http.Get(modelURL(proxy.ModelNameGPT5)); requestError == nil {
+			_ = httpResponse.Body.Close()
+		}
+	}()
+	<-slowCallStarted
+
+	rejectingClient := &http.Client{Timeout: concurrencyRejectionDeadline}
+	httpResponse, requestError := rejectingClient.Get(modelURL(proxy.ModelNameGPT41))
+	if requestError != nil {
+		close(releaseSlowCalls)
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	responseBytes, _ := io.ReadAll(httpResponse.Body)
+	_ = httpResponse.Body.Close()
+	close(releaseSlowCalls)
+	if httpResponse.StatusCode != http.StatusServiceUnavailable || !strings.Contains(string(responseBytes), concurrencyLimitMessage) {
+		testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusServiceUnavailable, string(responseBytes))
+	}
+
+	<-slowRequestDone
+	httpResponse, requestError = http.Get(modelURL(proxy.ModelNameGPT41))
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, _ = io.ReadAll(httpResponse.Body)
+	if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != integrationOKBody {
+		testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+	}
+}
+
+// TestMaxConcurrentRequestsCoverChatCompletions verifies that chat completion requests share the concurrent-request
+// slots of the root endpoint and are rejected with 503 once every slot is taken.
+func TestMaxConcurrentRequestsCoverChatCompletions(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	endpoints := proxy.NewEndpoints()
+	slowCallStarted := make(chan struct{})
+	releaseSlowCalls := make(chan struct{})
+	client := makeSlowModelHTTPClient(testingInstance, endpoints, proxy.ModelNameGPT5, slowCallStarted, releaseSlowCalls)
+	server := newRouterServer(testingInstance, client, endpoints, func(configuration *proxy.Configuration) {
+		configuration.MaxConcurrentRequests = 1
+	})
+
+	slowRequestDone := make(chan struct{})
+	go func() {
+		defer close(slowRequestDone)
+		slowURL := server.URL + "?prompt=ping&model=" + proxy.ModelNameGPT5 + "&key=" + serviceSecretValue
+		if httpResponse, requestError := http.Get(slowURL); requestError == nil {
+			_ = httpResponse.Body.Close()
+		}
+	}()
+	<-slowCallStarted
+
+	httpRequest, _ := http.NewRequest(http.MethodPost, server.URL+chatCompletionsPath, strings.NewReader(chatCompletionsRequestBody))
+	httpRequest.Header.Set("Content-Type", contentTypeJSON)
+	httpRequest.Header.Set(authorizationHeader, "Bearer "+serviceSecretValue)
+	rejectingClient := &http.Client{Timeout: concurrencyRejectionDeadline}
+	httpResponse, requestError := rejectingClient.Do(httpRequest)
+	close(releaseSlowCalls)
+	<-slowRequestDone
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, _ := io.ReadAll(httpResponse.Body)
+	if httpResponse.StatusCode != http.StatusServiceUnavailable || !strings.Contains(string(responseBytes), concurrencyLimitMessage) {
+		testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusServiceUnavailable, string(responseBytes))
+	}
+}
@@ -0,0 +1,64 @@
+package integration_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// maxTokensQueryParameter overrides the output token limit for one request.
+	maxTokensQueryParameter = "max_tokens"
+	// maxTokensCeiling is the configured ceiling for max_tokens.
+	maxTokensCeiling = 4096
+	// maxTokensMismatchFormat reports an unexpected max_output_tokens value in the captured payload.
+	maxTokensMismatchFormat = "max_output_tokens=%v want=%v"
+)
+
+// TestMaxTokensOverride verifies that max_tokens reaches the upstream payload and is validated against the ceiling.
+func TestMaxTokensOverride(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		maxTokens      string
+		expectedStatus int
+		expectedTokens float64
+	}{
+		{name: "omitted uses configured limit", maxTokens: "", expectedStatus: http.StatusOK, expectedTokens: proxy.DefaultMaxOutputTokens},
+		{name: "smaller limit", maxTokens: "64", expectedStatus: http.StatusOK, expectedTokens: 64},
+		{name: "larger limit at ceiling", maxTokens: "4096", expectedStatus: http.StatusOK, expectedTokens: maxTokensCeiling},
+		{name: "above ceiling", maxTokens: "4097", expectedStatus: http.StatusBadRequest},
+		{name: "zero", maxTokens: "0", expectedStatus: http.StatusBadRequest},
+		{name: "not a number", maxTokens: "many", expectedStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.MaxOutputTokensCeiling = maxTokensCeiling
+			})
+
+			requestURL := server.URL + "?prompt=ping&key=" + serviceSecretValue
+			if testCase.maxTokens != "" {
+				requestURL += "&" + maxTokensQueryParameter + "=" + testCase.maxTokens
+			}
+			httpResponse, requestError := http.Get(requestURL)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				return
+			}
+			if (*capturedPayload)[maxOutputTokensField] != testCase.expectedTokens {
+				subTest.Fatalf(maxTokensMismatchFormat, (*capturedPayload)[maxOutputTokensField], testCase.expectedTokens)
+			}
+		})
+	}
+}
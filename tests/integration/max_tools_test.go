@@ -0,0 +1,81 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// toolsQueryParameter lists further hosted tools to attach.
+	toolsQueryParameter = "tools"
+	// maxToolsLimit is the tool cap configured in the test.
+	maxToolsLimit = 1
+	// attachedToolsMismatchFormat reports an unexpected number of tools in the captured payload.
+	attachedToolsMismatchFormat = "tools=%v want %d entries"
+	// unsupportedToolMessage is the rejection of an unknown tool, listing every supported tool.
+	unsupportedToolMessage = `unsupported tool "file_browser"; supported tools are web_search, web_search_preview, code_interpreter`
+)
+
+// TestMaxToolsLimit verifies that requests attaching more tools than MaxTools are rejected before reaching the
+// upstream, and that requests within the limit are forwarded with their tools.
+func TestMaxToolsLimit(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		webSearch      string
+		tools          string
+		expectedStatus int
+		expectedTools  int
+		expectedBody   string
+	}{
+		{name: "within limit", tools: "web_search", expectedStatus: http.StatusOK, expectedTools: 1},
+		{name: "web search repeated in tools", webSearch: "true", tools: "web_search", expectedStatus: http.StatusOK, expectedTools: 1},
+		{name: "too many tools", tools: "web_search,web_search_preview", expectedStatus: http.StatusBadRequest},
+		{name: "too many with web search", webSearch: "true", tools: "web_search_preview", expectedStatus: http.StatusBadRequest},
+		{name: "unsupported tool", tools: "file_browser", expectedStatus: http.StatusBadRequest, expectedBody: unsupportedToolMessage},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.MaxTools = maxToolsLimit
+			})
+
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, proxy.ModelNameGPT41)
+			queryValues.Set(toolsQueryParameter, testCase.tools)
+			if testCase.webSearch != "" {
+				queryValues.Set(webSearchQueryParameter, testCase.webSearch)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus || (testCase.expectedBody != "" && string(responseBytes) != testCase.expectedBody) {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				if *capturedPayload != nil {
+					subTest.Fatalf(attachedToolsMismatchFormat, (*capturedPayload)[toolsField], 0)
+				}
+				return
+			}
+			attachedTools, _ := (*capturedPayload)[toolsField].([]any)
+			if len(attachedTools) != testCase.expectedTools {
+				subTest.Fatalf(attachedToolsMismatchFormat, attachedTools, testCase.expectedTools)
+			}
+		})
+	}
+}
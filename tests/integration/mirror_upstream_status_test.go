@@ -0,0 +1,59 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// rateLimitedMessage is the error message of the rate-limited upstream stub.
+	rateLimitedMessage = "Rate limit reached for requests"
+	// rateLimitedBody is the error body returned by the rate-limited upstream stub.
+	rateLimitedBody = `{"error":{"message":"` + rateLimitedMessage + `","type":"requests","code":"rate_limit_exceeded"}}`
+	// mirrorRetryMilliseconds bounds the retries of the rate-limited request so that they end before the deadline.
+	mirrorRetryMilliseconds = 50
+)
+
+// TestMirrorUpstreamStatus verifies that an upstream 429 reaches the client as 429 with the upstream message when
+// MirrorUpstreamStatus is set, and as 429 with the proxy's own message otherwise.
+func TestMirrorUpstreamStatus(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		mirrorStatus   bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "mirrored", mirrorStatus: true, expectedStatus: http.StatusTooManyRequests, expectedBody: rateLimitedMessage},
+		{name: "mapped", mirrorStatus: false, expectedStatus: http.StatusTooManyRequests, expectedBody: "upstream rate limit exceeded"},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+				responseWriter.WriteHeader(http.StatusTooManyRequests)
+				_, _ = io.WriteString(responseWriter, rateLimitedBody)
+			})
+			applicationServer := newIntegrationServer(subTest, openAIHandler, func(configuration *proxy.Configuration) {
+				configuration.RetryInitialIntervalMilliseconds = 10
+				configuration.RetryMaxElapsedMilliseconds = mirrorRetryMilliseconds
+				configuration.MirrorUpstreamStatus = testCase.mirrorStatus
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus || !strings.Contains(string(responseBytes), testCase.expectedBody) {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+		})
+	}
+}
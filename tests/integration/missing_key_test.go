@@ -42,9 +42,7 @@ func TestClientKeyHandling(testingInstance *testing.T) {
 	}
 	for _, testCase := range testCases {
 		testingInstance.Run(testCase.name, func(subTest *testing.T) {
-			openAIServer := newOpenAIServer(subTest, integrationOKBody, nil)
-			subTest.Cleanup(openAIServer.Close)
-			applicationServer := newIntegrationServer(subTest, openAIServer)
+			applicationServer := newIntegrationServer(subTest, newOpenAIHandler(integrationOKBody, nil), nil)
 			requestURL, _ := url.Parse(applicationServer.URL)
 			queryValues := requestURL.Query()
 			queryValues.Set(promptQueryParameter, promptValue)
@@ -0,0 +1,60 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// fastModelAlias is the client-facing name mapped to a faster model.
+	fastModelAlias = "fast"
+	// unmappedModelName is a model name absent from both the alias table and the known models.
+	unmappedModelName = "slow"
+)
+
+// TestModelAliasResolvesBeforeValidation verifies that aliased model names reach the upstream payload as the mapped model
+// and that names without an alias fall through to normal validation.
+func TestModelAliasResolvesBeforeValidation(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		requestedModel string
+		expectedStatus int
+		expectedModel  string
+	}{
+		{name: "alias", requestedModel: fastModelAlias, expectedStatus: http.StatusOK, expectedModel: proxy.ModelNameGPT4oMini},
+		{name: "direct model", requestedModel: proxy.ModelNameGPT41, expectedStatus: http.StatusOK, expectedModel: proxy.ModelNameGPT41},
+		{name: "unknown name", requestedModel: unmappedModelName, expectedStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, captured := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.QueueSize = 8
+				configuration.ModelAliases = map[string]string{fastModelAlias: proxy.ModelNameGPT4oMini}
+			})
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, testCase.requestedModel)
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			if testCase.expectedModel != "" && (*captured)[modelField] != testCase.expectedModel {
+				subTest.Fatalf(modelMismatchFormat, (*captured)[modelField], testCase.expectedModel)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+// modelOutputTokenCeiling is the output token ceiling configured for the capped model.
+const modelOutputTokenCeiling = 512
+
+// TestModelOutputTokenCeilings verifies that output token budgets above a model's configured ceiling are clamped to
+// it in the upstream payload, while smaller budgets and models without a ceiling are forwarded unchanged.
+func TestModelOutputTokenCeilings(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		model          string
+		maxTokens      string
+		expectedTokens float64
+	}{
+		{name: "requested above ceiling", model: proxy.ModelNameGPT4oMini, maxTokens: "2048", expectedTokens: modelOutputTokenCeiling},
+		{name: "configured default above ceiling", model: proxy.ModelNameGPT4oMini, expectedTokens: modelOutputTokenCeiling},
+		{name: "requested below ceiling", model: proxy.ModelNameGPT4oMini, maxTokens: "128", expectedTokens: 128},
+		{name: "model without ceiling", model: proxy.ModelNameGPT41, maxTokens: "2048", expectedTokens: 2048},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.ModelOutputTokenCeilings = map[string]int{proxy.ModelNameGPT4oMini: modelOutputTokenCeiling}
+			})
+
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, testCase.model)
+			if testCase.maxTokens != "" {
+				queryValues.Set(maxTokensQueryParameter, testCase.maxTokens)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			if (*capturedPayload)[maxOutputTokensField] != testCase.expectedTokens {
+				subTest.Fatalf(maxTokensMismatchFormat, (*capturedPayload)[maxOutputTokensField], testCase.expectedTokens)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// slowModelFloodSize is the number of slow-model requests sent before the fast-model request.
+	slowModelFloodSize = 4
+	// fastModelDeadline bounds the wait for the fast-model answer while the slow pool is busy.
+	fastModelDeadline = 2 * time.Second
+	// modelPoolName names the pool serving the slow model in the test.
+	modelPoolName = "reasoning"
+	// fastModelBlockedFormat reports a fast-model request held up by slow-model traffic.
+	fastModelBlockedFormat = "fast model request failed while slow pool was busy: %v"
+	// modelPoolAlias is a model alias listed in a pool in place of the model it resolves to.
+	modelPoolAlias = "thinker"
+	// secondModelPoolName names a second pool in the validation test.
+	secondModelPoolName = "flagship"
+)
+
+// makeSlowModelHTTPClient returns an HTTP client whose responses calls for slowModel block until release is closed.
+// slowStarted is closed when the first slow call arrives; other models are answered at once.
+func makeSlowModelHTTPClient(testingInstance *testing.T, endpoints *proxy.Endpoints, slowModel string, slowStarted chan struct{}, release chan struct{}) *http.Client {
+	testingInstance.Helper()
+	var startOnce sync.Once
+	return &http.Client{Transport: roundTripperFunc(func(httpRequest *http.Request) (*http.Response, error) {
+		switch {
+		case httpRequest.URL.String() == endpoints.GetModelsURL():
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(availableModelsBody)), Header: make(http.Header)}, nil
+		case httpRequest.URL.String() == endpoints.GetResponsesURL():
+			var payload map[string]any
+			requestBytes, _ := io.ReadAll(httpRequest.Body)
+			_ = json.Unmarshal(requestBytes, &payload)
+			if payload["model"] == slowModel {
+				startOnce.Do(func() { close(slowStarted) })
+				<-release
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"output_text":"` + integrationOKBody + `"}`)), Header: make(http.Header)}, nil
+		default:
+			testingInstance.Fatalf(unexpectedRequestFormat, httpRequest.URL.String())
+			return nil, nil
+		}
+	})}
+}
+
+// TestModelPoolsIsolateSlowModels verifies that a flood of requests for a model served by its own pool does not
+// delay requests for models served by the default pool.
+func TestModelPoolsIsolateSlowModels(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	endpoints := proxy.NewEndpoints()
+	slowCallStarted := make(chan struct{})
+	releaseSlowCalls := make(chan struct{})
+	client := makeSlowModelHTTPClient(testingInstance, endpoints, proxy.ModelNameGPT5, slowCallStarted, releaseSlowCalls)
+	server := newRouterServer(testingInstance, client, endpoints, func(configuration *proxy.Configuration) {
+		configuration.QueueSize = slowModelFloodSize
+		configuration.ModelPools = map[string]proxy.ModelPool{
+			modelPoolName: {Models: []string{proxy.ModelNameGPT5}, WorkerCount: 1, QueueSize: slowModelFloodSize},
+		}
+	})
+	modelURL := func(modelIdentifier string) string {
+		requestURL, _ := url.Parse(server.URL)
+		queryValues := requestURL.Query()
+		queryValues.Set(promptQueryParameter, promptValue)
+		queryValues.Set(keyQueryParameter, serviceSecretValue)
+		queryValues.Set(adaptiveModelQueryParameter, modelIdentifier)
+		requestURL.RawQuery = queryValues.Encode()
+		return requestURL.String()
+	}
+
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(slowModelFloodSize)
+	for requestIndex := 0; requestIndex < slowModelFloodSize; requestIndex++ {
+		go func() {
+			defer waitGroup.Done()
+			if httpResponse, requestError := http.Get(modelURL(proxy.ModelNameGPT5)); requestError == nil {
+				_ = httpResponse.Body.Close()
+			}
+		}()
+	}
+	<-slowCallStarted
+	defer waitGroup.Wait()
+	defer close(releaseSlowCalls)
+
+	fastClient := &http.Client{Timeout: fastModelDeadline}
+	httpResponse, requestError := fastClient.Get(modelURL(proxy.ModelNameGPT41))
+	if requestError != nil {
+		testingInstance.Fatalf(fastModelBlockedFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, _ := io.ReadAll(httpResponse.Body)
+	if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != integrationOKBody {
+		testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+	}
+}
+
+// TestModelPoolsReplaySpilledTasksIndependently verifies that tasks spilled to the disk overflow queue for a model
+// served by the default pool are replayed while spilled tasks of a busy pool wait for its workers.
+func TestModelPoolsReplaySpilledTasksIndependently(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	endpoints := proxy.NewEndpoints()
+	slowCallStarted := make(chan struct{})
+	releaseSlowCalls := make(chan struct{})
+	client := makeSlowModelHTTPClient(testingInstance, endpoints, proxy.ModelNameGPT5, slowCallStarted, releaseSlowCalls)
+	server := newRouterServer(testingInstance, client, endpoints, func(configuration *proxy.Configuration) {
+		configuration.QueueSize = 1
+		configuration.DiskQueuePath = testingInstance.TempDir()
+		configuration.DiskQueueMaxEntries = 4 * slowModelFloodSize
+		configuration.ModelPools = map[string]proxy.ModelPool{
+			modelPoolName: {Models: []string{proxy.ModelNameGPT5}, WorkerCount: 1, QueueSize: 1},
+		}
+	})
+	modelURL := func(modelIdentifier string) string {
+		requestURL, _ := url.Parse(server.URL)
+		queryValues := requestURL.Query()
+		queryValues.Set(promptQueryParameter, promptValue)
+		queryValues.Set(keyQueryParameter, serviceSecretValue)
+		queryValues.Set(adaptiveModelQueryParameter, modelIdentifier)
+		requestURL.RawQuery = queryValues.Encode()
+		return requestURL.String()
+	}
+
+	var slowGroup sync.WaitGroup
+	slowGroup.Add(slowModelFloodSize)
+	for requestIndex := 0; requestIndex < slowModelFloodSize; requestIndex++ {
+		go func() {
+			defer slowGroup.Done()
+			if httpResponse, requestError := http.Get(modelURL(proxy.ModelNameGPT5)); requestError == nil {
+				_ = httpResponse.Body.Close()
+			}
+		}()
+	}
+	<-slowCallStarted
+	defer slowGroup.Wait()
+	defer close(releaseSlowCalls)
+
+	fastClient := &http.Client{Timeout: fastModelDeadline}
+	fastErrors := make(chan error, slowModelFloodSize)
+	var fastGroup sync.WaitGroup
+	fastGroup.Add(slowModelFloodSize)
+	for requestIndex := 0; requestIndex < slowModelFloodSize; requestIndex++ {
+		go func() {
+			defer fastGroup.Done()
+			httpResponse, requestError := fastClient.Get(modelURL(proxy.ModelNameGPT41))
+			if requestError != nil {
+				fastErrors <- requestError
+				return
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				fastErrors <- fmt.Errorf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+		}()
+	}
+	fastGroup.Wait()
+	close(fastErrors)
+	for fastError := range fastErrors {
+		testingInstance.Fatalf(fastModelBlockedFormat, fastError)
+	}
+}
+
+// TestModelPoolsValidateModels verifies that BuildRouter refuses a pool listing an unrecognized model or a model
+// whose alias another pool lists, and accepts a pool listing a model alias.
+func TestModelPoolsValidateModels(testingInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		modelPools    map[string]proxy.ModelPool
+		expectedError error
+	}{
+		{
+			name:          "unknown model",
+			modelPools:    map[string]proxy.ModelPool{modelPoolName: {Models: []string{unmappedModelName}, WorkerCount: 1}},
+			expectedError: proxy.ErrUnknownModel,
+		},
+		{
+			name:       "model alias",
+			modelPools: map[string]proxy.ModelPool{modelPoolName: {Models: []string{modelPoolAlias}, WorkerCount: 1}},
+		},
+		{
+			name: "alias and target in different pools",
+			modelPools: map[string]proxy.ModelPool{
+				modelPoolName:       {Models: []string{modelPoolAlias}, WorkerCount: 1},
+				secondModelPoolName: {Models: []string{proxy.ModelNameGPT5}, WorkerCount: 1},
+			},
+			expectedError: proxy.ErrInvalidModelPools,
+		},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			_, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+				ServiceSecret: serviceSecretValue,
+				OpenAIKey:     openAIKeyValue,
+				LogLevel:      logLevelDebug,
+				WorkerCount:   1,
+				QueueSize:     1,
+				ModelAliases:  map[string]string{modelPoolAlias: proxy.ModelNameGPT5},
+				ModelPools:    testCase.modelPools,
+				Endpoints:     proxy.NewEndpoints(),
+			}, newLogger(subTest))
+			if testCase.expectedError == nil {
+				if buildRouterError != nil {
+					subTest.Fatalf(buildRouterFailedFormat, buildRouterError)
+				}
+				return
+			}
+			if !errors.Is(buildRouterError, testCase.expectedError) {
+				subTest.Fatalf(expectedErrorFormat, testCase.expectedError, buildRouterError)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// modelRequestsPerMinute is the per-minute budget of the limited model in the test.
+	modelRequestsPerMinute = 2
+	// modelRateLimitRequestCount is the number of requests sent to each model, more than the limited budget.
+	modelRateLimitRequestCount = 4
+)
+
+// TestModelRateLimit verifies that a model with a per-minute limit is throttled while a model without one is not.
+func TestModelRateLimit(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	applicationServer := newIntegrationServer(testingInstance, newStaticOpenAIHandler(completedResponseBody), func(configuration *proxy.Configuration) {
+		configuration.ModelRateLimits = map[string]int{proxy.ModelNameGPT5: modelRequestsPerMinute}
+	})
+
+	testCases := []struct {
+		name            string
+		model           string
+		expectedLimited int
+	}{
+		{name: "limited model", model: proxy.ModelNameGPT5, expectedLimited: modelRateLimitRequestCount - modelRequestsPerMinute},
+		{name: "unlimited model", model: proxy.ModelNameGPT4o, expectedLimited: 0},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			acceptedCount, limitedCount := 0, 0
+			for requestIndex := 0; requestIndex < modelRateLimitRequestCount; requestIndex++ {
+				requestURL, _ := url.Parse(applicationServer.URL)
+				queryValues := requestURL.Query()
+				queryValues.Set(promptQueryParameter, promptValue)
+				queryValues.Set(keyQueryParameter, serviceSecretValue)
+				queryValues.Set(adaptiveModelQueryParameter, testCase.model)
+				requestURL.RawQuery = queryValues.Encode()
+				httpResponse, requestError := http.Get(requestURL.String())
+				if requestError != nil {
+					subTest.Fatalf(requestErrorFormat, requestError)
+				}
+				_ = httpResponse.Body.Close()
+				switch httpResponse.StatusCode {
+				case http.StatusOK:
+					acceptedCount++
+				case http.StatusTooManyRequests:
+					limitedCount++
+					retryAfter := httpResponse.Header.Get(retryAfterHeader)
+					if retryAfterSeconds, parseError := strconv.Atoi(retryAfter); parseError != nil || retryAfterSeconds <= 0 {
+						subTest.Fatalf(retryAfterInvalidFormat, retryAfter)
+					}
+				default:
+					subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+				}
+			}
+			if limitedCount != testCase.expectedLimited {
+				subTest.Fatalf(rateLimitCountFormat, acceptedCount, limitedCount, modelRateLimitRequestCount-testCase.expectedLimited, testCase.expectedLimited)
+			}
+		})
+	}
+}
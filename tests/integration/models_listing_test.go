@@ -0,0 +1,79 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// modelsListingPath serves the recognized model identifiers.
+	modelsListingPath = "/v1/models"
+	// modelsListingMismatchFormat reports an unexpected models listing.
+	modelsListingMismatchFormat = "models listing=%+v want ids %v"
+)
+
+// modelsListingResponse mirrors the JSON document served by the models endpoint.
+type modelsListingResponse struct {
+	Object string `json:"object"`
+	Data   []struct {
+		ID     string `json:"id"`
+		Object string `json:"object"`
+	} `json:"data"`
+}
+
+// TestModelsListing verifies that the recognized models are listed behind the service secret.
+func TestModelsListing(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret: serviceSecretValue,
+		OpenAIKey:     openAIKeyValue,
+		LogLevel:      logLevelDebug,
+		WorkerCount:   1,
+		QueueSize:     1,
+		Endpoints:     proxy.NewEndpoints(),
+	}, newLogger(testingInstance))
+	if buildRouterError != nil {
+		testingInstance.Fatalf(buildRouterFailedFormat, buildRouterError)
+	}
+	applicationServer := httptest.NewServer(router)
+	testingInstance.Cleanup(applicationServer.Close)
+
+	unauthorizedResponse, requestError := http.Get(applicationServer.URL + modelsListingPath)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	_ = unauthorizedResponse.Body.Close()
+	if unauthorizedResponse.StatusCode != http.StatusForbidden {
+		testingInstance.Fatalf(statusWantFormat, unauthorizedResponse.StatusCode, http.StatusForbidden)
+	}
+
+	httpResponse, requestError := http.Get(applicationServer.URL + modelsListingPath + "?key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+	}
+	var listing modelsListingResponse
+	if decodeError := json.NewDecoder(httpResponse.Body).Decode(&listing); decodeError != nil {
+		testingInstance.Fatalf(requestErrorFormat, decodeError)
+	}
+	expectedIdentifiers := []string{proxy.ModelNameGPT41, proxy.ModelNameGPT4o, proxy.ModelNameGPT4oMini, proxy.ModelNameGPT5, proxy.ModelNameGPT5Mini}
+	var listedIdentifiers []string
+	for _, entry := range listing.Data {
+		if entry.Object != "model" {
+			testingInstance.Fatalf(modelsListingMismatchFormat, listing, expectedIdentifiers)
+		}
+		listedIdentifiers = append(listedIdentifiers, entry.ID)
+	}
+	if listing.Object != "list" || !slices.Equal(listedIdentifiers, expectedIdentifiers) {
+		testingInstance.Fatalf(modelsListingMismatchFormat, listing, expectedIdentifiers)
+	}
+}
@@ -3,8 +3,9 @@ package integration_test
 import (
 	"io"
 	"net/http"
-	"net/http/httptest"
 	"testing"
+
+	"github.com/temirov/llm-proxy/internal/proxy"
 )
 
 const (
@@ -16,7 +17,7 @@ const (
 // TestOpenAIResponsesRetries verifies that the proxy retries upstream server errors and ultimately returns HTTP 504.
 func TestOpenAIResponsesRetries(testingInstance *testing.T) {
 	responsesAPICallCount := 0
-	openAIServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
 		switch httpRequest.URL.Path {
 		case integrationModelsPath:
 			responseWriter.Header().Set(contentTypeHeaderKey, mimeApplicationJSON)
@@ -27,10 +28,11 @@ func TestOpenAIResponsesRetries(testingInstance *testing.T) {
 		default:
 			http.NotFound(responseWriter, httpRequest)
 		}
-	}))
-	testingInstance.Cleanup(openAIServer.Close)
+	})
 
-	applicationServer := newIntegrationServerWithTimeout(testingInstance, openAIServer, 4)
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.RequestTimeoutSeconds = 4
+	})
 	requestURL := applicationServer.URL + "?prompt=ping&key=" + integrationServiceSecret
 	httpResponse, requestError := http.Get(requestURL)
 	if requestError != nil {
@@ -0,0 +1,96 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// openAIOrganizationHeader attributes upstream usage to an organization.
+	openAIOrganizationHeader = "OpenAI-Organization"
+	// openAIProjectHeader attributes upstream usage to a project.
+	openAIProjectHeader = "OpenAI-Project"
+	// organizationValue is the organization configured for the test.
+	organizationValue = "org-integration"
+	// projectValue is the project configured for the test.
+	projectValue = "proj-integration"
+	// queuedResponseBody is an initial responses API payload that requires polling.
+	queuedResponseBody = `{"id":"resp_queued","status":"queued"}`
+	// attributionHeadersMismatchFormat reports an upstream request without the expected attribution headers.
+	attributionHeadersMismatchFormat = "%s %s organization=%q project=%q want organization=%q project=%q"
+	// upstreamRequestCountFormat reports an unexpected number of upstream requests.
+	upstreamRequestCountFormat = "upstream requests=%d want at least %d"
+)
+
+// capturedUpstreamRequest records the attribution headers of one upstream call.
+type capturedUpstreamRequest struct {
+	method       string
+	path         string
+	organization string
+	project      string
+}
+
+// TestOpenAIAttributionHeaders verifies that the initial and polling requests carry the configured attribution headers
+// and that the headers are omitted when unset.
+func TestOpenAIAttributionHeaders(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name         string
+		organization string
+		project      string
+	}{
+		{name: "configured", organization: organizationValue, project: projectValue},
+		{name: "unset"},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			var capturedMutex sync.Mutex
+			var capturedRequests []capturedUpstreamRequest
+			openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				_, _ = io.Copy(io.Discard, httpRequest.Body)
+				capturedMutex.Lock()
+				capturedRequests = append(capturedRequests, capturedUpstreamRequest{
+					method:       httpRequest.Method,
+					path:         httpRequest.URL.Path,
+					organization: httpRequest.Header.Get(openAIOrganizationHeader),
+					project:      httpRequest.Header.Get(openAIProjectHeader),
+				})
+				capturedMutex.Unlock()
+				responseWriter.Header().Set("Content-Type", contentTypeJSON)
+				if httpRequest.Method == http.MethodPost {
+					_, _ = io.WriteString(responseWriter, queuedResponseBody)
+					return
+				}
+				_, _ = io.WriteString(responseWriter, completedResponseBody)
+			})
+			applicationServer := newIntegrationServer(subTest, openAIHandler, func(configuration *proxy.Configuration) {
+				configuration.OpenAIOrganization = testCase.organization
+				configuration.OpenAIProject = testCase.project
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			capturedMutex.Lock()
+			defer capturedMutex.Unlock()
+			if len(capturedRequests) < 2 {
+				subTest.Fatalf(upstreamRequestCountFormat, len(capturedRequests), 2)
+			}
+			for _, capturedRequest := range capturedRequests {
+				if capturedRequest.organization != testCase.organization || capturedRequest.project != testCase.project {
+					subTest.Fatalf(attributionHeadersMismatchFormat, capturedRequest.method, capturedRequest.path, capturedRequest.organization, capturedRequest.project, testCase.organization, testCase.project)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// errorObjectMessage is the message carried by the stubbed upstream error object.
+	errorObjectMessage = "The model is currently overloaded"
+	// expectedErrorObjectBody is the error returned to the client for an embedded upstream error.
+	expectedErrorObjectBody = "OpenAI API error: " + errorObjectMessage
+)
+
+// newStaticOpenAIHandler returns a stub handler that answers every request with HTTP 200 and the supplied body.
+func newStaticOpenAIHandler(responseBody string) http.HandlerFunc {
+	return func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		responseWriter.Header().Set("Content-Type", contentTypeJSON)
+		_, _ = io.WriteString(responseWriter, responseBody)
+	}
+}
+
+// TestOpenAIErrorObjectInSuccessfulResponse verifies that a 200 response carrying an error object is reported as an upstream error.
+func TestOpenAIErrorObjectInSuccessfulResponse(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		responseBody   string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "error object",
+			responseBody:   `{"error":{"message":"` + errorObjectMessage + `","type":"server_error"}}`,
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   expectedErrorObjectBody,
+		},
+		{
+			name:           "null error field",
+			responseBody:   `{"status":"completed","error":null,"output_text":"` + integrationOKBody + `"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   integrationOKBody,
+		},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			applicationServer := newIntegrationServer(subTest, newStaticOpenAIHandler(testCase.responseBody), nil)
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus || string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package integration_test
 import (
 	"io"
 	"net/http"
-	"net/http/httptest"
 	"net/url"
 	"testing"
 )
@@ -13,10 +12,9 @@ const (
 	expectedErrorMessage = "OpenAI API error"
 )
 
-// newMalformedOpenAIServer returns a stub OpenAI server emitting invalid JSON for the responses endpoint.
-func newMalformedOpenAIServer(testingInstance *testing.T) *httptest.Server {
-	testingInstance.Helper()
-	server := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+// newMalformedOpenAIHandler returns a stub OpenAI handler emitting invalid JSON for the responses endpoint.
+func newMalformedOpenAIHandler() http.HandlerFunc {
+	return func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
 		switch httpRequest.URL.Path {
 		case integrationModelsPath:
 			responseWriter.Header().Set("Content-Type", contentTypeJSON)
@@ -27,15 +25,12 @@ func newMalformedOpenAIServer(testingInstance *testing.T) *httptest.Server {
 		default:
 			http.NotFound(responseWriter, httpRequest)
 		}
-	}))
-	return server
+	}
 }
 
 // TestOpenAIMalformedJSON verifies that the proxy returns a 502 error when the upstream responds with invalid JSON.
 func TestOpenAIMalformedJSON(testingInstance *testing.T) {
-	openAIServer := newMalformedOpenAIServer(testingInstance)
-	testingInstance.Cleanup(openAIServer.Close)
-	applicationServer := newIntegrationServer(testingInstance, openAIServer)
+	applicationServer := newIntegrationServer(testingInstance, newMalformedOpenAIHandler(), nil)
 	requestURL, _ := url.Parse(applicationServer.URL)
 	queryValues := requestURL.Query()
 	queryValues.Set(promptQueryParameter, promptValue)
@@ -0,0 +1,66 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+// truncatedJSONBody is a responses API payload cut off mid-document.
+const truncatedJSONBody = `{"id":"resp_cut","status":"comp`
+
+// TestRetryOnParseFailure verifies that a malformed first response is retried once when enabled.
+func TestRetryOnParseFailure(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name             string
+		retryEnabled     bool
+		expectedAttempts int
+		expectedStatus   int
+		expectedBody     string
+	}{
+		{name: "retry returns the valid answer", retryEnabled: true, expectedAttempts: 2, expectedStatus: http.StatusOK, expectedBody: integrationOKBody},
+		{name: "disabled reports the malformed answer", retryEnabled: false, expectedAttempts: 1, expectedStatus: http.StatusBadGateway, expectedBody: expectedErrorMessage},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			var attemptMutex sync.Mutex
+			attemptCount := 0
+			openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				_, _ = io.Copy(io.Discard, httpRequest.Body)
+				attemptMutex.Lock()
+				attemptCount++
+				currentAttempt := attemptCount
+				attemptMutex.Unlock()
+				responseWriter.Header().Set("Content-Type", contentTypeJSON)
+				if currentAttempt == 1 {
+					_, _ = io.WriteString(responseWriter, truncatedJSONBody)
+					return
+				}
+				_, _ = io.WriteString(responseWriter, completedResponseBody)
+			})
+			applicationServer := newIntegrationServer(subTest, openAIHandler, func(configuration *proxy.Configuration) {
+				configuration.RetryOnParseFailure = testCase.retryEnabled
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus || string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+			attemptMutex.Lock()
+			defer attemptMutex.Unlock()
+			if attemptCount != testCase.expectedAttempts {
+				subTest.Fatalf(truncationAttemptsMismatchFormat, attemptCount, testCase.expectedAttempts)
+			}
+		})
+	}
+}
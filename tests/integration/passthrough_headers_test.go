@@ -0,0 +1,79 @@
+package integration_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// traceHeaderName is the inbound header allowlisted for passthrough.
+	traceHeaderName = "X-Trace-Id"
+	// traceHeaderValue is the trace identifier sent by the client.
+	traceHeaderValue = "trace-7f3a"
+	// tenantHeaderName is an inbound header that is not allowlisted.
+	tenantHeaderName = "X-Tenant"
+	// authorizationHeaderName carries the OpenAI key upstream.
+	authorizationHeaderName = "Authorization"
+	// upstreamHeaderMismatchFormat reports an unexpected header on the upstream request.
+	upstreamHeaderMismatchFormat = "upstream header %s=%q want %q"
+	// passthroughValidationFormat reports an allowlist naming Authorization that was accepted.
+	passthroughValidationFormat = "build router error=%v want %v"
+)
+
+// TestPassthroughHeaders verifies that allowlisted inbound headers reach the upstream request while other headers
+// and the client's Authorization do not, and that Authorization cannot be allowlisted.
+func TestPassthroughHeaders(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	upstreamHeaders := make(chan http.Header, 1)
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		upstreamHeaders <- httpRequest.Header.Clone()
+		responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+		_, _ = io.WriteString(responseWriter, tracedCompletedBody)
+	})
+
+	_, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret:      serviceSecretValue,
+		OpenAIKey:          openAIKeyValue,
+		LogLevel:           logLevelDebug,
+		WorkerCount:        1,
+		QueueSize:          4,
+		PassthroughHeaders: []string{traceHeaderName, "authorization"},
+		Endpoints:          proxy.NewEndpoints(),
+	}, newLogger(testingInstance))
+	if !errors.Is(buildRouterError, proxy.ErrInvalidPassthroughHeaders) {
+		testingInstance.Fatalf(passthroughValidationFormat, buildRouterError, proxy.ErrInvalidPassthroughHeaders)
+	}
+
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.PassthroughHeaders = []string{"x-trace-id"}
+	})
+
+	httpRequest, _ := http.NewRequest(http.MethodGet, applicationServer.URL+"?prompt=ping", nil)
+	httpRequest.Header.Set(authorizationHeaderName, "Bearer "+serviceSecretValue)
+	httpRequest.Header.Set(traceHeaderName, traceHeaderValue)
+	httpRequest.Header.Set(tenantHeaderName, "acme")
+	httpResponse, requestError := http.DefaultClient.Do(httpRequest)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	_ = httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+	}
+	forwardedHeaders := <-upstreamHeaders
+	expectedHeaders := map[string]string{
+		traceHeaderName:         traceHeaderValue,
+		tenantHeaderName:        "",
+		authorizationHeaderName: "Bearer " + openAIKeyValue,
+	}
+	for headerName, expectedValue := range expectedHeaders {
+		if forwardedValue := forwardedHeaders.Get(headerName); forwardedValue != expectedValue {
+			testingInstance.Fatalf(upstreamHeaderMismatchFormat, headerName, forwardedValue, expectedValue)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package integration_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// pollingResponseIdentifier identifies the session polled in the test.
+	pollingResponseIdentifier = "resp_polling"
+	// pollingInProgressBody is the state the session reports until the poll window ends.
+	pollingInProgressBody = `{"id":"` + pollingResponseIdentifier + `","status":"in_progress","output":[]}`
+	// pollingCompletedBody is the state the session reports once the poll window ends.
+	pollingCompletedBody = `{"id":"` + pollingResponseIdentifier + `","status":"completed","output_text":"POLLED"}`
+	// pollingWindow is how long the session stays in progress after it is created.
+	pollingWindow = 600 * time.Millisecond
+	// fastPollIntervalMillis is the poll interval configured for the aggressive proxy.
+	fastPollIntervalMillis = 20
+	// pollCountComparisonFormat reports poll counts that did not grow with the shorter interval.
+	pollCountComparisonFormat = "polls with %d ms interval=%d, with default interval=%d; want more with the shorter interval"
+)
+
+// countPolls runs one request through a proxy configured with pollIntervalMillis against an upstream whose session
+// completes after pollingWindow, and returns the number of polls the proxy issued.
+func countPolls(testingInstance *testing.T, pollIntervalMillis int) int64 {
+	testingInstance.Helper()
+	var pollCount atomic.Int64
+	var createdAt atomic.Int64
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+		switch {
+		case httpRequest.Method == http.MethodPost && httpRequest.URL.Path == integrationResponsesPath:
+			createdAt.Store(time.Now().UnixNano())
+			_, _ = io.WriteString(responseWriter, pollingInProgressBody)
+		case httpRequest.Method == http.MethodPost && strings.HasSuffix(httpRequest.URL.Path, continuePathSuffix):
+			_, _ = io.WriteString(responseWriter, pollingInProgressBody)
+		case httpRequest.Method == http.MethodGet && strings.HasSuffix(httpRequest.URL.Path, pollingResponseIdentifier):
+			pollCount.Add(1)
+			if time.Since(time.Unix(0, createdAt.Load())) < pollingWindow {
+				_, _ = io.WriteString(responseWriter, pollingInProgressBody)
+				return
+			}
+			_, _ = io.WriteString(responseWriter, pollingCompletedBody)
+		default:
+			http.NotFound(responseWriter, httpRequest)
+		}
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.PollIntervalMillis = pollIntervalMillis
+	})
+
+	httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	_ = httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+	}
+	return pollCount.Load()
+}
+
+// TestPollIntervalControlsPollRate verifies that a shorter configured poll interval polls an in-progress response
+// more often within the same window than the default interval.
+func TestPollIntervalControlsPollRate(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fastPolls := countPolls(testingInstance, fastPollIntervalMillis)
+	defaultPolls := countPolls(testingInstance, 0)
+	if fastPolls <= defaultPolls {
+		testingInstance.Fatalf(pollCountComparisonFormat, fastPollIntervalMillis, fastPolls, defaultPolls)
+	}
+}
+
+// TestPollIntervalRejectsNegative verifies that BuildRouter refuses a negative poll interval.
+func TestPollIntervalRejectsNegative(testingInstance *testing.T) {
+	_, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret:      serviceSecretValue,
+		OpenAIKey:          openAIKeyValue,
+		LogLevel:           logLevelDebug,
+		WorkerCount:        1,
+		QueueSize:          1,
+		PollIntervalMillis: -1,
+		Endpoints:          proxy.NewEndpoints(),
+	}, newLogger(testingInstance))
+	if !errors.Is(buildRouterError, proxy.ErrInvalidPollInterval) {
+		testingInstance.Fatalf(expectedErrorFormat, proxy.ErrInvalidPollInterval, buildRouterError)
+	}
+}
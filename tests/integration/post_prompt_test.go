@@ -0,0 +1,94 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// largePromptSize is the size in bytes of the prompt sent through POST.
+	largePromptSize = 50 * 1024
+	// oversizedPostBodyBytes exceeds the 1 MiB limit on POST bodies.
+	oversizedPostBodyBytes = 1<<20 + 1
+	// contentTypeFormURLEncoded is the media type of HTML form bodies.
+	contentTypeFormURLEncoded = "application/x-www-form-urlencoded"
+	// contentTypeTextPlain is the media type of raw text bodies.
+	contentTypeTextPlain = "text/plain; charset=utf-8"
+	// largePromptMismatchFormat reports a forwarded prompt that differs from the posted one.
+	largePromptMismatchFormat = "forwarded input length=%d want=%d"
+)
+
+// TestPostDeliversLargePrompt verifies that a 50KB prompt posted as a form or plain text reaches the upstream payload intact.
+func TestPostDeliversLargePrompt(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	largePrompt := strings.Repeat("abcdefghij", largePromptSize/10)
+	testCases := []struct {
+		name        string
+		contentType string
+		requestBody string
+	}{
+		{name: "form body", contentType: contentTypeFormURLEncoded, requestBody: url.Values{promptQueryParameter: {largePrompt}}.Encode()},
+		{name: "plain text body", contentType: contentTypeTextPlain, requestBody: largePrompt},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, nil)
+
+			httpResponse, requestError := http.Post(server.URL+"?key="+serviceSecretValue, testCase.contentType, strings.NewReader(testCase.requestBody))
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != integrationOKBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+			}
+			forwardedInput, _ := (*capturedPayload)[inputField].(string)
+			if forwardedInput != largePrompt {
+				subTest.Fatalf(largePromptMismatchFormat, len(forwardedInput), len(largePrompt))
+			}
+		})
+	}
+}
+
+// TestPostRequiresSecretAndSupportedBody verifies that POST requests keep the secret check and reject unknown media types.
+func TestPostRequiresSecretAndSupportedBody(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		query          string
+		contentType    string
+		requestBody    string
+		expectedStatus int
+	}{
+		{name: "missing key", query: "", contentType: contentTypeTextPlain, requestBody: promptValue, expectedStatus: http.StatusForbidden},
+		{name: "json body", query: "?key=" + serviceSecretValue, contentType: contentTypeJSON, requestBody: promptValue, expectedStatus: http.StatusUnsupportedMediaType},
+		{name: "empty body", query: "?key=" + serviceSecretValue, contentType: contentTypeTextPlain, requestBody: "", expectedStatus: http.StatusBadRequest},
+		{name: "oversized text body", query: "?key=" + serviceSecretValue, contentType: contentTypeTextPlain, requestBody: strings.Repeat("x", oversizedPostBodyBytes), expectedStatus: http.StatusRequestEntityTooLarge},
+		{name: "oversized form body", query: "?key=" + serviceSecretValue, contentType: contentTypeFormURLEncoded, requestBody: url.Values{promptQueryParameter: {strings.Repeat("x", oversizedPostBodyBytes)}}.Encode(), expectedStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, _ := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, nil)
+
+			httpResponse, requestError := http.Post(server.URL+testCase.query, testCase.contentType, strings.NewReader(testCase.requestBody))
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// previousResponseIDQueryParameter continues the conversation of an earlier response.
+	previousResponseIDQueryParameter = "previous_response_id"
+	// responseIDHeader carries the id of the upstream response that produced the answer.
+	responseIDHeader = "X-Response-Id"
+	// earlierResponseIdentifier is the id of the turn the test continues from.
+	earlierResponseIdentifier = "resp_earlier_turn"
+	// responseIDHeaderMismatchFormat reports an unexpected X-Response-Id header.
+	responseIDHeaderMismatchFormat = "X-Response-Id=%q want %q"
+)
+
+// TestPreviousResponseIDChainsTurns verifies that answers report their upstream response id in X-Response-Id, that
+// previous_response_id is forwarded in the upstream payload, and that malformed ids are rejected.
+func TestPreviousResponseIDChainsTurns(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var captureMutex sync.Mutex
+	var capturedPayload map[string]any
+	upstreamCalls := 0
+	client := &http.Client{Transport: roundTripperFunc(func(httpRequest *http.Request) (*http.Response, error) {
+		captureMutex.Lock()
+		defer captureMutex.Unlock()
+		if httpRequest.Method == http.MethodPost {
+			upstreamCalls++
+			capturedPayload = nil
+			_ = json.NewDecoder(httpRequest.Body).Decode(&capturedPayload)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(tracedCompletedBody)), Header: make(http.Header)}, nil
+	})}
+	endpoints := proxy.NewEndpoints()
+	server := newRouterServer(testingInstance, client, endpoints, nil)
+
+	sendTurn := func(previousResponseID string) *http.Response {
+		requestURL, _ := url.Parse(server.URL)
+		queryValues := requestURL.Query()
+		queryValues.Set(promptQueryParameter, promptValue)
+		queryValues.Set(keyQueryParameter, serviceSecretValue)
+		if previousResponseID != "" {
+			queryValues.Set(previousResponseIDQueryParameter, previousResponseID)
+		}
+		requestURL.RawQuery = queryValues.Encode()
+		httpResponse, requestError := http.Get(requestURL.String())
+		if requestError != nil {
+			testingInstance.Fatalf(requestErrorFormat, requestError)
+		}
+		_ = httpResponse.Body.Close()
+		return httpResponse
+	}
+
+	firstTurn := sendTurn("")
+	if firstTurn.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, firstTurn.StatusCode, http.StatusOK)
+	}
+	if responseID := firstTurn.Header.Get(responseIDHeader); responseID != tracedResponseIdentifier {
+		testingInstance.Fatalf(responseIDHeaderMismatchFormat, responseID, tracedResponseIdentifier)
+	}
+	captureMutex.Lock()
+	if forwardedID, present := capturedPayload[previousResponseIDQueryParameter]; present {
+		testingInstance.Fatalf(previousResponseMismatchFormat, forwardedID, nil)
+	}
+	captureMutex.Unlock()
+
+	secondTurn := sendTurn(earlierResponseIdentifier)
+	if secondTurn.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, secondTurn.StatusCode, http.StatusOK)
+	}
+	captureMutex.Lock()
+	if capturedPayload[previousResponseIDQueryParameter] != earlierResponseIdentifier {
+		testingInstance.Fatalf(previousResponseMismatchFormat, capturedPayload[previousResponseIDQueryParameter], earlierResponseIdentifier)
+	}
+	callsBeforeRejection := upstreamCalls
+	captureMutex.Unlock()
+
+	rejectedTurn := sendTurn("resp earlier/turn")
+	if rejectedTurn.StatusCode != http.StatusBadRequest {
+		testingInstance.Fatalf(statusWantFormat, rejectedTurn.StatusCode, http.StatusBadRequest)
+	}
+	captureMutex.Lock()
+	defer captureMutex.Unlock()
+	if upstreamCalls != callsBeforeRejection {
+		testingInstance.Fatalf("upstream calls=%d want %d", upstreamCalls, callsBeforeRejection)
+	}
+}
@@ -0,0 +1,115 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// pricingPath serves the configured model token prices.
+	pricingPath = "/pricing"
+	// pricingMismatchFormat reports an unexpected pricing document.
+	pricingMismatchFormat = "pricing=%+v want=%+v"
+)
+
+// pricingEntry mirrors one model entry of the pricing document.
+type pricingEntry struct {
+	Model  string  `json:"model"`
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
+}
+
+// pricingResponse mirrors the JSON document served by the pricing endpoint.
+type pricingResponse struct {
+	Unit string         `json:"unit"`
+	Data []pricingEntry `json:"data"`
+}
+
+// TestPricingEndpoint verifies that configured prices are served sorted by model and that the endpoint is absent
+// without configured prices.
+func TestPricingEndpoint(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name             string
+		modelPricing     map[string]proxy.ModelPrice
+		expectedStatus   int
+		expectedDocument pricingResponse
+	}{
+		{
+			name: "configured prices",
+			modelPricing: map[string]proxy.ModelPrice{
+				proxy.ModelNameGPT4o: {InputPerMillionTokens: 2.5, OutputPerMillionTokens: 10},
+				proxy.ModelNameGPT41: {InputPerMillionTokens: 2, OutputPerMillionTokens: 8},
+			},
+			expectedStatus: http.StatusOK,
+			expectedDocument: pricingResponse{
+				Unit: "per_million_tokens",
+				Data: []pricingEntry{
+					{Model: proxy.ModelNameGPT41, Input: 2, Output: 8},
+					{Model: proxy.ModelNameGPT4o, Input: 2.5, Output: 10},
+				},
+			},
+		},
+		{name: "no prices", expectedStatus: http.StatusNotFound},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			router, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+				ServiceSecret: serviceSecretValue,
+				OpenAIKey:     openAIKeyValue,
+				LogLevel:      logLevelDebug,
+				WorkerCount:   1,
+				QueueSize:     1,
+				ModelPricing:  testCase.modelPricing,
+				Endpoints:     proxy.NewEndpoints(),
+			}, newLogger(subTest))
+			if buildRouterError != nil {
+				subTest.Fatalf(buildRouterFailedFormat, buildRouterError)
+			}
+			applicationServer := httptest.NewServer(router)
+			subTest.Cleanup(applicationServer.Close)
+
+			httpResponse, requestError := http.Get(applicationServer.URL + pricingPath + "?key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				return
+			}
+			var document pricingResponse
+			if decodeError := json.NewDecoder(httpResponse.Body).Decode(&document); decodeError != nil {
+				subTest.Fatalf(requestErrorFormat, decodeError)
+			}
+			if !reflect.DeepEqual(document, testCase.expectedDocument) {
+				subTest.Fatalf(pricingMismatchFormat, document, testCase.expectedDocument)
+			}
+		})
+	}
+}
+
+// TestPricingRejectsNegativePrices verifies that BuildRouter refuses negative token prices.
+func TestPricingRejectsNegativePrices(testingInstance *testing.T) {
+	_, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret: serviceSecretValue,
+		OpenAIKey:     openAIKeyValue,
+		LogLevel:      logLevelDebug,
+		WorkerCount:   1,
+		QueueSize:     1,
+		ModelPricing:  map[string]proxy.ModelPrice{proxy.ModelNameGPT41: {InputPerMillionTokens: -1}},
+		Endpoints:     proxy.NewEndpoints(),
+	}, newLogger(testingInstance))
+	if !errors.Is(buildRouterError, proxy.ErrInvalidModelPricing) {
+		testingInstance.Fatalf(expectedErrorFormat, proxy.ErrInvalidModelPricing, buildRouterError)
+	}
+}
@@ -0,0 +1,64 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// fastDirective is the prompt directive mapped to a faster model.
+	fastDirective = "@fast:"
+	// modelField identifies the model request field.
+	modelField = "model"
+	// modelMismatchFormat reports an unexpected model in the captured payload.
+	modelMismatchFormat = "model=%v want=%v"
+	// inputExactMismatchFormat reports an unexpected input value in the captured payload.
+	inputExactMismatchFormat = "input=%q want=%q"
+)
+
+// TestPromptPrefixRoutesToMappedModel verifies that a recognized directive selects the mapped model and is stripped.
+func TestPromptPrefixRoutesToMappedModel(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name          string
+		prompt        string
+		expectedModel string
+		expectedInput string
+	}{
+		{name: "recognized directive", prompt: fastDirective + " hello", expectedModel: proxy.ModelNameGPT4oMini, expectedInput: "hello"},
+		{name: "unrecognized directive", prompt: "@slow: hello", expectedModel: proxy.DefaultModel, expectedInput: "@slow: hello"},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, captured := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.QueueSize = 8
+				configuration.PromptPrefixModelMap = map[string]string{fastDirective: proxy.ModelNameGPT4oMini}
+			})
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, testCase.prompt)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(getFailedFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			if (*captured)[modelField] != testCase.expectedModel {
+				subTest.Fatalf(modelMismatchFormat, (*captured)[modelField], testCase.expectedModel)
+			}
+			if (*captured)[inputField] != testCase.expectedInput {
+				subTest.Fatalf(inputExactMismatchFormat, (*captured)[inputField], testCase.expectedInput)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// templatePromptPrefix is the prompt prefix configured on the proxy.
+	templatePromptPrefix = "GUARDRAILS_BEGIN\n"
+	// templatePromptSuffix is the prompt suffix configured on the proxy.
+	templatePromptSuffix = "\nGUARDRAILS_END"
+	// templateInputMismatchFormat reports an unexpected upstream input.
+	templateInputMismatchFormat = "input=%q want %q"
+)
+
+// TestPromptTemplateWrapsInput verifies that the configured prompt prefix and suffix wrap the upstream input around
+// the system prompt, including one supplied through the system_prompt override.
+func TestPromptTemplateWrapsInput(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name                 string
+		systemPromptOverride string
+		expectedSystemPrompt string
+	}{
+		{name: "configured system prompt", expectedSystemPrompt: configuredSystemPrompt},
+		{name: "system prompt override", systemPromptOverride: overrideSystemPrompt, expectedSystemPrompt: overrideSystemPrompt},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, captured := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.SystemPrompt = configuredSystemPrompt
+				configuration.PromptPrefix = templatePromptPrefix
+				configuration.PromptSuffix = templatePromptSuffix
+			})
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			if testCase.systemPromptOverride != "" {
+				queryValues.Set(systemPromptQueryParameter, testCase.systemPromptOverride)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			inputValue, _ := (*captured)[inputField].(string)
+			expectedInput := templatePromptPrefix + testCase.expectedSystemPrompt + "\n\n" + promptValue + templatePromptSuffix
+			if inputValue != expectedInput {
+				subTest.Fatalf(templateInputMismatchFormat, inputValue, expectedInput)
+			}
+		})
+	}
+}
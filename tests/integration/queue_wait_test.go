@@ -0,0 +1,87 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// queueWaitHeader reports how long a request waited for a worker.
+	queueWaitHeader = "X-Queue-Wait-Ms"
+	// saturatedWorkerHold is how long the only worker stays busy after the queued request arrives.
+	saturatedWorkerHold = 100 * time.Millisecond
+	// queuedResponseDeadline bounds the wait for each response once the worker is released.
+	queuedResponseDeadline = 2 * time.Second
+	// latencyHeaderFormat reports an unexpected latency header value.
+	latencyHeaderFormat = "%s=%q want at least %d"
+)
+
+// TestQueueWaitHeader verifies that a request queued behind a busy single worker reports its wait in the
+// X-Queue-Wait-Ms header, next to the upstream latency header.
+func TestQueueWaitHeader(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	endpoints := proxy.NewEndpoints()
+	slowCallStarted := make(chan struct{})
+	releaseSlowCalls := make(chan struct{})
+	client := makeSlowModelHTTPClient(testingInstance, endpoints, proxy.ModelNameGPT5, slowCallStarted, releaseSlowCalls)
+	server := newRouterServer(testingInstance, client, endpoints, func(configuration *proxy.Configuration) {
+		configuration.QueueSize = 2
+		configuration.ExposeQueueWait = true
+		configuration.ExposeUpstreamLatency = true
+	})
+	requestURL, _ := url.Parse(server.URL)
+	queryValues := requestURL.Query()
+	queryValues.Set(promptQueryParameter, promptValue)
+	queryValues.Set(keyQueryParameter, serviceSecretValue)
+	queryValues.Set(adaptiveModelQueryParameter, proxy.ModelNameGPT5)
+	requestURL.RawQuery = queryValues.Encode()
+
+	responses := make(chan *http.Response, 2)
+	sendRequest := func() {
+		httpResponse, requestError := http.Get(requestURL.String())
+		if requestError != nil {
+			testingInstance.Errorf(requestErrorFormat, requestError)
+			responses <- nil
+			return
+		}
+		_ = httpResponse.Body.Close()
+		responses <- httpResponse
+	}
+	go sendRequest()
+	<-slowCallStarted
+	go sendRequest()
+	time.Sleep(saturatedWorkerHold)
+	close(releaseSlowCalls)
+
+	maximumQueueWait := int64(-1)
+	for range 2 {
+		select {
+		case httpResponse := <-responses:
+			if httpResponse == nil {
+				testingInstance.FailNow()
+			}
+			if httpResponse.StatusCode != http.StatusOK {
+				testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			if _, parseError := strconv.ParseInt(httpResponse.Header.Get(upstreamLatencyHeader), 10, 64); parseError != nil {
+				testingInstance.Fatalf(latencyHeaderFormat, upstreamLatencyHeader, httpResponse.Header.Get(upstreamLatencyHeader), 0)
+			}
+			queueWait, parseError := strconv.ParseInt(httpResponse.Header.Get(queueWaitHeader), 10, 64)
+			if parseError != nil {
+				testingInstance.Fatalf(latencyHeaderFormat, queueWaitHeader, httpResponse.Header.Get(queueWaitHeader), 0)
+			}
+			maximumQueueWait = max(maximumQueueWait, queueWait)
+		case <-time.After(queuedResponseDeadline):
+			testingInstance.Fatalf(latencyHeaderFormat, queueWaitHeader, "", 1)
+		}
+	}
+	if maximumQueueWait < 1 {
+		testingInstance.Fatalf(latencyHeaderFormat, queueWaitHeader, strconv.FormatInt(maximumQueueWait, 10), 1)
+	}
+}
@@ -0,0 +1,56 @@
+package integration_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// retryAfterHeader tells a rate-limited client how long to wait.
+	retryAfterHeader = "Retry-After"
+	// rateLimitBurst is the number of requests allowed at once in the test.
+	rateLimitBurst = 2
+	// rateLimitRequestCount is the number of requests fired back to back, more than the burst allows.
+	rateLimitRequestCount = 5
+	// rateLimitCountFormat reports an unexpected split between accepted and limited requests.
+	rateLimitCountFormat = "accepted=%d limited=%d want accepted=%d limited=%d"
+	// retryAfterInvalidFormat reports a missing or malformed Retry-After header.
+	retryAfterInvalidFormat = "Retry-After=%q want a positive number of seconds"
+)
+
+// TestRateLimitPerClient verifies that requests beyond the burst from one client are rejected with 429 and Retry-After.
+func TestRateLimitPerClient(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	applicationServer := newIntegrationServer(testingInstance, newStaticOpenAIHandler(completedResponseBody), func(configuration *proxy.Configuration) {
+		configuration.RateLimitPerSecond = 0.1
+		configuration.RateLimitBurst = rateLimitBurst
+	})
+
+	acceptedCount, limitedCount := 0, 0
+	for requestIndex := 0; requestIndex < rateLimitRequestCount; requestIndex++ {
+		httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+		if requestError != nil {
+			testingInstance.Fatalf(requestErrorFormat, requestError)
+		}
+		_ = httpResponse.Body.Close()
+		switch httpResponse.StatusCode {
+		case http.StatusOK:
+			acceptedCount++
+		case http.StatusTooManyRequests:
+			limitedCount++
+			retryAfter := httpResponse.Header.Get(retryAfterHeader)
+			if retrySeconds, parseError := strconv.Atoi(retryAfter); parseError != nil || retrySeconds <= 0 {
+				testingInstance.Fatalf(retryAfterInvalidFormat, retryAfter)
+			}
+		default:
+			testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+		}
+	}
+	if acceptedCount != rateLimitBurst || limitedCount != rateLimitRequestCount-rateLimitBurst {
+		testingInstance.Fatalf(rateLimitCountFormat, acceptedCount, limitedCount, rateLimitBurst, rateLimitRequestCount-rateLimitBurst)
+	}
+}
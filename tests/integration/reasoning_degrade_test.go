@@ -0,0 +1,116 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// degradeQueuedRequests is the number of requests queued behind the blocked one.
+	degradeQueuedRequests = 3
+	// degradeQueueDepth is the queue length above which reasoning effort is degraded in the test.
+	degradeQueueDepth = 1
+	// degradeQueueSettleDelay gives the queued requests time to reach the task queue.
+	degradeQueueSettleDelay = 300 * time.Millisecond
+	// degradedEffortsFormat reports the reasoning efforts forwarded upstream in order.
+	degradedEffortsFormat = "forwarded efforts=%v want %v"
+)
+
+// makeGatedEffortHTTPClient returns an HTTP client that records the reasoning effort of each responses call and holds
+// the first call until release is closed. started is closed once the first call arrives.
+func makeGatedEffortHTTPClient(testingInstance *testing.T, endpoints *proxy.Endpoints, started chan struct{}, release chan struct{}) (*http.Client, func() []string) {
+	testingInstance.Helper()
+	var recordMutex sync.Mutex
+	var forwardedEfforts []string
+	client := &http.Client{Transport: roundTripperFunc(func(httpRequest *http.Request) (*http.Response, error) {
+		switch {
+		case httpRequest.URL.String() == endpoints.GetModelsURL():
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(availableModelsBody)), Header: make(http.Header)}, nil
+		case httpRequest.URL.String() == endpoints.GetResponsesURL():
+			var payload map[string]any
+			requestBytes, _ := io.ReadAll(httpRequest.Body)
+			_ = json.Unmarshal(requestBytes, &payload)
+			forwardedEffort := ""
+			if reasoningObject, isObject := payload[reasoningField].(map[string]any); isObject {
+				forwardedEffort, _ = reasoningObject[effortField].(string)
+			}
+			recordMutex.Lock()
+			forwardedEfforts = append(forwardedEfforts, forwardedEffort)
+			firstCall := len(forwardedEfforts) == 1
+			recordMutex.Unlock()
+			if firstCall {
+				close(started)
+				<-release
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"output_text":"` + integrationOKBody + `"}`)), Header: make(http.Header)}, nil
+		default:
+			testingInstance.Fatalf(unexpectedRequestFormat, httpRequest.URL.String())
+			return nil, nil
+		}
+	})}
+	return client, func() []string {
+		recordMutex.Lock()
+		defer recordMutex.Unlock()
+		return append([]string(nil), forwardedEfforts...)
+	}
+}
+
+// TestDegradeReasoningUnderLoad verifies that a request taken from a deep queue is sent with a lower reasoning effort
+// and that the requested effort returns once the queue drains.
+func TestDegradeReasoningUnderLoad(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	endpoints := proxy.NewEndpoints()
+	firstCallStarted := make(chan struct{})
+	releaseFirstCall := make(chan struct{})
+	client, forwardedEfforts := makeGatedEffortHTTPClient(testingInstance, endpoints, firstCallStarted, releaseFirstCall)
+	server := newRouterServer(testingInstance, client, endpoints, func(configuration *proxy.Configuration) {
+		configuration.DegradeReasoningUnderLoad = true
+		configuration.DegradeReasoningQueueDepth = degradeQueueDepth
+	})
+	requestURL, _ := url.Parse(server.URL)
+	queryValues := requestURL.Query()
+	queryValues.Set(promptQueryParameter, promptValue)
+	queryValues.Set(keyQueryParameter, serviceSecretValue)
+	queryValues.Set(adaptiveModelQueryParameter, proxy.ModelNameGPT5)
+	queryValues.Set(reasoningEffortQueryParameter, "high")
+	requestURL.RawQuery = queryValues.Encode()
+
+	var waitGroup sync.WaitGroup
+	sendRequest := func() {
+		defer waitGroup.Done()
+		httpResponse, requestError := http.Get(requestURL.String())
+		if requestError != nil {
+			testingInstance.Errorf(requestErrorFormat, requestError)
+			return
+		}
+		_ = httpResponse.Body.Close()
+		if httpResponse.StatusCode != http.StatusOK {
+			testingInstance.Errorf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+		}
+	}
+	waitGroup.Add(1)
+	go sendRequest()
+	<-firstCallStarted
+	waitGroup.Add(degradeQueuedRequests)
+	for requestIndex := 0; requestIndex < degradeQueuedRequests; requestIndex++ {
+		go sendRequest()
+	}
+	time.Sleep(degradeQueueSettleDelay)
+	close(releaseFirstCall)
+	waitGroup.Wait()
+
+	expectedEfforts := []string{"high", "medium", "high", "high"}
+	actualEfforts := forwardedEfforts()
+	if strings.Join(actualEfforts, ",") != strings.Join(expectedEfforts, ",") {
+		testingInstance.Fatalf(degradedEffortsFormat, actualEfforts, expectedEfforts)
+	}
+}
@@ -0,0 +1,65 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// reasoningEffortQueryParameter selects the reasoning effort for one request.
+	reasoningEffortQueryParameter = "reasoning_effort"
+	// reasoningObjectMismatchFormat reports an unexpected reasoning object in the captured payload.
+	reasoningObjectMismatchFormat = "reasoning=%v want effort=%q"
+)
+
+// TestReasoningEffortOverride verifies that reasoning_effort reaches reasoning-capable models and rejects unknown levels.
+func TestReasoningEffortOverride(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		reasoningLevel string
+		expectedStatus int
+		expectedEffort string
+	}{
+		{name: "high", reasoningLevel: "high", expectedStatus: http.StatusOK, expectedEffort: "high"},
+		{name: "omitted keeps default", reasoningLevel: "", expectedStatus: http.StatusOK, expectedEffort: "medium"},
+		{name: "unknown level", reasoningLevel: "extreme", expectedStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, nil)
+
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, proxy.ModelNameGPT5)
+			queryValues.Set(webSearchQueryParameter, "1")
+			if testCase.reasoningLevel != "" {
+				queryValues.Set(reasoningEffortQueryParameter, testCase.reasoningLevel)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				return
+			}
+			reasoningObject, isObject := (*capturedPayload)[reasoningField].(map[string]any)
+			if !isObject || reasoningObject[effortField] != testCase.expectedEffort {
+				subTest.Fatalf(reasoningObjectMismatchFormat, (*capturedPayload)[reasoningField], testCase.expectedEffort)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// recentRequestsPath serves the recent request summaries.
+	recentRequestsPath = "/recent"
+	// recentBufferSize is the ring buffer capacity configured for the test.
+	recentBufferSize = 2
+	// recentRequestsMismatchFormat reports unexpected recent request summaries.
+	recentRequestsMismatchFormat = "recent requests=%+v want models %v"
+)
+
+// recentRequestsResponse mirrors the JSON document served by the recent endpoint.
+type recentRequestsResponse struct {
+	Requests []struct {
+		Timestamp         string `json:"timestamp"`
+		Model             string `json:"model"`
+		Status            int    `json:"status"`
+		LatencyMillis     int64  `json:"latency_ms"`
+		ClientFingerprint string `json:"client_fingerprint"`
+	} `json:"requests"`
+}
+
+// TestRecentRequests verifies that the newest requests appear in the ring buffer output, most recent first.
+func TestRecentRequests(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	applicationServer := newIntegrationServer(testingInstance, newStaticOpenAIHandler(completedResponseBody), func(configuration *proxy.Configuration) {
+		configuration.RecentBufferSize = recentBufferSize
+	})
+
+	for _, requestedModel := range []string{proxy.ModelNameGPT41, proxy.ModelNameGPT4o, proxy.ModelNameGPT4oMini} {
+		requestURL, _ := url.Parse(applicationServer.URL)
+		queryValues := requestURL.Query()
+		queryValues.Set(promptQueryParameter, promptValue)
+		queryValues.Set(keyQueryParameter, serviceSecretValue)
+		queryValues.Set(adaptiveModelQueryParameter, requestedModel)
+		requestURL.RawQuery = queryValues.Encode()
+		httpResponse, requestError := http.Get(requestURL.String())
+		if requestError != nil {
+			testingInstance.Fatalf(requestErrorFormat, requestError)
+		}
+		_ = httpResponse.Body.Close()
+		if httpResponse.StatusCode != http.StatusOK {
+			testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+		}
+	}
+
+	httpResponse, requestError := http.Get(applicationServer.URL + recentRequestsPath + "?key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+	}
+	var recentRequests recentRequestsResponse
+	if decodeError := json.NewDecoder(httpResponse.Body).Decode(&recentRequests); decodeError != nil {
+		testingInstance.Fatalf(requestErrorFormat, decodeError)
+	}
+	expectedModels := []string{proxy.ModelNameGPT4oMini, proxy.ModelNameGPT4o}
+	if len(recentRequests.Requests) != len(expectedModels) {
+		testingInstance.Fatalf(recentRequestsMismatchFormat, recentRequests.Requests, expectedModels)
+	}
+	for summaryIndex, summary := range recentRequests.Requests {
+		if summary.Model != expectedModels[summaryIndex] || summary.Status != http.StatusOK || summary.Timestamp == "" || summary.ClientFingerprint == "" {
+			testingInstance.Fatalf(recentRequestsMismatchFormat, recentRequests.Requests, expectedModels)
+		}
+	}
+}
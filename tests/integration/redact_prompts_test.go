@@ -0,0 +1,113 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/proxy"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const (
+	// sensitivePromptText is prompt text that must not appear in logs when prompts are redacted.
+	sensitivePromptText = "DIAGNOSIS_FOR_JANE_DOE"
+	// logEventResponseSent is the log message carrying the response status and latency.
+	logEventResponseSent = "response sent"
+	// logFieldStatus is the structured field holding the response status.
+	logFieldStatus = "status"
+	// sensitiveTextVisibilityFormat reports that logged sensitive text did not match the redaction setting.
+	sensitiveTextVisibilityFormat = "sensitive text logged=%v want %v"
+	// responseMetadataMissingFormat reports a response log entry without its status or latency.
+	responseMetadataMissingFormat = "response log entry missing status or latency: %v"
+)
+
+// TestRedactPrompts verifies that RedactPrompts removes prompt and response text from every log entry while the
+// response status and latency are still logged, and that both texts are logged when it is unset.
+func TestRedactPrompts(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name          string
+		redactPrompts bool
+	}{
+		{name: "redacted", redactPrompts: true},
+		{name: "logged", redactPrompts: false},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			openAIServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+				_, _ = io.WriteString(responseWriter, redactedResponseBody)
+			}))
+			subTest.Cleanup(openAIServer.Close)
+			endpoints := proxy.NewEndpoints()
+			endpoints.SetResponsesURL(openAIServer.URL + integrationResponsesPath)
+			originalClient := proxy.HTTPClient
+			proxy.HTTPClient = openAIServer.Client()
+			subTest.Cleanup(func() { proxy.HTTPClient = originalClient })
+
+			observedCore, observedLogs := observer.New(zapcore.DebugLevel)
+			router, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+				ServiceSecret: serviceSecretValue,
+				OpenAIKey:     openAIKeyValue,
+				LogLevel:      logLevelDebug,
+				WorkerCount:   1,
+				QueueSize:     4,
+				RedactPrompts: testCase.redactPrompts,
+				Endpoints:     endpoints,
+			}, zap.New(observedCore).Sugar())
+			if buildRouterError != nil {
+				subTest.Fatalf(buildRouterFailedFormat, buildRouterError)
+			}
+			applicationServer := httptest.NewServer(router)
+			subTest.Cleanup(applicationServer.Close)
+
+			requestURL, _ := url.Parse(applicationServer.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, sensitivePromptText)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if string(responseBytes) != sensitiveAnswerText {
+				subTest.Fatalf(bodyMismatchFormat, string(responseBytes), sensitiveAnswerText)
+			}
+
+			promptLogged, answerLogged := false, false
+			for _, loggedEntry := range observedLogs.All() {
+				for _, fieldValue := range loggedEntry.ContextMap() {
+					valueText, isText := fieldValue.(string)
+					if !isText {
+						continue
+					}
+					promptLogged = promptLogged || strings.Contains(valueText, sensitivePromptText)
+					answerLogged = answerLogged || strings.Contains(valueText, sensitiveAnswerText)
+				}
+			}
+			if promptLogged == testCase.redactPrompts || answerLogged == testCase.redactPrompts {
+				subTest.Fatalf(sensitiveTextVisibilityFormat, promptLogged || answerLogged, !testCase.redactPrompts)
+			}
+			responseEntries := observedLogs.FilterMessage(logEventResponseSent).All()
+			if len(responseEntries) == 0 {
+				subTest.Fatalf(responseMetadataMissingFormat, constants.EmptyString)
+			}
+			responseFields := responseEntries[0].ContextMap()
+			_, statusLogged := responseFields[logFieldStatus]
+			_, latencyLogged := responseFields[constants.LogFieldLatencyMilliseconds]
+			if !statusLogged || !latencyLogged {
+				subTest.Fatalf(responseMetadataMissingFormat, responseFields)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// fallbackAnswerText is the text the proxy synthesizes when the model ends with a web search and no answer.
+	fallbackAnswerText = `Model did not provide a final answer. Last web search: "final query"`
+	// webSearchOnlyResponseBody is a completed payload whose only output item is a web search call.
+	webSearchOnlyResponseBody = `{"id":"resp_search","status":"completed","output":[{"type":"web_search_call","action":{"query":"final query"}}]}`
+	// echoedFallbackResponseBody is a completed payload whose genuine answer repeats the fallback text verbatim.
+	echoedFallbackResponseBody = `{"id":"resp_echo","status":"completed","output_text":"Model did not provide a final answer. Last web search: \"final query\""}`
+)
+
+// TestRejectFallbackAnswer verifies that only the synthesized fallback is rejected, not a model answer with the same text.
+func TestRejectFallbackAnswer(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		responseBody   string
+		rejectFallback bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "fallback rejected", responseBody: webSearchOnlyResponseBody, rejectFallback: true, expectedStatus: http.StatusBadGateway, expectedBody: "OpenAI API error (no final answer)"},
+		{name: "echoed fallback text", responseBody: echoedFallbackResponseBody, rejectFallback: true, expectedStatus: http.StatusOK, expectedBody: fallbackAnswerText},
+		{name: "fallback allowed", responseBody: webSearchOnlyResponseBody, rejectFallback: false, expectedStatus: http.StatusOK, expectedBody: fallbackAnswerText},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			applicationServer := newIntegrationServer(subTest, newStaticOpenAIHandler(testCase.responseBody), func(configuration *proxy.Configuration) {
+				configuration.RejectFallbackAnswer = testCase.rejectFallback
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus || string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// estimatedCostHeader reports the estimated cost of a request in US dollars.
+	estimatedCostHeader = "X-Estimated-Cost-USD"
+	// estimatedCostMismatchFormat reports an unexpected estimated cost header.
+	estimatedCostMismatchFormat = "estimated cost=%q want=%q"
+)
+
+// TestReportCost verifies that the estimated cost prices the stubbed usage with the configured model price.
+func TestReportCost(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gpt41Pricing := map[string]proxy.ModelPrice{proxy.ModelNameGPT41: {InputPerMillionTokens: 2, OutputPerMillionTokens: 8}}
+	testCases := []struct {
+		name         string
+		reportCost   bool
+		responseBody string
+		modelPricing map[string]proxy.ModelPrice
+		expectedCost string
+	}{
+		// 12 input tokens at $2/M plus 5 output tokens at $8/M.
+		{name: "priced usage", reportCost: true, responseBody: usageResponseBody, modelPricing: gpt41Pricing, expectedCost: "0.000064"},
+		{name: "disabled", reportCost: false, responseBody: usageResponseBody, modelPricing: gpt41Pricing, expectedCost: constants.EmptyString},
+		{name: "usage absent", reportCost: true, responseBody: completedResponseBody, modelPricing: gpt41Pricing, expectedCost: constants.EmptyString},
+		{
+			name:         "model unpriced",
+			reportCost:   true,
+			responseBody: usageResponseBody,
+			modelPricing: map[string]proxy.ModelPrice{proxy.ModelNameGPT4o: {InputPerMillionTokens: 2.5, OutputPerMillionTokens: 10}},
+			expectedCost: constants.EmptyString,
+		},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			applicationServer := newIntegrationServer(subTest, newStaticOpenAIHandler(testCase.responseBody), func(configuration *proxy.Configuration) {
+				configuration.ModelPricing = testCase.modelPricing
+				configuration.ReportCost = testCase.reportCost
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != integrationOKBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+			}
+			if actualCost := httpResponse.Header.Get(estimatedCostHeader); actualCost != testCase.expectedCost {
+				subTest.Fatalf(estimatedCostMismatchFormat, actualCost, testCase.expectedCost)
+			}
+		})
+	}
+}
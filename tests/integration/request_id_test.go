@@ -0,0 +1,147 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/proxy"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const (
+	// requestIDHeader carries the correlation id of a request.
+	requestIDHeader = "X-Request-ID"
+	// metadataField holds the metadata object in the captured payload.
+	metadataField = "metadata"
+	// proxyRequestIDField holds the correlation id inside the metadata object.
+	proxyRequestIDField = "proxy_request_id"
+	// requestIDMismatchFormat reports a metadata id that differs from the response header.
+	requestIDMismatchFormat = "metadata=%v want %s=%q"
+	// requestIDPresenceFormat reports metadata that should be absent or a correlation id header that is missing.
+	requestIDPresenceFormat = "request id header=%q metadata=%v want header only"
+	// suppliedRequestID is the correlation id a client supplies.
+	suppliedRequestID = "client-request-42"
+	// logFieldRequestID is the structured field holding the correlation id.
+	logFieldRequestID = "request_id"
+	// logEventOpenAIResponse is the log message the OpenAI client records for an upstream response.
+	logEventOpenAIResponse = "OpenAI API response"
+	// requestIDEchoFormat reports an unexpected correlation id in the response header.
+	requestIDEchoFormat = "request id header=%q want %q"
+	// requestIDLogFormat reports log entries missing the correlation id.
+	requestIDLogFormat = "log entry %q has request_id=%v want %q"
+)
+
+// TestRequestIDInUpstreamMetadata verifies that the correlation id reaches the upstream metadata only when enabled,
+// while it is echoed in the response header either way.
+func TestRequestIDInUpstreamMetadata(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name          string
+		sendRequestID bool
+	}{
+		{name: "enabled", sendRequestID: true},
+		{name: "disabled", sendRequestID: false},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.SendRequestIDToUpstream = testCase.sendRequestID
+			})
+
+			httpResponse, requestError := http.Get(server.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			requestID := httpResponse.Header.Get(requestIDHeader)
+			metadataValue, hasMetadata := (*capturedPayload)[metadataField]
+			if !testCase.sendRequestID {
+				if requestID == constants.EmptyString || hasMetadata {
+					subTest.Fatalf(requestIDPresenceFormat, requestID, metadataValue)
+				}
+				return
+			}
+			metadataObject, isObject := metadataValue.(map[string]any)
+			if requestID == constants.EmptyString || !isObject || metadataObject[proxyRequestIDField] != requestID {
+				subTest.Fatalf(requestIDMismatchFormat, metadataValue, proxyRequestIDField, requestID)
+			}
+		})
+	}
+}
+
+// TestRequestIDPropagation verifies that a supplied X-Request-ID is echoed and attached to the request and upstream
+// log events, that a malformed one is replaced, and that a UUID is generated when the header is omitted.
+func TestRequestIDPropagation(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	generatedRequestIDPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	testCases := []struct {
+		name           string
+		suppliedID     string
+		expectSupplied bool
+	}{
+		{name: "supplied", suppliedID: suppliedRequestID, expectSupplied: true},
+		{name: "omitted"},
+		{name: "malformed", suppliedID: "bad id\tvalue"},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, _ := makeHTTPClient(subTest, false, endpoints)
+			configureProxy(subTest, client, endpoints)
+			observedCore, observedLogs := observer.New(zapcore.DebugLevel)
+			router, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+				ServiceSecret: serviceSecretValue,
+				OpenAIKey:     openAIKeyValue,
+				LogLevel:      logLevelDebug,
+				WorkerCount:   1,
+				QueueSize:     4,
+				Endpoints:     endpoints,
+			}, zap.New(observedCore).Sugar())
+			if buildRouterError != nil {
+				subTest.Fatalf(buildRouterFailedFormat, buildRouterError)
+			}
+			server := httptest.NewServer(router)
+			subTest.Cleanup(server.Close)
+
+			httpRequest, _ := http.NewRequest(http.MethodGet, server.URL+"?prompt=ping&key="+serviceSecretValue, nil)
+			if testCase.suppliedID != constants.EmptyString {
+				httpRequest.Header.Set(requestIDHeader, testCase.suppliedID)
+			}
+			httpResponse, requestError := http.DefaultClient.Do(httpRequest)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			requestID := httpResponse.Header.Get(requestIDHeader)
+			if testCase.expectSupplied && requestID != testCase.suppliedID {
+				subTest.Fatalf(requestIDEchoFormat, requestID, testCase.suppliedID)
+			}
+			if !testCase.expectSupplied && !generatedRequestIDPattern.MatchString(requestID) {
+				subTest.Fatalf(requestIDEchoFormat, requestID, "generated UUID")
+			}
+			for _, loggedMessage := range []string{logEventResponseSent, logEventOpenAIResponse} {
+				loggedEntries := observedLogs.FilterMessage(loggedMessage).All()
+				if len(loggedEntries) == 0 {
+					subTest.Fatalf(requestIDLogFormat, loggedMessage, nil, requestID)
+				}
+				if loggedID := loggedEntries[0].ContextMap()[logFieldRequestID]; loggedID != requestID {
+					subTest.Fatalf(requestIDLogFormat, loggedMessage, loggedID, requestID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// responseCacheUpstreamCallsFormat reports an unexpected number of upstream calls.
+	responseCacheUpstreamCallsFormat = "upstream calls=%d want=%d"
+)
+
+// TestResponseCache verifies that identical requests are answered from the cache and different prompts are not.
+func TestResponseCache(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name          string
+		cacheSize     int
+		prompts       []string
+		expectedCalls int32
+	}{
+		{name: "identical requests hit the cache", cacheSize: 8, prompts: []string{"ping", "ping"}, expectedCalls: 1},
+		{name: "different prompts miss the cache", cacheSize: 8, prompts: []string{"ping", "pong"}, expectedCalls: 2},
+		{name: "disabled cache", cacheSize: 0, prompts: []string{"ping", "ping"}, expectedCalls: 2},
+		{name: "evicted entry", cacheSize: 1, prompts: []string{"ping", "pong", "ping"}, expectedCalls: 3},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			var upstreamCalls atomic.Int32
+			openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				upstreamCalls.Add(1)
+				responseWriter.Header().Set("Content-Type", contentTypeJSON)
+				_, _ = io.WriteString(responseWriter, completedResponseBody)
+			})
+			applicationServer := newIntegrationServer(subTest, openAIHandler, func(configuration *proxy.Configuration) {
+				configuration.ResponseCacheSize = testCase.cacheSize
+			})
+
+			for _, prompt := range testCase.prompts {
+				httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=" + prompt + "&key=" + serviceSecretValue)
+				if requestError != nil {
+					subTest.Fatalf(requestErrorFormat, requestError)
+				}
+				responseBytes, _ := io.ReadAll(httpResponse.Body)
+				_ = httpResponse.Body.Close()
+				if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != integrationOKBody {
+					subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+				}
+			}
+			if upstreamCalls.Load() != testCase.expectedCalls {
+				subTest.Fatalf(responseCacheUpstreamCallsFormat, upstreamCalls.Load(), testCase.expectedCalls)
+			}
+		})
+	}
+}
+
+// TestResponseCacheHitReportsNoUsage verifies that only the request answered upstream reports token usage, since a
+// cache hit consumes no tokens.
+func TestResponseCacheHitReportsNoUsage(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		responseWriter.Header().Set("Content-Type", contentTypeJSON)
+		_, _ = io.WriteString(responseWriter, usageResponseBody)
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.ResponseCacheSize = 8
+	})
+
+	for _, expectedTotalTokens := range []string{"17", ""} {
+		httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+		if requestError != nil {
+			testingInstance.Fatalf(requestErrorFormat, requestError)
+		}
+		_ = httpResponse.Body.Close()
+		if totalTokens := httpResponse.Header.Get(usageTotalTokensHeader); totalTokens != expectedTotalTokens {
+			testingInstance.Fatalf(usageHeaderMismatchFormat, usageTotalTokensHeader, totalTokens, expectedTotalTokens)
+		}
+	}
+}
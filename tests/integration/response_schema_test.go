@@ -0,0 +1,84 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// responseSchemaQueryParameter carries the JSON schema for structured output.
+	responseSchemaQueryParameter = "response_schema"
+	// responseSchemaValue is the JSON schema sent in the test.
+	responseSchemaValue = `{"type":"object","properties":{"answer":{"type":"string"}},"required":["answer"]}`
+	// textField names the text settings in the captured payload.
+	textField = "text"
+	// textFormatMismatchFormat reports an unexpected text object in the captured payload.
+	textFormatMismatchFormat = "text=%v want format with schema %s"
+	// textPresentFormat reports a text object sent without a response schema.
+	textPresentFormat = "text must be omitted without response_schema, got: %v"
+)
+
+// TestResponseSchemaStructuredOutput verifies that response_schema is forwarded as a json_schema text format, that
+// plain text stays the default, and that a schema that is not a JSON object is rejected.
+func TestResponseSchemaStructuredOutput(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		responseSchema string
+		expectedStatus int
+	}{
+		{name: "schema", responseSchema: responseSchemaValue, expectedStatus: http.StatusOK},
+		{name: "absent", responseSchema: "", expectedStatus: http.StatusOK},
+		{name: "invalid json", responseSchema: `{"type":`, expectedStatus: http.StatusBadRequest},
+		{name: "not an object", responseSchema: `["object"]`, expectedStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, nil)
+
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, proxy.ModelNameGPT41)
+			if testCase.responseSchema != "" {
+				queryValues.Set(responseSchemaQueryParameter, testCase.responseSchema)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				return
+			}
+			textObject, textPresent := (*capturedPayload)[textField]
+			if testCase.responseSchema == "" {
+				if textPresent {
+					subTest.Fatalf(textPresentFormat, textObject)
+				}
+				return
+			}
+			textSettings, _ := textObject.(map[string]any)
+			formatObject, _ := textSettings["format"].(map[string]any)
+			forwardedSchema, _ := json.Marshal(formatObject["schema"])
+			var expectedSchema any
+			_ = json.Unmarshal([]byte(testCase.responseSchema), &expectedSchema)
+			expectedSchemaBytes, _ := json.Marshal(expectedSchema)
+			if formatObject["type"] != "json_schema" || string(forwardedSchema) != string(expectedSchemaBytes) {
+				subTest.Fatalf(textFormatMismatchFormat, textObject, testCase.responseSchema)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package integration_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// retryBackoffMaximumAttempts bounds the upstream calls made within the configured retry window.
+	retryBackoffMaximumAttempts = 10
+	// retryBackoffMaximumDuration bounds how long a request may take once retries stop.
+	retryBackoffMaximumDuration = 3 * time.Second
+	// retryBackoffAttemptsFormat reports an upstream call count outside the expected range.
+	retryBackoffAttemptsFormat = "attempts=%d want between %d and %d"
+	// retryBackoffDurationFormat reports a request that kept retrying for too long.
+	retryBackoffDurationFormat = "request took %v want under %v"
+)
+
+// TestRetryBackoffMaxElapsedTime verifies that a short maximum elapsed time bounds the retries of a failing upstream
+// well before the request timeout.
+func TestRetryBackoffMaxElapsedTime(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var attemptMutex sync.Mutex
+	attemptCount := 0
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		_, _ = io.Copy(io.Discard, httpRequest.Body)
+		attemptMutex.Lock()
+		attemptCount++
+		attemptMutex.Unlock()
+		responseWriter.WriteHeader(http.StatusInternalServerError)
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.RequestTimeoutSeconds = 30
+		configuration.RetryInitialIntervalMilliseconds = 50
+		configuration.RetryMultiplier = 2
+		configuration.RetryMaxElapsedMilliseconds = 500
+	})
+
+	requestStart := time.Now()
+	httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, _ := io.ReadAll(httpResponse.Body)
+	if requestDuration := time.Since(requestStart); requestDuration > retryBackoffMaximumDuration {
+		testingInstance.Fatalf(retryBackoffDurationFormat, requestDuration, retryBackoffMaximumDuration)
+	}
+	if httpResponse.StatusCode != http.StatusBadGateway || string(responseBytes) != upstreamRequestErrorMessage {
+		testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusBadGateway, string(responseBytes))
+	}
+	attemptMutex.Lock()
+	defer attemptMutex.Unlock()
+	if attemptCount < minimumExpectedCalls || attemptCount > retryBackoffMaximumAttempts {
+		testingInstance.Fatalf(retryBackoffAttemptsFormat, attemptCount, minimumExpectedCalls, retryBackoffMaximumAttempts)
+	}
+}
+
+// TestRetryBackoffRejectsInvalidSettings verifies that BuildRouter refuses negative intervals and multipliers below one.
+func TestRetryBackoffRejectsInvalidSettings(testingInstance *testing.T) {
+	testCases := []struct {
+		name          string
+		configuration proxy.Configuration
+	}{
+		{name: "negative initial interval", configuration: proxy.Configuration{RetryInitialIntervalMilliseconds: -1}},
+		{name: "negative max elapsed", configuration: proxy.Configuration{RetryMaxElapsedMilliseconds: -1}},
+		{name: "shrinking multiplier", configuration: proxy.Configuration{RetryMultiplier: 0.5}},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			configuration := testCase.configuration
+			configuration.ServiceSecret = serviceSecretValue
+			configuration.OpenAIKey = openAIKeyValue
+			configuration.LogLevel = logLevelDebug
+			configuration.WorkerCount = 1
+			configuration.QueueSize = 1
+			configuration.Endpoints = proxy.NewEndpoints()
+			_, buildRouterError := proxy.BuildRouter(configuration, newLogger(subTest))
+			if !errors.Is(buildRouterError, proxy.ErrInvalidRetryBackoff) {
+				subTest.Fatalf(expectedErrorFormat, proxy.ErrInvalidRetryBackoff, buildRouterError)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// seedQueryParameter requests deterministic sampling.
+	seedQueryParameter = "seed"
+	// seedField names the seed in the captured payload.
+	seedField = "seed"
+	// seedValue is the seed sent in the test.
+	seedValue = "42"
+	// seedMismatchFormat reports an unexpected seed in the captured payload for a model.
+	seedMismatchFormat = "model %s seed=%v want present=%v"
+)
+
+// TestSeedParameter verifies that seed reaches models whose schema allows it, is omitted for other models, and
+// rejects values that are not integers.
+func TestSeedParameter(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		model          string
+		seed           string
+		expectedStatus int
+		expectSeed     bool
+	}{
+		{name: "supported model", model: proxy.ModelNameGPT41, seed: seedValue, expectedStatus: http.StatusOK, expectSeed: true},
+		{name: "unsupported model", model: proxy.ModelNameGPT5Mini, seed: seedValue, expectedStatus: http.StatusOK, expectSeed: false},
+		{name: "absent", model: proxy.ModelNameGPT41, seed: "", expectedStatus: http.StatusOK, expectSeed: false},
+		{name: "not an integer", model: proxy.ModelNameGPT41, seed: "4.2", expectedStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, nil)
+
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, testCase.model)
+			if testCase.seed != "" {
+				queryValues.Set(seedQueryParameter, testCase.seed)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				return
+			}
+			forwardedSeed, seedPresent := (*capturedPayload)[seedField]
+			if seedPresent != testCase.expectSeed || (testCase.expectSeed && forwardedSeed != float64(42)) {
+				subTest.Fatalf(seedMismatchFormat, testCase.model, forwardedSeed, testCase.expectSeed)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// rotatedServiceSecret is an additional secret accepted during rotation.
+	rotatedServiceSecret = "rotated-secret"
+	// unknownServiceSecret is a client key that matches no configured secret.
+	unknownServiceSecret = "unknown-secret"
+)
+
+// TestMultipleServiceSecrets verifies that every configured secret is accepted and any other key is rejected.
+func TestMultipleServiceSecrets(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		presentedKey   string
+		expectedStatus int
+	}{
+		{name: "primary secret", presentedKey: serviceSecretValue, expectedStatus: http.StatusOK},
+		{name: "additional secret", presentedKey: rotatedServiceSecret, expectedStatus: http.StatusOK},
+		{name: "unknown secret", presentedKey: unknownServiceSecret, expectedStatus: http.StatusForbidden},
+	}
+	applicationServer := newIntegrationServer(testingInstance, newStaticOpenAIHandler(completedResponseBody), func(configuration *proxy.Configuration) {
+		configuration.ServiceSecrets = []string{rotatedServiceSecret}
+	})
+
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			requestURL, _ := url.Parse(applicationServer.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, testCase.presentedKey)
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				responseBody, _ := io.ReadAll(httpResponse.Body)
+				subTest.Fatalf(unexpectedStatusFormat, httpResponse.StatusCode, string(responseBody))
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// unlistedModelName names a model missing from the proxy's model table.
+	unlistedModelName = "local-llama-70b"
+	// forwardedModelMismatchFormat reports an unexpected model in the captured payload.
+	forwardedModelMismatchFormat = "forwarded model=%v want %v"
+)
+
+// TestSkipModelValidation verifies that an unlisted model is rejected by default and forwarded to the upstream when
+// SkipModelValidation is set.
+func TestSkipModelValidation(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name                string
+		skipModelValidation bool
+		expectedStatus      int
+	}{
+		{name: "validated", skipModelValidation: false, expectedStatus: http.StatusBadRequest},
+		{name: "skipped", skipModelValidation: true, expectedStatus: http.StatusOK},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.SkipModelValidation = testCase.skipModelValidation
+			})
+
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, unlistedModelName)
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			var forwardedModel any
+			if *capturedPayload != nil {
+				forwardedModel = (*capturedPayload)["model"]
+			}
+			expectedModel := any(nil)
+			if testCase.skipModelValidation {
+				expectedModel = unlistedModelName
+			}
+			if forwardedModel != expectedModel {
+				subTest.Fatalf(forwardedModelMismatchFormat, forwardedModel, expectedModel)
+			}
+		})
+	}
+}
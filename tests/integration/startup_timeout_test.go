@@ -0,0 +1,61 @@
+package integration_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// startupTimeoutSeconds is the startup bound configured for the hanging upstream.
+	startupTimeoutSeconds = 1
+	// startupTimeoutSlack allows for scheduling overhead beyond the configured startup bound.
+	startupTimeoutSlack = 2 * time.Second
+	// startupDurationFormat reports a startup that outlived its bound.
+	startupDurationFormat = "startup took %s want at most %s"
+)
+
+// TestStartupTimeoutBoundsWarmup verifies that a hanging upstream fails startup once the startup timeout elapses.
+func TestStartupTimeoutBoundsWarmup(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	releaseUpstream := make(chan struct{})
+	openAIServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		select {
+		case <-releaseUpstream:
+		case <-httpRequest.Context().Done():
+		}
+	}))
+	testingInstance.Cleanup(openAIServer.Close)
+	testingInstance.Cleanup(func() { close(releaseUpstream) })
+	endpoints := proxy.NewEndpoints()
+	endpoints.SetResponsesURL(openAIServer.URL + integrationResponsesPath)
+	originalClient := proxy.HTTPClient
+	proxy.HTTPClient = openAIServer.Client()
+	testingInstance.Cleanup(func() { proxy.HTTPClient = originalClient })
+
+	startupStart := time.Now()
+	_, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret:         serviceSecretValue,
+		OpenAIKey:             openAIKeyValue,
+		LogLevel:              logLevelDebug,
+		WorkerCount:           1,
+		QueueSize:             4,
+		WarmupEnabled:         true,
+		WarmupFailureFatal:    true,
+		StartupTimeoutSeconds: startupTimeoutSeconds,
+		Endpoints:             endpoints,
+	}, newLogger(testingInstance))
+	startupDuration := time.Since(startupStart)
+	if !errors.Is(buildRouterError, proxy.ErrStartupTimeout) || !errors.Is(buildRouterError, proxy.ErrWarmupFailed) {
+		testingInstance.Fatalf(expectedErrorFormat, proxy.ErrStartupTimeout, buildRouterError)
+	}
+	startupBound := startupTimeoutSeconds*time.Second + startupTimeoutSlack
+	if startupDuration > startupBound {
+		testingInstance.Fatalf(startupDurationFormat, startupDuration, startupBound)
+	}
+}
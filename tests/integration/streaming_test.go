@@ -0,0 +1,132 @@
+package integration_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// streamField identifies the stream request field.
+	streamField = "stream"
+	// contentTypeEventStream is the media type of server-sent event streams.
+	contentTypeEventStream = "text/event-stream"
+	// upstreamDeltaEventFormat renders one upstream output text delta event.
+	upstreamDeltaEventFormat = "event: response.output_text.delta\ndata: {\"type\":\"response.output_text.delta\",\"delta\":%q}\n\n"
+	// upstreamCompletedEvent ends the upstream stream.
+	upstreamCompletedEvent = "event: response.completed\ndata: {\"type\":\"response.completed\",\"response\":{\"status\":\"completed\"}}\n\n"
+	// proxyEventPrefix starts an event name line in the proxied stream.
+	proxyEventPrefix = "event:"
+	// proxyDataPrefix starts a data line in the proxied stream.
+	proxyDataPrefix = "data:"
+	// proxyDeltaEvent labels proxied text increments.
+	proxyDeltaEvent = "delta"
+	// proxyDoneEvent labels the final proxied event.
+	proxyDoneEvent = "done"
+	// streamRelayWait bounds how long the stub waits for the client to observe the first delta.
+	streamRelayWait = 2 * time.Second
+	// streamDeltasMismatchFormat reports unexpected proxied deltas.
+	streamDeltasMismatchFormat = "deltas=%q want=%q"
+	// streamNotIncrementalMessage reports that the first delta only arrived after the upstream stream ended.
+	streamNotIncrementalMessage = "first delta was not relayed before the upstream stream finished"
+	// streamPayloadFormat reports a missing stream flag in the upstream payload.
+	streamPayloadFormat = "upstream payload stream=%v want true"
+	// streamDoneMismatchFormat reports an unexpected done event payload.
+	streamDoneMismatchFormat = "done event data=%q want=%q"
+)
+
+// proxiedEvent is one server-sent event read from the proxy.
+type proxiedEvent struct {
+	name string
+	data string
+}
+
+// TestStreamingRelaysUpstreamDeltas verifies that stream=1 relays each upstream text delta as it arrives.
+func TestStreamingRelaysUpstreamDeltas(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	upstreamDeltas := []string{"Hel", "lo, ", "world"}
+	firstDeltaObserved := make(chan struct{})
+	upstreamPayload := make(chan map[string]any, 1)
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		requestBytes, _ := io.ReadAll(httpRequest.Body)
+		var decodedPayload map[string]any
+		_ = json.Unmarshal(requestBytes, &decodedPayload)
+		upstreamPayload <- decodedPayload
+		responseWriter.Header().Set("Content-Type", contentTypeEventStream)
+		flusher := responseWriter.(http.Flusher)
+		for deltaIndex, upstreamDelta := range upstreamDeltas {
+			_, _ = fmt.Fprintf(responseWriter, upstreamDeltaEventFormat, upstreamDelta)
+			flusher.Flush()
+			if deltaIndex == 0 {
+				select {
+				case <-firstDeltaObserved:
+				case <-time.After(streamRelayWait):
+				}
+			}
+		}
+		_, _ = io.WriteString(responseWriter, upstreamCompletedEvent)
+		flusher.Flush()
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, nil)
+
+	httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&stream=1&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK || !strings.HasPrefix(httpResponse.Header.Get("Content-Type"), contentTypeEventStream) {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+	}
+
+	var proxiedEvents []proxiedEvent
+	var currentEvent proxiedEvent
+	relayedIncrementally := false
+	lineScanner := bufio.NewScanner(httpResponse.Body)
+	for lineScanner.Scan() {
+		line := lineScanner.Text()
+		switch {
+		case strings.HasPrefix(line, proxyEventPrefix):
+			currentEvent.name = strings.TrimPrefix(line, proxyEventPrefix)
+		case strings.HasPrefix(line, proxyDataPrefix):
+			currentEvent.data = strings.TrimPrefix(line, proxyDataPrefix)
+		case line == "":
+			proxiedEvents = append(proxiedEvents, currentEvent)
+			if len(proxiedEvents) == 1 {
+				select {
+				case <-firstDeltaObserved:
+				default:
+					relayedIncrementally = true
+					close(firstDeltaObserved)
+				}
+			}
+			currentEvent = proxiedEvent{}
+		}
+	}
+
+	if !relayedIncrementally {
+		testingInstance.Fatal(streamNotIncrementalMessage)
+	}
+	var relayedDeltas []string
+	for _, event := range proxiedEvents {
+		if event.name == proxyDeltaEvent {
+			relayedDeltas = append(relayedDeltas, event.data)
+		}
+	}
+	if strings.Join(relayedDeltas, "|") != strings.Join(upstreamDeltas, "|") {
+		testingInstance.Fatalf(streamDeltasMismatchFormat, relayedDeltas, upstreamDeltas)
+	}
+	lastEvent := proxiedEvents[len(proxiedEvents)-1]
+	if lastEvent.name != proxyDoneEvent || lastEvent.data != "stop" {
+		testingInstance.Fatalf(streamDoneMismatchFormat, lastEvent.data, "stop")
+	}
+	if decodedPayload := <-upstreamPayload; decodedPayload[streamField] != true {
+		testingInstance.Fatalf(streamPayloadFormat, decodedPayload[streamField])
+	}
+}
@@ -0,0 +1,78 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// stuckResponseIdentifier identifies the session that never advances.
+	stuckResponseIdentifier = "resp_stuck"
+	// stuckSessionBody is the non-terminal state the session reports on creation and on every poll.
+	stuckSessionBody = `{"id":"` + stuckResponseIdentifier + `","status":"in_progress","output":[]}`
+	// continuePathSuffix ends the path of the continue endpoint.
+	continuePathSuffix = "/continue"
+	// synthesizedAnswer is the text of synthesisFinalBody.
+	synthesizedAnswer = "SYNTHESIZED"
+	// stuckSessionPollThreshold is the number of unchanged polls that triggers escalation in the test.
+	stuckSessionPollThreshold = 2
+	// previousResponseMismatchFormat reports an unexpected previous_response_id in the synthesis request.
+	previousResponseMismatchFormat = "previous_response_id=%v want=%v"
+)
+
+// TestStuckSessionEscalatesToSynthesis verifies that a continued session reporting the same state across the
+// configured number of polls is escalated to a synthesis continuation.
+func TestStuckSessionEscalatesToSynthesis(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var captureMutex sync.Mutex
+	var synthesisPayload map[string]any
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		responseWriter.Header().Set("Content-Type", contentTypeJSON)
+		switch {
+		case httpRequest.Method == http.MethodPost && strings.HasSuffix(httpRequest.URL.Path, continuePathSuffix):
+			_, _ = io.WriteString(responseWriter, stuckSessionBody)
+		case httpRequest.Method == http.MethodPost && httpRequest.URL.Path == integrationResponsesPath:
+			var decoded map[string]any
+			_ = json.NewDecoder(httpRequest.Body).Decode(&decoded)
+			if _, isSynthesis := decoded[previousResponseIDField]; isSynthesis {
+				captureMutex.Lock()
+				synthesisPayload = decoded
+				captureMutex.Unlock()
+				_, _ = io.WriteString(responseWriter, synthesisCreatedBody)
+				return
+			}
+			_, _ = io.WriteString(responseWriter, stuckSessionBody)
+		case httpRequest.Method == http.MethodGet && strings.HasSuffix(httpRequest.URL.Path, stuckResponseIdentifier):
+			_, _ = io.WriteString(responseWriter, stuckSessionBody)
+		case httpRequest.Method == http.MethodGet && strings.HasSuffix(httpRequest.URL.Path, synthesisResponseIdentifier):
+			_, _ = io.WriteString(responseWriter, synthesisFinalBody)
+		default:
+			http.NotFound(responseWriter, httpRequest)
+		}
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.StuckSessionPollThreshold = stuckSessionPollThreshold
+	})
+
+	httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, _ := io.ReadAll(httpResponse.Body)
+	if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != synthesizedAnswer {
+		testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+	}
+	captureMutex.Lock()
+	defer captureMutex.Unlock()
+	if synthesisPayload[previousResponseIDField] != stuckResponseIdentifier {
+		testingInstance.Fatalf(previousResponseMismatchFormat, synthesisPayload[previousResponseIDField], stuckResponseIdentifier)
+	}
+}
@@ -0,0 +1,56 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// pendingResponseBody is a response that never leaves the in-progress state.
+	pendingResponseBody = `{"id":"resp_pending","status":"in_progress"}`
+	// budgetRequestTimeoutSeconds is the request budget of the budget fraction test.
+	budgetRequestTimeoutSeconds = 4
+	// budgetPollTimeoutSeconds is a poll timeout far longer than the fraction-limited phase.
+	budgetPollTimeoutSeconds = 30
+	// budgetFraction is the share of the remaining budget granted to the poll phase.
+	budgetFraction = 0.25
+	// budgetCutoffSlack allows for the poll interval and scheduling delays beyond the expected cutoff.
+	budgetCutoffSlack = 1200 * time.Millisecond
+	// budgetElapsedFormat reports a poll phase that outlived its budget.
+	budgetElapsedFormat = "request took %v; want poll phase cut off before %v"
+)
+
+// TestSynthesisBudgetFractionCutsOffPolling verifies that polling stops at the configured fraction of the remaining request budget.
+func TestSynthesisBudgetFractionCutsOffPolling(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		responseWriter.Header().Set("Content-Type", contentTypeJSON)
+		_, _ = io.WriteString(responseWriter, pendingResponseBody)
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.RequestTimeoutSeconds = budgetRequestTimeoutSeconds
+		configuration.UpstreamPollTimeoutSeconds = budgetPollTimeoutSeconds
+		configuration.SynthesisBudgetFraction = budgetFraction
+	})
+
+	requestStart := time.Now()
+	httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	_ = httpResponse.Body.Close()
+	elapsed := time.Since(requestStart)
+
+	expectedCutoff := time.Duration(float64(budgetRequestTimeoutSeconds*time.Second)*budgetFraction) + budgetCutoffSlack
+	if elapsed > expectedCutoff {
+		testingInstance.Fatalf(budgetElapsedFormat, elapsed, expectedCutoff)
+	}
+	if httpResponse.StatusCode != http.StatusGatewayTimeout {
+		testingInstance.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusGatewayTimeout)
+	}
+}
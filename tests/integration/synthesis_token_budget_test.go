@@ -0,0 +1,89 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// synthesisToolPhaseBody is a completed response that only contains a web search call.
+	synthesisToolPhaseBody = `{"id":"resp_tool","status":"completed","output":[{"type":"web_search_call","action":{"query":"budget"}}]}`
+	// synthesisCreatedBody acknowledges the synthesis continuation.
+	synthesisCreatedBody = `{"id":"resp_synth","status":"queued"}`
+	// synthesisFinalBody is returned when polling the synthesis continuation.
+	synthesisFinalBody = `{"id":"resp_synth","status":"completed","output_text":"SYNTHESIZED"}`
+	// synthesisResponseIdentifier identifies the synthesis continuation.
+	synthesisResponseIdentifier = "resp_synth"
+	// previousResponseIDField identifies the previous_response_id request field.
+	previousResponseIDField = "previous_response_id"
+	// maxOutputTokensField identifies the max_output_tokens request field.
+	maxOutputTokensField = "max_output_tokens"
+	// synthesisTokensMismatchFormat reports an unexpected synthesis token budget.
+	synthesisTokensMismatchFormat = "synthesis max_output_tokens=%v want=%v"
+)
+
+// newSynthesisOpenAIHandler returns a stub handler that forces a synthesis continuation and records its payload.
+func newSynthesisOpenAIHandler(synthesisPayload *map[string]any) http.HandlerFunc {
+	var captureMutex sync.Mutex
+	return func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		responseWriter.Header().Set("Content-Type", contentTypeJSON)
+		switch {
+		case httpRequest.Method == http.MethodPost && httpRequest.URL.Path == integrationResponsesPath:
+			requestBytes, _ := io.ReadAll(httpRequest.Body)
+			var decoded map[string]any
+			_ = json.Unmarshal(requestBytes, &decoded)
+			if _, isSynthesis := decoded[previousResponseIDField]; isSynthesis {
+				captureMutex.Lock()
+				*synthesisPayload = decoded
+				captureMutex.Unlock()
+				_, _ = io.WriteString(responseWriter, synthesisCreatedBody)
+				return
+			}
+			_, _ = io.WriteString(responseWriter, synthesisToolPhaseBody)
+		case httpRequest.Method == http.MethodGet && strings.HasSuffix(httpRequest.URL.Path, synthesisResponseIdentifier):
+			_, _ = io.WriteString(responseWriter, synthesisFinalBody)
+		default:
+			http.NotFound(responseWriter, httpRequest)
+		}
+	}
+}
+
+// TestSynthesisTokenBudgetFollowsRequestCeiling verifies that synthesis is not capped below the request's output ceiling.
+func TestSynthesisTokenBudgetFollowsRequestCeiling(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name             string
+		configuredTokens int
+		expectedTokens   float64
+	}{
+		{name: "floor applies to small ceilings", configuredTokens: 512, expectedTokens: 1536},
+		{name: "large ceiling raises synthesis budget", configuredTokens: 4096, expectedTokens: 4096},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			var synthesisPayload map[string]any
+			applicationServer := newIntegrationServer(subTest, newSynthesisOpenAIHandler(&synthesisPayload), func(configuration *proxy.Configuration) {
+				configuration.MaxOutputTokens = testCase.configuredTokens
+			})
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&web_search=1&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				responseBody, _ := io.ReadAll(httpResponse.Body)
+				subTest.Fatalf(unexpectedStatusFormat, httpResponse.StatusCode, string(responseBody))
+			}
+			if synthesisPayload[maxOutputTokensField] != testCase.expectedTokens {
+				subTest.Fatalf(synthesisTokensMismatchFormat, synthesisPayload[maxOutputTokensField], testCase.expectedTokens)
+			}
+		})
+	}
+}
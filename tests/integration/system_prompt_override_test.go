@@ -0,0 +1,66 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// systemPromptQueryParameter is the name of the system prompt override query parameter.
+	systemPromptQueryParameter = "system_prompt"
+	// configuredSystemPrompt is the system prompt configured on the proxy.
+	configuredSystemPrompt = "CONFIGURED_GUARDRAILS"
+	// overrideSystemPrompt is the system prompt supplied by the client.
+	overrideSystemPrompt = "CLIENT_OVERRIDE"
+	// inputField identifies the input request field.
+	inputField = "input"
+	// inputMismatchFormat reports an unexpected input value in the captured payload.
+	inputMismatchFormat = "input=%q want prefix %q"
+)
+
+// TestSystemPromptOverridePolicy verifies that the system_prompt parameter is honored unless overrides are disabled.
+func TestSystemPromptOverridePolicy(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name            string
+		disableOverride bool
+		expectedPrompt  string
+	}{
+		{name: "override allowed", expectedPrompt: overrideSystemPrompt},
+		{name: "override disabled", disableOverride: true, expectedPrompt: configuredSystemPrompt},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, captured := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.SystemPrompt = configuredSystemPrompt
+				configuration.DisableSystemPromptOverride = testCase.disableOverride
+				configuration.QueueSize = 8
+			})
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(systemPromptQueryParameter, overrideSystemPrompt)
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(getFailedFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			inputValue, _ := (*captured)[inputField].(string)
+			if !strings.HasPrefix(inputValue, testCase.expectedPrompt) {
+				subTest.Fatalf(inputMismatchFormat, inputValue, testCase.expectedPrompt)
+			}
+		})
+	}
+}
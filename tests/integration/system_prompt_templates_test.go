@@ -0,0 +1,73 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// systemPromptNameQueryParameter selects a named system prompt template.
+	systemPromptNameQueryParameter = "system_prompt_name"
+	// supportTemplateName names the system prompt template configured for the test.
+	supportTemplateName = "support"
+	// supportTemplatePrompt is the system prompt of the support template; it contains a comma on purpose.
+	supportTemplatePrompt = "You are a support agent, polite and brief."
+	// unknownTemplateName names no configured template.
+	unknownTemplateName = "sales"
+)
+
+// TestSystemPromptTemplates verifies that system_prompt_name selects a configured template in place of the configured
+// system prompt, that a system_prompt override still wins, and that an unknown name is rejected.
+func TestSystemPromptTemplates(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name                 string
+		templateName         string
+		systemPromptOverride string
+		expectedStatus       int
+		expectedSystemPrompt string
+	}{
+		{name: "named template", templateName: supportTemplateName, expectedStatus: http.StatusOK, expectedSystemPrompt: supportTemplatePrompt},
+		{name: "override wins", templateName: supportTemplateName, systemPromptOverride: overrideSystemPrompt, expectedStatus: http.StatusOK, expectedSystemPrompt: overrideSystemPrompt},
+		{name: "unknown template", templateName: unknownTemplateName, expectedStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, captured := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.SystemPrompt = configuredSystemPrompt
+				configuration.SystemPromptTemplates = map[string]string{supportTemplateName: supportTemplatePrompt}
+			})
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(systemPromptNameQueryParameter, testCase.templateName)
+			if testCase.systemPromptOverride != "" {
+				queryValues.Set(systemPromptQueryParameter, testCase.systemPromptOverride)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				return
+			}
+			inputValue, _ := (*captured)[inputField].(string)
+			expectedInput := testCase.expectedSystemPrompt + "\n\n" + promptValue
+			if inputValue != expectedInput {
+				subTest.Fatalf(templateInputMismatchFormat, inputValue, expectedInput)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// temperatureQueryParameter overrides the sampling temperature for one request.
+	temperatureQueryParameter = "temperature"
+	// temperatureField holds the sampling temperature in the captured payload.
+	temperatureField = "temperature"
+	// temperatureMismatchFormat reports an unexpected temperature in the captured payload.
+	temperatureMismatchFormat = "model=%s temperature present=%t value=%v want present=%t value=%v"
+)
+
+// TestTemperatureOverride verifies that the temperature parameter reaches models that accept it and is ignored otherwise.
+func TestTemperatureOverride(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name                string
+		model               string
+		temperature         string
+		expectedStatus      int
+		expectTemperature   bool
+		expectedTemperature float64
+	}{
+		{name: "gpt-4o applies temperature", model: proxy.ModelNameGPT4o, temperature: "1.3", expectedStatus: http.StatusOK, expectTemperature: true, expectedTemperature: 1.3},
+		{name: "gpt-4o accepts zero", model: proxy.ModelNameGPT4o, temperature: "0", expectedStatus: http.StatusOK, expectTemperature: true, expectedTemperature: 0},
+		{name: "gpt-5 ignores temperature", model: proxy.ModelNameGPT5, temperature: "1.3", expectedStatus: http.StatusOK, expectTemperature: false},
+		{name: "above range", model: proxy.ModelNameGPT4o, temperature: "2.5", expectedStatus: http.StatusBadRequest},
+		{name: "negative", model: proxy.ModelNameGPT4o, temperature: "-0.1", expectedStatus: http.StatusBadRequest},
+		{name: "not a number", model: proxy.ModelNameGPT4o, temperature: "warm", expectedStatus: http.StatusBadRequest},
+		{name: "NaN", model: proxy.ModelNameGPT4o, temperature: "NaN", expectedStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, nil)
+
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, testCase.model)
+			queryValues.Set(temperatureQueryParameter, testCase.temperature)
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				return
+			}
+			temperatureValue, hasTemperature := (*capturedPayload)[temperatureField]
+			if hasTemperature != testCase.expectTemperature || (hasTemperature && temperatureValue != testCase.expectedTemperature) {
+				subTest.Fatalf(temperatureMismatchFormat, testCase.model, hasTemperature, temperatureValue, testCase.expectTemperature, testCase.expectedTemperature)
+			}
+		})
+	}
+}
@@ -93,10 +93,9 @@ func (roundTripper roundTripperFunc) RoundTrip(httpRequest *http.Request) (*http
 	return roundTripper(httpRequest)
 }
 
-// newOpenAIServer returns a stub OpenAI server yielding the provided body and optionally capturing requests.
-func newOpenAIServer(testingInstance *testing.T, responseText string, captureTarget *any) *httptest.Server {
-	testingInstance.Helper()
-	server := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+// newOpenAIHandler returns a stub OpenAI handler yielding the provided body and optionally capturing requests.
+func newOpenAIHandler(responseText string, captureTarget *any) http.HandlerFunc {
+	return func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
 		switch httpRequest.URL.Path {
 		case integrationModelsPath:
 			responseWriter.Header().Set("Content-Type", contentTypeJSON)
@@ -111,35 +110,66 @@ func newOpenAIServer(testingInstance *testing.T, responseText string, captureTar
 		default:
 			http.NotFound(responseWriter, httpRequest)
 		}
-	}))
-	return server
+	}
 }
 
-// newIntegrationServer builds the application server pointing at the stub OpenAI server.
-func newIntegrationServer(testingInstance *testing.T, openAIServer *httptest.Server) *httptest.Server {
+// newIntegrationServer builds the application server in front of a stub OpenAI server running upstreamHandler. The
+// configuration uses the integration secrets, one worker and a queue of four; configure, when not nil, adjusts it before
+// the router is built.
+func newIntegrationServer(testingInstance *testing.T, upstreamHandler http.Handler, configure func(*proxy.Configuration)) *httptest.Server {
 	testingInstance.Helper()
+	openAIServer := httptest.NewServer(upstreamHandler)
+	testingInstance.Cleanup(openAIServer.Close)
 	endpoints := proxy.NewEndpoints()
 	endpoints.SetModelsURL(openAIServer.URL + integrationModelsPath)
 	endpoints.SetResponsesURL(openAIServer.URL + integrationResponsesPath)
 	originalClient := proxy.HTTPClient
 	proxy.HTTPClient = openAIServer.Client()
 	testingInstance.Cleanup(func() { proxy.HTTPClient = originalClient })
-	loggerInstance, _ := zap.NewDevelopment()
-	testingInstance.Cleanup(func() { _ = loggerInstance.Sync() })
-	router, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+	configuration := proxy.Configuration{
 		ServiceSecret: integrationServiceSecret,
 		OpenAIKey:     integrationOpenAIKey,
 		LogLevel:      logLevelDebug,
 		WorkerCount:   1,
 		QueueSize:     4,
 		Endpoints:     endpoints,
-	}, loggerInstance.Sugar())
+	}
+	if configure != nil {
+		configure(&configuration)
+	}
+	router, buildRouterError := proxy.BuildRouter(configuration, newLogger(testingInstance))
 	if buildRouterError != nil {
 		testingInstance.Fatalf(buildRouterErrorFormat, buildRouterError)
 	}
-	server := httptest.NewServer(router)
-	testingInstance.Cleanup(server.Close)
-	return server
+	applicationServer := httptest.NewServer(router)
+	testingInstance.Cleanup(applicationServer.Close)
+	return applicationServer
+}
+
+// newRouterServer builds the application server with client standing in for OpenAI through configureProxy. The
+// configuration uses the test secrets, one worker and a queue of four; configure, when not nil, adjusts it before the
+// router is built. endpoints must be the instance client matches request URLs against.
+func newRouterServer(testingInstance *testing.T, client *http.Client, endpoints *proxy.Endpoints, configure func(*proxy.Configuration)) *httptest.Server {
+	testingInstance.Helper()
+	configureProxy(testingInstance, client, endpoints)
+	configuration := proxy.Configuration{
+		ServiceSecret: serviceSecretValue,
+		OpenAIKey:     openAIKeyValue,
+		LogLevel:      logLevelDebug,
+		WorkerCount:   1,
+		QueueSize:     4,
+		Endpoints:     endpoints,
+	}
+	if configure != nil {
+		configure(&configuration)
+	}
+	router, buildRouterError := proxy.BuildRouter(configuration, newLogger(testingInstance))
+	if buildRouterError != nil {
+		testingInstance.Fatalf(buildRouterFailedFormat, buildRouterError)
+	}
+	applicationServer := httptest.NewServer(router)
+	testingInstance.Cleanup(applicationServer.Close)
+	return applicationServer
 }
 
 // makeHTTPClient returns a stub HTTP client capturing payloads and returning canned responses.
@@ -0,0 +1,70 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// timeoutQueryParameter overrides the request timeout for one request.
+	timeoutQueryParameter = "timeout"
+	// timeoutParameterGlobalSeconds is the global request timeout, long enough for the delayed upstream to answer.
+	timeoutParameterGlobalSeconds = 5
+	// timeoutParameterMaxSeconds is the largest timeout a request may ask for in the test.
+	timeoutParameterMaxSeconds = 10
+	// timeoutElapsedFormat reports a response that arrived no earlier than the upstream delay.
+	timeoutElapsedFormat = "elapsed=%v want less than %v"
+)
+
+// TestTimeoutParameterOverridesRequestTimeout verifies that the timeout query parameter shortens the request deadline
+// below the global default and that values outside the configured bounds, or above the global default, are rejected.
+func TestTimeoutParameterOverridesRequestTimeout(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		timeout        string
+		expectedStatus int
+	}{
+		{name: "short timeout", timeout: "1", expectedStatus: http.StatusGatewayTimeout},
+		{name: "below minimum", timeout: "0", expectedStatus: http.StatusBadRequest},
+		{name: "above maximum", timeout: "11", expectedStatus: http.StatusBadRequest},
+		{name: "above request timeout", timeout: "6", expectedStatus: http.StatusBadRequest},
+		{name: "not a number", timeout: "soon", expectedStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client := makeTimeoutHTTPClient(subTest, endpoints)
+			server := newRouterServer(subTest, client, endpoints, func(configuration *proxy.Configuration) {
+				configuration.QueueSize = 1
+				configuration.RequestTimeoutSeconds = timeoutParameterGlobalSeconds
+				configuration.MaxRequestTimeoutSeconds = timeoutParameterMaxSeconds
+			})
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(timeoutQueryParameter, testCase.timeout)
+			requestURL.RawQuery = queryValues.Encode()
+
+			startInstant := time.Now()
+			httpResponse, requestError := http.Get(requestURL.String())
+			elapsedDuration := time.Since(startInstant)
+			if requestError != nil {
+				subTest.Fatalf(getFailedFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			if elapsedDuration >= timeoutUpstreamDelay {
+				subTest.Fatalf(timeoutElapsedFormat, elapsedDuration, timeoutUpstreamDelay)
+			}
+		})
+	}
+}
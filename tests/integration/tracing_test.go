@@ -0,0 +1,226 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	// tracedResponseIdentifier identifies the upstream response of the traced request.
+	tracedResponseIdentifier = "resp_traced"
+	// tracedPendingBody is the in-progress initial response that makes the proxy continue and poll.
+	tracedPendingBody = `{"id":"` + tracedResponseIdentifier + `","status":"in_progress"}`
+	// tracedCompletedBody is the completed response returned by the poll.
+	tracedCompletedBody = `{"id":"` + tracedResponseIdentifier + `","status":"completed","output_text":"` + integrationOKBody + `","output":[{"type":"message","role":"assistant","content":[{"type":"output_text","text":"` + integrationOKBody + `"}]}]}`
+	// rootSpanName is the span name of a proxied GET request to the root route.
+	rootSpanName = "GET /"
+	// upstreamRequestSpanName names the span around one upstream call.
+	upstreamRequestSpanName = "openai.responses.request"
+	// pollSpanName names the span around polling a response.
+	pollSpanName = "openai.responses.poll"
+	// spanModelAttribute records the model on the root span.
+	spanModelAttribute = "llm_proxy.model"
+	// spanStatusCodeAttribute records the HTTP status code on the root span.
+	spanStatusCodeAttribute = "http.response.status_code"
+	// spanLatencyAttribute records the latency of a span.
+	spanLatencyAttribute = "llm_proxy.latency_ms"
+	// rootSpanWait bounds the wait for the root span, which ends after the response has been written.
+	rootSpanWait = 2 * time.Second
+	// rootSpanMissingFormat reports a missing or duplicated root span.
+	rootSpanMissingFormat = "root spans=%v want one named %q"
+	// spanChildrenFormat reports unexpected children of a span.
+	spanChildrenFormat = "children of %s=%v want %v"
+	// spanAttributeFormat reports an unexpected span attribute.
+	spanAttributeFormat = "span %s attribute %s=%v want %v"
+	// spanTraceFormat reports a span outside the request trace.
+	spanTraceFormat = "span %s belongs to another trace"
+	// upstreamSpanCountFormat reports an unexpected number of upstream request spans.
+	upstreamSpanCountFormat = "upstream spans=%d want %d"
+)
+
+// TestTracingSpanTree verifies that a proxied request yields one root span whose children are the spans of the
+// initial upstream request, the continuation and the poll, with the poll's fetch nested below the poll span.
+func TestTracingSpanTree(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+		if httpRequest.Method == http.MethodGet {
+			_, _ = io.WriteString(responseWriter, tracedCompletedBody)
+			return
+		}
+		_, _ = io.WriteString(responseWriter, tracedPendingBody)
+	})
+	spanExporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter))
+	testingInstance.Cleanup(func() { _ = tracerProvider.Shutdown(testingInstance.Context()) })
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.TracerProvider = tracerProvider
+	})
+
+	requestURL, _ := url.Parse(applicationServer.URL)
+	queryValues := requestURL.Query()
+	queryValues.Set(promptQueryParameter, promptValue)
+	queryValues.Set(keyQueryParameter, serviceSecretValue)
+	queryValues.Set(adaptiveModelQueryParameter, proxy.ModelNameGPT41)
+	requestURL.RawQuery = queryValues.Encode()
+	httpResponse, requestError := http.Get(requestURL.String())
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, _ := io.ReadAll(httpResponse.Body)
+	if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != integrationOKBody {
+		testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+	}
+
+	var rootSpans []tracetest.SpanStub
+	for waitStart := time.Now(); time.Since(waitStart) < rootSpanWait; time.Sleep(10 * time.Millisecond) {
+		rootSpans = rootSpans[:0]
+		for _, endedSpan := range spanExporter.GetSpans() {
+			if !endedSpan.Parent.IsValid() {
+				rootSpans = append(rootSpans, endedSpan)
+			}
+		}
+		if len(rootSpans) > 0 {
+			break
+		}
+	}
+	if len(rootSpans) != 1 || rootSpans[0].Name != rootSpanName {
+		testingInstance.Fatalf(rootSpanMissingFormat, rootSpans, rootSpanName)
+	}
+	rootSpan := rootSpans[0]
+	rootAttributes := make(map[string]any)
+	for _, keyValue := range rootSpan.Attributes {
+		rootAttributes[string(keyValue.Key)] = keyValue.Value.AsInterface()
+	}
+	if rootAttributes[spanModelAttribute] != proxy.ModelNameGPT41 {
+		testingInstance.Fatalf(spanAttributeFormat, rootSpan.Name, spanModelAttribute, rootAttributes[spanModelAttribute], proxy.ModelNameGPT41)
+	}
+	if rootAttributes[spanStatusCodeAttribute] != int64(http.StatusOK) {
+		testingInstance.Fatalf(spanAttributeFormat, rootSpan.Name, spanStatusCodeAttribute, rootAttributes[spanStatusCodeAttribute], http.StatusOK)
+	}
+	if _, latencyRecorded := rootAttributes[spanLatencyAttribute]; !latencyRecorded {
+		testingInstance.Fatalf(spanAttributeFormat, rootSpan.Name, spanLatencyAttribute, nil, "present")
+	}
+
+	endedSpans := spanExporter.GetSpans()
+	childrenOf := func(parentSpan tracetest.SpanStub) ([]string, []tracetest.SpanStub) {
+		var childNames []string
+		var childSpans []tracetest.SpanStub
+		for _, endedSpan := range endedSpans {
+			if endedSpan.Parent.SpanID() == parentSpan.SpanContext.SpanID() {
+				childNames = append(childNames, endedSpan.Name)
+				childSpans = append(childSpans, endedSpan)
+			}
+		}
+		slices.Sort(childNames)
+		return childNames, childSpans
+	}
+	for _, endedSpan := range endedSpans {
+		if endedSpan.SpanContext.TraceID() != rootSpan.SpanContext.TraceID() {
+			testingInstance.Fatalf(spanTraceFormat, endedSpan.Name)
+		}
+	}
+
+	rootChildNames, rootChildren := childrenOf(rootSpan)
+	expectedRootChildren := []string{upstreamRequestSpanName, upstreamRequestSpanName, pollSpanName}
+	slices.Sort(expectedRootChildren)
+	if !slices.Equal(rootChildNames, expectedRootChildren) {
+		testingInstance.Fatalf(spanChildrenFormat, rootSpan.Name, rootChildNames, expectedRootChildren)
+	}
+	for _, rootChild := range rootChildren {
+		childNames, _ := childrenOf(rootChild)
+		expectedChildren := []string(nil)
+		if rootChild.Name == pollSpanName {
+			expectedChildren = []string{upstreamRequestSpanName}
+		}
+		if !slices.Equal(childNames, expectedChildren) {
+			testingInstance.Fatalf(spanChildrenFormat, rootChild.Name, childNames, expectedChildren)
+		}
+	}
+}
+
+// TestTracingSpilledTaskJoinsRequestTrace verifies that the upstream span of a task replayed from the disk overflow
+// queue is a child of its request span instead of the root of a new trace.
+func TestTracingSpilledTaskJoinsRequestTrace(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	releaseGate := make(chan struct{})
+	workerBusy := make(chan struct{}, diskQueueRequestCount)
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		workerBusy <- struct{}{}
+		<-releaseGate
+		responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+		_, _ = io.WriteString(responseWriter, completedResponseBody)
+	})
+	spanExporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter))
+	testingInstance.Cleanup(func() { _ = tracerProvider.Shutdown(testingInstance.Context()) })
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.QueueSize = 1
+		configuration.RequestTimeoutSeconds = 10
+		configuration.DiskQueuePath = testingInstance.TempDir()
+		configuration.DiskQueueMaxEntries = diskQueueRequestCount
+		configuration.TracerProvider = tracerProvider
+	})
+
+	statusCodes := make(chan int, 3)
+	var requestGroup sync.WaitGroup
+	for _, prompt := range []string{diskQueueBusyPrompt, diskQueueQueuedPrompt, promptValue} {
+		requestGroup.Add(1)
+		go func() {
+			defer requestGroup.Done()
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=" + prompt + "&key=" + serviceSecretValue)
+			if requestError != nil {
+				statusCodes <- 0
+				return
+			}
+			_, _ = io.Copy(io.Discard, httpResponse.Body)
+			_ = httpResponse.Body.Close()
+			statusCodes <- httpResponse.StatusCode
+		}()
+		if prompt == diskQueueBusyPrompt {
+			<-workerBusy
+			continue
+		}
+		time.Sleep(diskQueueSettleDelay)
+	}
+	close(releaseGate)
+	requestGroup.Wait()
+	close(statusCodes)
+	for statusCode := range statusCodes {
+		if statusCode != http.StatusOK {
+			testingInstance.Fatalf(statusWantFormat, statusCode, http.StatusOK)
+		}
+	}
+
+	var upstreamSpans []tracetest.SpanStub
+	for waitStart := time.Now(); time.Since(waitStart) < rootSpanWait; time.Sleep(10 * time.Millisecond) {
+		upstreamSpans = upstreamSpans[:0]
+		for _, endedSpan := range spanExporter.GetSpans() {
+			if endedSpan.Name == upstreamRequestSpanName {
+				upstreamSpans = append(upstreamSpans, endedSpan)
+			}
+		}
+		if len(upstreamSpans) == 3 {
+			break
+		}
+	}
+	if len(upstreamSpans) != 3 {
+		testingInstance.Fatalf(upstreamSpanCountFormat, len(upstreamSpans), 3)
+	}
+	for _, upstreamSpan := range upstreamSpans {
+		if !upstreamSpan.Parent.IsValid() {
+			testingInstance.Fatalf(spanTraceFormat, upstreamSpan.Name)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// trailingNewlineResponseBody is a completed upstream response whose text ends with newlines.
+	trailingNewlineResponseBody = `{"id":"resp_newline","status":"completed","output_text":"` + integrationOKBody + `\n\n","output":[{"type":"message","role":"assistant","content":[{"type":"output_text","text":"` + integrationOKBody + `"}]}]}`
+)
+
+// TestTrimTrailingNewline verifies that trailing newlines are removed from plain-text output only when enabled.
+func TestTrimTrailingNewline(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name         string
+		trimNewline  bool
+		expectedBody string
+	}{
+		{name: "enabled", trimNewline: true, expectedBody: integrationOKBody},
+		{name: "disabled", trimNewline: false, expectedBody: integrationOKBody + "\n\n"},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			applicationServer := newIntegrationServer(subTest, newStaticOpenAIHandler(trailingNewlineResponseBody), func(configuration *proxy.Configuration) {
+				configuration.TrimTrailingNewline = testCase.trimNewline
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// truncatedPartialText is the partial answer returned by the truncated first attempt.
+	truncatedPartialText = "PARTIAL"
+	// truncatedFirstAttemptBody is a responses API payload cut short by the output token limit.
+	truncatedFirstAttemptBody = `{"id":"resp_partial","status":"incomplete","incomplete_details":{"reason":"max_output_tokens"},"output_text":"` + truncatedPartialText + `"}`
+	// truncationRetryConfiguredTokens is the configured output budget of the first attempt.
+	truncationRetryConfiguredTokens = 512
+	// truncationAttemptsMismatchFormat reports an unexpected number of upstream attempts.
+	truncationAttemptsMismatchFormat = "upstream attempts=%d want=%d"
+	// truncationBudgetMismatchFormat reports an unexpected retry output budget.
+	truncationBudgetMismatchFormat = "retry max_output_tokens=%v want=%v"
+)
+
+// TestRetryOnLengthTruncation verifies that a truncated answer is retried once with a larger budget when enabled.
+func TestRetryOnLengthTruncation(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name             string
+		retryEnabled     bool
+		expectedAttempts int
+		expectedBody     string
+	}{
+		{name: "retry completes the answer", retryEnabled: true, expectedAttempts: 2, expectedBody: integrationOKBody},
+		{name: "disabled returns the truncated answer", retryEnabled: false, expectedAttempts: 1, expectedBody: truncatedPartialText},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			var captureMutex sync.Mutex
+			var requestedBudgets []any
+			openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				requestBytes, _ := io.ReadAll(httpRequest.Body)
+				var decoded map[string]any
+				_ = json.Unmarshal(requestBytes, &decoded)
+				captureMutex.Lock()
+				requestedBudgets = append(requestedBudgets, decoded[maxOutputTokensField])
+				attemptCount := len(requestedBudgets)
+				captureMutex.Unlock()
+				responseWriter.Header().Set("Content-Type", contentTypeJSON)
+				if attemptCount == 1 {
+					_, _ = io.WriteString(responseWriter, truncatedFirstAttemptBody)
+					return
+				}
+				_, _ = io.WriteString(responseWriter, completedResponseBody)
+			})
+			applicationServer := newIntegrationServer(subTest, openAIHandler, func(configuration *proxy.Configuration) {
+				configuration.MaxOutputTokens = truncationRetryConfiguredTokens
+				configuration.RetryOnLengthTruncation = testCase.retryEnabled
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != testCase.expectedBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+			}
+			captureMutex.Lock()
+			defer captureMutex.Unlock()
+			if len(requestedBudgets) != testCase.expectedAttempts {
+				subTest.Fatalf(truncationAttemptsMismatchFormat, len(requestedBudgets), testCase.expectedAttempts)
+			}
+			if testCase.expectedAttempts > 1 && requestedBudgets[1] != float64(2*truncationRetryConfiguredTokens) {
+				subTest.Fatalf(truncationBudgetMismatchFormat, requestedBudgets[1], 2*truncationRetryConfiguredTokens)
+			}
+		})
+	}
+}
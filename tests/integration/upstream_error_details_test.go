@@ -0,0 +1,69 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// unsupportedParameterMessage is the message of the upstream error object in the test.
+	unsupportedParameterMessage = "Unsupported parameter: 'temperature' is not supported with this model."
+	// unsupportedParameterErrorBody is an upstream error response describing a bad request parameter.
+	unsupportedParameterErrorBody = `{"error":{"message":"` + unsupportedParameterMessage + `","type":"invalid_request_error","param":"temperature","code":"unsupported_parameter"}}`
+)
+
+// TestUpstreamClientErrorDetails verifies that an upstream rejection of the request reaches the client with the
+// upstream message, type and parameter and a client error status, while upstream authentication failures are still
+// reported as a generic 502.
+func TestUpstreamClientErrorDetails(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		upstreamStatus int
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "bad request",
+			upstreamStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "OpenAI rejected the request: " + unsupportedParameterMessage + " (type: invalid_request_error, param: temperature)",
+		},
+		{
+			name:           "unprocessable request",
+			upstreamStatus: http.StatusUnprocessableEntity,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   "OpenAI rejected the request: " + unsupportedParameterMessage + " (type: invalid_request_error, param: temperature)",
+		},
+		{
+			name:           "unauthorized",
+			upstreamStatus: http.StatusUnauthorized,
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   "OpenAI API error",
+		},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+				responseWriter.WriteHeader(testCase.upstreamStatus)
+				_, _ = io.WriteString(responseWriter, unsupportedParameterErrorBody)
+			})
+			applicationServer := newIntegrationServer(subTest, openAIHandler, nil)
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != testCase.expectedStatus || strings.TrimSpace(string(responseBytes)) != testCase.expectedBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, testCase.expectedStatus, string(responseBytes))
+			}
+		})
+	}
+}
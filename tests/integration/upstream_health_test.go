@@ -0,0 +1,80 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// upstreamHealthPath reports whether OpenAI is reachable.
+	upstreamHealthPath = "/healthz/upstream"
+	// upstreamHealthTestCacheMillis keeps the cached probe outcome short enough to expire within the test.
+	upstreamHealthTestCacheMillis = 200
+	// upstreamHealthMismatchFormat reports an unexpected health document.
+	upstreamHealthMismatchFormat = "status=%d upstream_status=%d want status=%d upstream_status=%d"
+	// upstreamProbeCountFormat reports an unexpected number of probes of the models endpoint.
+	upstreamProbeCountFormat = "models endpoint probed %d times, want %d"
+)
+
+// upstreamHealthResponse mirrors the JSON document served by the upstream health endpoint.
+type upstreamHealthResponse struct {
+	Status         string `json:"status"`
+	UpstreamStatus int    `json:"upstream_status"`
+}
+
+// TestUpstreamHealthReflectsModelsEndpoint verifies that the upstream health route answers 200 while the models
+// endpoint returns 200, reuses the cached outcome within the cache duration, and answers 503 with the upstream
+// status once the models endpoint starts failing.
+func TestUpstreamHealthReflectsModelsEndpoint(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var modelsStatus atomic.Int32
+	modelsStatus.Store(http.StatusOK)
+	var probeCount atomic.Int32
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		if httpRequest.URL.Path != integrationModelsPath || httpRequest.Header.Get("Authorization") != "Bearer "+openAIKeyValue {
+			http.NotFound(responseWriter, httpRequest)
+			return
+		}
+		probeCount.Add(1)
+		responseWriter.Header().Set("Content-Type", contentTypeJSON)
+		responseWriter.WriteHeader(int(modelsStatus.Load()))
+		_, _ = io.WriteString(responseWriter, integrationModelListBody)
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.QueueSize = 1
+		configuration.UpstreamHealthCacheMillis = upstreamHealthTestCacheMillis
+	})
+
+	checkHealth := func(expectedStatus int, expectedUpstreamStatus int) {
+		testingInstance.Helper()
+		httpResponse, requestError := http.Get(applicationServer.URL + upstreamHealthPath + "?key=" + serviceSecretValue)
+		if requestError != nil {
+			testingInstance.Fatalf(requestErrorFormat, requestError)
+		}
+		defer httpResponse.Body.Close()
+		var healthDocument upstreamHealthResponse
+		_ = json.NewDecoder(httpResponse.Body).Decode(&healthDocument)
+		if httpResponse.StatusCode != expectedStatus || healthDocument.UpstreamStatus != expectedUpstreamStatus {
+			testingInstance.Fatalf(upstreamHealthMismatchFormat, httpResponse.StatusCode, healthDocument.UpstreamStatus, expectedStatus, expectedUpstreamStatus)
+		}
+	}
+
+	checkHealth(http.StatusOK, http.StatusOK)
+	modelsStatus.Store(http.StatusInternalServerError)
+	checkHealth(http.StatusOK, http.StatusOK)
+	if probes := probeCount.Load(); probes != 1 {
+		testingInstance.Fatalf(upstreamProbeCountFormat, probes, 1)
+	}
+	time.Sleep((upstreamHealthTestCacheMillis + 50) * time.Millisecond)
+	checkHealth(http.StatusServiceUnavailable, http.StatusInternalServerError)
+	if probes := probeCount.Load(); probes != 2 {
+		testingInstance.Fatalf(upstreamProbeCountFormat, probes, 2)
+	}
+}
@@ -0,0 +1,68 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// upstreamLatencyHeader reports the cumulative upstream latency in milliseconds.
+	upstreamLatencyHeader = "X-Upstream-Latency-Ms"
+	// stubbedUpstreamDelay is the artificial latency added by the stub upstream.
+	stubbedUpstreamDelay = 60 * time.Millisecond
+	// upstreamLatencyMismatchFormat reports an unexpected latency header value.
+	upstreamLatencyMismatchFormat = "upstream latency header=%q want at least %d"
+	// upstreamLatencyPresenceFormat reports a latency header that should be absent.
+	upstreamLatencyPresenceFormat = "upstream latency header=%q want absent"
+)
+
+// TestUpstreamLatencyHeader verifies that the upstream latency is reported when enabled.
+func TestUpstreamLatencyHeader(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name          string
+		exposeLatency bool
+	}{
+		{name: "enabled", exposeLatency: true},
+		{name: "disabled", exposeLatency: false},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				time.Sleep(stubbedUpstreamDelay)
+				responseWriter.Header().Set("Content-Type", contentTypeJSON)
+				_, _ = io.WriteString(responseWriter, completedResponseBody)
+			})
+			applicationServer := newIntegrationServer(subTest, openAIHandler, func(configuration *proxy.Configuration) {
+				configuration.ExposeUpstreamLatency = testCase.exposeLatency
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, http.StatusOK)
+			}
+			latencyHeader := httpResponse.Header.Get(upstreamLatencyHeader)
+			if !testCase.exposeLatency {
+				if latencyHeader != constants.EmptyString {
+					subTest.Fatalf(upstreamLatencyPresenceFormat, latencyHeader)
+				}
+				return
+			}
+			reportedMillis, parseError := strconv.ParseInt(latencyHeader, 10, 64)
+			if parseError != nil || reportedMillis < stubbedUpstreamDelay.Milliseconds() {
+				subTest.Fatalf(upstreamLatencyMismatchFormat, latencyHeader, stubbedUpstreamDelay.Milliseconds())
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// upstreamRetryAfterSeconds is the delay announced by the rate-limited upstream stub.
+	upstreamRetryAfterSeconds = "7"
+	// upstreamRateLimitedMessage is the message the proxy reports for an exhausted upstream rate limit.
+	upstreamRateLimitedMessage = "upstream rate limit exceeded"
+	// retryAfterMismatchFormat reports an unexpected Retry-After header.
+	retryAfterMismatchFormat = "Retry-After=%q want %q"
+)
+
+// TestUpstreamRateLimitReturns429 verifies that an upstream that keeps answering 429 is reported to the client as 429
+// with the upstream Retry-After delay, rather than as a generic gateway error.
+func TestUpstreamRateLimitReturns429(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	openAIHandler := http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		responseWriter.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+		responseWriter.Header().Set(retryAfterHeader, upstreamRetryAfterSeconds)
+		responseWriter.WriteHeader(http.StatusTooManyRequests)
+		_, _ = io.WriteString(responseWriter, rateLimitedBody)
+	})
+	applicationServer := newIntegrationServer(testingInstance, openAIHandler, func(configuration *proxy.Configuration) {
+		configuration.RetryInitialIntervalMilliseconds = 10
+		configuration.RetryMaxElapsedMilliseconds = mirrorRetryMilliseconds
+	})
+
+	httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, _ := io.ReadAll(httpResponse.Body)
+	if httpResponse.StatusCode != http.StatusTooManyRequests || !strings.Contains(string(responseBytes), upstreamRateLimitedMessage) {
+		testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusTooManyRequests, string(responseBytes))
+	}
+	if retryAfter := httpResponse.Header.Get(retryAfterHeader); retryAfter != upstreamRetryAfterSeconds {
+		testingInstance.Fatalf(retryAfterMismatchFormat, retryAfter, upstreamRetryAfterSeconds)
+	}
+}
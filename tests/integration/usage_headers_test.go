@@ -0,0 +1,72 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+)
+
+const (
+	// usageInputTokensHeader reports the prompt tokens consumed by a request.
+	usageInputTokensHeader = "X-Usage-Input-Tokens"
+	// usageOutputTokensHeader reports the tokens generated for a request.
+	usageOutputTokensHeader = "X-Usage-Output-Tokens"
+	// usageTotalTokensHeader reports the combined token count of a request.
+	usageTotalTokensHeader = "X-Usage-Total-Tokens"
+	// usageResponseBody is a completed responses API payload carrying a usage object.
+	usageResponseBody = `{"id":"resp_usage","status":"completed","output_text":"` + integrationOKBody + `","usage":{"input_tokens":12,"output_tokens":5,"total_tokens":17}}`
+	// usageHeaderMismatchFormat reports an unexpected usage header value.
+	usageHeaderMismatchFormat = "%s=%q want=%q"
+)
+
+// TestUsageHeaders verifies that token usage reported upstream is exposed in response headers.
+func TestUsageHeaders(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name            string
+		responseBody    string
+		expectedHeaders map[string]string
+	}{
+		{
+			name:         "usage reported",
+			responseBody: usageResponseBody,
+			expectedHeaders: map[string]string{
+				usageInputTokensHeader:  "12",
+				usageOutputTokensHeader: "5",
+				usageTotalTokensHeader:  "17",
+			},
+		},
+		{
+			name:         "usage absent",
+			responseBody: completedResponseBody,
+			expectedHeaders: map[string]string{
+				usageInputTokensHeader:  constants.EmptyString,
+				usageOutputTokensHeader: constants.EmptyString,
+				usageTotalTokensHeader:  constants.EmptyString,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			applicationServer := newIntegrationServer(subTest, newStaticOpenAIHandler(testCase.responseBody), nil)
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + serviceSecretValue)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK || string(responseBytes) != integrationOKBody {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+			}
+			for headerName, expectedValue := range testCase.expectedHeaders {
+				if actualValue := httpResponse.Header.Get(headerName); actualValue != expectedValue {
+					subTest.Fatalf(usageHeaderMismatchFormat, headerName, actualValue, expectedValue)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// verboseQueueSize is the queue capacity used to saturate the proxy.
+	verboseQueueSize = 1
+	// queueFullReportMismatchFormat reports an unexpected queue-full body.
+	queueFullReportMismatchFormat = "queue-full body=%s want %+v"
+	// queueFullResponseCountFormat reports an unexpected number of queue-full responses.
+	queueFullResponseCountFormat = "queue-full responses=%d want 1"
+)
+
+// queueFullResponse mirrors the JSON body of a verbose queue-full response.
+type queueFullResponse struct {
+	Error             string `json:"error"`
+	QueueLength       int    `json:"queue_length"`
+	QueueCapacity     int    `json:"queue_capacity"`
+	RetryAfterSeconds int    `json:"retry_after_seconds"`
+}
+
+// TestVerboseQueueFullReportsQueueMetrics verifies that with VerboseQueueFull the queue-full 503 body reports the
+// queue length, capacity and Retry-After seconds in the negotiated format.
+func TestVerboseQueueFullReportsQueueMetrics(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	endpoints := proxy.NewEndpoints()
+	client := makeDelayedHTTPClient(testingInstance, endpoints)
+	server := newRouterServer(testingInstance, client, endpoints, func(configuration *proxy.Configuration) {
+		configuration.WorkerCount = singleWorkerCount
+		configuration.QueueSize = verboseQueueSize
+		configuration.RequestTimeoutSeconds = requestTimeoutSeconds
+		configuration.VerboseQueueFull = true
+	})
+	requestURL, _ := url.Parse(server.URL)
+	queryValues := requestURL.Query()
+	queryValues.Set(promptQueryParameter, promptValue)
+	queryValues.Set(keyQueryParameter, serviceSecretValue)
+	queryValues.Set(formatQueryParameter, contentTypeJSON)
+	requestURL.RawQuery = queryValues.Encode()
+
+	totalRequests := verboseQueueSize + singleWorkerCount + 1
+	var resultMutex sync.Mutex
+	var queueFullBodies []string
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(totalRequests)
+	for requestIndex := 0; requestIndex < totalRequests; requestIndex++ {
+		go func() {
+			defer waitGroup.Done()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				return
+			}
+			defer httpResponse.Body.Close()
+			if httpResponse.StatusCode != http.StatusServiceUnavailable {
+				return
+			}
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			resultMutex.Lock()
+			queueFullBodies = append(queueFullBodies, string(responseBytes))
+			resultMutex.Unlock()
+		}()
+	}
+	waitGroup.Wait()
+
+	if len(queueFullBodies) != 1 {
+		testingInstance.Fatalf(queueFullResponseCountFormat, len(queueFullBodies))
+	}
+	expectedReport := queueFullResponse{Error: "request queue full", QueueLength: verboseQueueSize, QueueCapacity: verboseQueueSize, RetryAfterSeconds: 5}
+	var decodedReport queueFullResponse
+	if decodeError := json.Unmarshal([]byte(queueFullBodies[0]), &decodedReport); decodeError != nil || decodedReport != expectedReport {
+		testingInstance.Fatalf(queueFullReportMismatchFormat, queueFullBodies[0], expectedReport)
+	}
+}
@@ -0,0 +1,66 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// versionPath reports the build details of the proxy.
+	versionPath = "/version"
+	// defaultBuildVersion is the version reported by a build without a link-time version.
+	defaultBuildVersion = "dev"
+	// unknownBuildDetail is the commit and build time reported by a build without link-time details.
+	unknownBuildDetail = "unknown"
+	// versionDocumentMismatchFormat reports an unexpected version document.
+	versionDocumentMismatchFormat = "version document=%+v want %+v"
+)
+
+// versionDocument mirrors the JSON document served by the version endpoint.
+type versionDocument struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+}
+
+// TestVersionEndpointReportsDefaults verifies that the version route answers without the service secret and reports
+// the defaults of a build without link-time details.
+func TestVersionEndpointReportsDefaults(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+		ServiceSecret: serviceSecretValue,
+		OpenAIKey:     openAIKeyValue,
+		LogLevel:      logLevelDebug,
+		WorkerCount:   1,
+		QueueSize:     1,
+	}, newLogger(testingInstance))
+	if buildRouterError != nil {
+		testingInstance.Fatalf(buildRouterFailedFormat, buildRouterError)
+	}
+	applicationServer := httptest.NewServer(router)
+	testingInstance.Cleanup(applicationServer.Close)
+
+	httpResponse, requestError := http.Get(applicationServer.URL + versionPath)
+	if requestError != nil {
+		testingInstance.Fatalf(requestErrorFormat, requestError)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, _ := io.ReadAll(httpResponse.Body)
+	if httpResponse.StatusCode != http.StatusOK {
+		testingInstance.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+	}
+	var reportedDocument versionDocument
+	if decodeError := json.Unmarshal(responseBytes, &reportedDocument); decodeError != nil {
+		testingInstance.Fatalf(requestErrorFormat, decodeError)
+	}
+	expectedDocument := versionDocument{Version: defaultBuildVersion, Commit: unknownBuildDetail, BuildTime: unknownBuildDetail}
+	if reportedDocument != expectedDocument {
+		testingInstance.Fatalf(versionDocumentMismatchFormat, reportedDocument, expectedDocument)
+	}
+}
@@ -0,0 +1,52 @@
+package integration_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/constants"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// proxyVersionHeader reports the build version of the proxy.
+	proxyVersionHeader = "X-Proxy-Version"
+	// proxyVersionMismatchFormat reports an unexpected version header.
+	proxyVersionMismatchFormat = "%s=%q want present=%t"
+)
+
+// TestProxyVersionHeader verifies that the version header is sent on successful and rejected responses when enabled.
+func TestProxyVersionHeader(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		exposeVersion  bool
+		key            string
+		expectedStatus int
+	}{
+		{name: "authorized", exposeVersion: true, key: serviceSecretValue, expectedStatus: http.StatusOK},
+		{name: "forbidden", exposeVersion: true, key: unknownServiceSecret, expectedStatus: http.StatusForbidden},
+		{name: "disabled", exposeVersion: false, key: serviceSecretValue, expectedStatus: http.StatusOK},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			applicationServer := newIntegrationServer(subTest, newStaticOpenAIHandler(completedResponseBody), func(configuration *proxy.Configuration) {
+				configuration.ExposeVersionHeader = testCase.exposeVersion
+			})
+
+			httpResponse, requestError := http.Get(applicationServer.URL + "?prompt=ping&key=" + testCase.key)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			proxyVersion := httpResponse.Header.Get(proxyVersionHeader)
+			if (proxyVersion != constants.EmptyString) != testCase.exposeVersion {
+				subTest.Fatalf(proxyVersionMismatchFormat, proxyVersionHeader, proxyVersion, testCase.exposeVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package integration_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// warmupRequestCountFormat reports an unexpected number of warm-up requests.
+	warmupRequestCountFormat = "warm-up requests=%d want=%d"
+	// warmupFailureBody is an upstream response without any text.
+	warmupFailureBody = `{"status":"completed","output":[]}`
+)
+
+// TestWarmupIssuesRequestAtStartup verifies that BuildRouter sends a warm-up prompt and honors the failure policy.
+func TestWarmupIssuesRequestAtStartup(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name          string
+		responseBody  string
+		warmupEnabled bool
+		failureFatal  bool
+		expectedCalls int32
+		expectError   bool
+	}{
+		{name: "warm-up disabled", responseBody: `{"output_text":"` + integrationOKBody + `"}`, expectedCalls: 0},
+		{name: "warm-up succeeds", responseBody: `{"output_text":"` + integrationOKBody + `"}`, warmupEnabled: true, expectedCalls: 1},
+		{name: "failure logged only", responseBody: warmupFailureBody, warmupEnabled: true, expectedCalls: 1},
+		{name: "failure is fatal", responseBody: warmupFailureBody, warmupEnabled: true, failureFatal: true, expectedCalls: 1, expectError: true},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			var upstreamCalls atomic.Int32
+			openAIServer := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				upstreamCalls.Add(1)
+				responseWriter.Header().Set("Content-Type", contentTypeJSON)
+				_, _ = io.WriteString(responseWriter, testCase.responseBody)
+			}))
+			subTest.Cleanup(openAIServer.Close)
+			endpoints := proxy.NewEndpoints()
+			endpoints.SetResponsesURL(openAIServer.URL + integrationResponsesPath)
+			originalClient := proxy.HTTPClient
+			proxy.HTTPClient = openAIServer.Client()
+			subTest.Cleanup(func() { proxy.HTTPClient = originalClient })
+
+			_, buildRouterError := proxy.BuildRouter(proxy.Configuration{
+				ServiceSecret:      serviceSecretValue,
+				OpenAIKey:          openAIKeyValue,
+				LogLevel:           logLevelDebug,
+				WorkerCount:        1,
+				QueueSize:          4,
+				WarmupEnabled:      testCase.warmupEnabled,
+				WarmupFailureFatal: testCase.failureFatal,
+				Endpoints:          endpoints,
+			}, newLogger(subTest))
+			if testCase.expectError != errors.Is(buildRouterError, proxy.ErrWarmupFailed) {
+				subTest.Fatalf(expectedErrorFormat, proxy.ErrWarmupFailed, buildRouterError)
+			}
+			if !testCase.expectError && buildRouterError != nil {
+				subTest.Fatalf(buildRouterFailedFormat, buildRouterError)
+			}
+			if upstreamCalls.Load() != testCase.expectedCalls {
+				subTest.Fatalf(warmupRequestCountFormat, upstreamCalls.Load(), testCase.expectedCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temirov/llm-proxy/internal/proxy"
+)
+
+const (
+	// searchLocationQueryParameter narrows web search results to a location.
+	searchLocationQueryParameter = "search_location"
+	// searchContextQueryParameter selects the web search context size.
+	searchContextQueryParameter = "search_context"
+	// webSearchToolMismatchFormat reports an unexpected tool list in the captured payload.
+	webSearchToolMismatchFormat = "tools=%v want %v"
+)
+
+// TestWebSearchLocationAndContext verifies that the search location and context reach the web_search tool object,
+// that they are ignored when web search is off and that malformed values are rejected.
+func TestWebSearchLocationAndContext(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		webSearch      string
+		searchLocation string
+		searchContext  string
+		expectedStatus int
+		expectedTools  any
+	}{
+		{
+			name: "location and context", webSearch: "true", searchLocation: "gb/London", searchContext: "HIGH", expectedStatus: http.StatusOK,
+			expectedTools: []any{map[string]any{
+				"type":                "web_search",
+				"user_location":       map[string]any{"type": "approximate", "country": "GB", "city": "London"},
+				"search_context_size": "high",
+			}},
+		},
+		{
+			name: "country only", webSearch: "true", searchLocation: "US", expectedStatus: http.StatusOK,
+			expectedTools: []any{map[string]any{"type": "web_search", "user_location": map[string]any{"type": "approximate", "country": "US"}}},
+		},
+		{name: "web search off", searchLocation: "not a country", searchContext: "huge", expectedStatus: http.StatusOK},
+		{name: "invalid location", webSearch: "true", searchLocation: "Germany/Berlin", expectedStatus: http.StatusBadRequest},
+		{name: "invalid context", webSearch: "true", searchContext: "huge", expectedStatus: http.StatusBadRequest},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			endpoints := proxy.NewEndpoints()
+			client, capturedPayload := makeHTTPClient(subTest, false, endpoints)
+			server := newRouterServer(subTest, client, endpoints, nil)
+
+			requestURL, _ := url.Parse(server.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			queryValues.Set(adaptiveModelQueryParameter, proxy.ModelNameGPT41)
+			queryValues.Set(searchLocationQueryParameter, testCase.searchLocation)
+			queryValues.Set(searchContextQueryParameter, testCase.searchContext)
+			if testCase.webSearch != "" {
+				queryValues.Set(webSearchQueryParameter, testCase.webSearch)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpResponse, requestError := http.Get(requestURL.String())
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			_ = httpResponse.Body.Close()
+			if httpResponse.StatusCode != testCase.expectedStatus {
+				subTest.Fatalf(statusWantFormat, httpResponse.StatusCode, testCase.expectedStatus)
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				return
+			}
+			if attachedTools := (*capturedPayload)[toolsField]; !reflect.DeepEqual(attachedTools, testCase.expectedTools) {
+				subTest.Fatalf(webSearchToolMismatchFormat, attachedTools, testCase.expectedTools)
+			}
+		})
+	}
+}
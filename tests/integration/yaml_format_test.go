@@ -0,0 +1,67 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// contentTypeYAML is the media type of YAML responses.
+	contentTypeYAML = "application/yaml"
+	// yamlDocumentMismatchFormat reports a YAML body that does not decode to the expected mapping.
+	yamlDocumentMismatchFormat = "yaml document=%v error=%v want %v"
+)
+
+// TestYAMLResponseFormat verifies that YAML can be requested through the format parameter or the Accept header.
+func TestYAMLResponseFormat(testingInstance *testing.T) {
+	gin.SetMode(gin.TestMode)
+	applicationServer := newIntegrationServer(testingInstance, newStaticOpenAIHandler(completedResponseBody), nil)
+
+	testCases := []struct {
+		name         string
+		format       string
+		acceptHeader string
+	}{
+		{name: "format parameter", format: contentTypeYAML},
+		{name: "accept header", acceptHeader: contentTypeYAML},
+	}
+	for _, testCase := range testCases {
+		testingInstance.Run(testCase.name, func(subTest *testing.T) {
+			requestURL, _ := url.Parse(applicationServer.URL)
+			queryValues := requestURL.Query()
+			queryValues.Set(promptQueryParameter, promptValue)
+			queryValues.Set(keyQueryParameter, serviceSecretValue)
+			if testCase.format != "" {
+				queryValues.Set(formatQueryParameter, testCase.format)
+			}
+			requestURL.RawQuery = queryValues.Encode()
+			httpRequest, _ := http.NewRequest(http.MethodGet, requestURL.String(), nil)
+			if testCase.acceptHeader != "" {
+				httpRequest.Header.Set(acceptHeaderName, testCase.acceptHeader)
+			}
+			httpResponse, requestError := http.DefaultClient.Do(httpRequest)
+			if requestError != nil {
+				subTest.Fatalf(requestErrorFormat, requestError)
+			}
+			defer httpResponse.Body.Close()
+			responseBytes, _ := io.ReadAll(httpResponse.Body)
+			if httpResponse.StatusCode != http.StatusOK {
+				subTest.Fatalf(statusWantBodyFormat, httpResponse.StatusCode, http.StatusOK, string(responseBytes))
+			}
+			if contentType := httpResponse.Header.Get("Content-Type"); contentType != contentTypeYAML {
+				subTest.Fatalf(contentTypeMismatchFormat, contentType, contentTypeYAML)
+			}
+			expectedDocument := map[string]string{"request": promptValue, "response": integrationOKBody}
+			var decodedDocument map[string]string
+			decodeError := yaml.Unmarshal(responseBytes, &decodedDocument)
+			if decodeError != nil || len(decodedDocument) != len(expectedDocument) || decodedDocument["request"] != promptValue || decodedDocument["response"] != integrationOKBody {
+				subTest.Fatalf(yamlDocumentMismatchFormat, decodedDocument, decodeError, expectedDocument)
+			}
+		})
+	}
+}
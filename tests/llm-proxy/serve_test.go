@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -174,4 +175,8 @@ func TestEndpoint_ReturnsServiceUnavailableWhenQueueFull(testingInstance *testin
 	if strings.TrimSpace(string(responseBody)) != expectedBody {
 		testingInstance.Fatalf("body=%q want=%q", string(responseBody), expectedBody)
 	}
+	retryAfter := secondResponse.Header.Get("Retry-After")
+	if retrySeconds, parseError := strconv.Atoi(retryAfter); parseError != nil || retrySeconds <= 0 {
+		testingInstance.Fatalf("Retry-After=%q want a positive number of seconds", retryAfter)
+	}
 }